@@ -0,0 +1,61 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// banAllower is an Allower that rejects all connections with a ban message.
+type banAllower struct{}
+
+func (banAllower) Allow(net.Addr, login.IdentityData, login.ClientData) (string, bool) {
+	return "You are banned: griefing.", false
+}
+
+func TestRejectedLoginReceivesReason(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "whitelist.toml")
+	if err := os.WriteFile(path, []byte(`players = ["Alex"]`), 0644); err != nil {
+		t.Fatalf("write whitelist: %v", err)
+	}
+	wl, err := LoadWhitelist(path)
+	if err != nil {
+		t.Fatalf("load whitelist: %v", err)
+	}
+	wl.SetEnabled(true)
+
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	for allower, want := range map[Allower]string{
+		banAllower{}: "You are banned: griefing.",
+		wl:           "You are not whitelisted on this server.",
+	} {
+		srv := Config{Log: log, DisableResourceBuilding: true, Allower: allower}.New()
+		closeWorlds(t, srv)
+
+		var disconnect *packet.Disconnect
+		conn := newLoginConn(uuid.New())
+		conn.written = func(pk packet.Packet) {
+			if d, ok := pk.(*packet.Disconnect); ok {
+				disconnect = d
+			}
+		}
+		if srv.allow(conn) {
+			t.Fatalf("expected %T to reject the login", allower)
+		}
+		if disconnect == nil || disconnect.Message != want || disconnect.HideDisconnectionScreen {
+			t.Fatalf("expected login to be disconnected with %q, got %+v", want, disconnect)
+		}
+		select {
+		case <-conn.closed:
+		default:
+			t.Fatalf("expected rejected connection to be closed")
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/audit"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// auditLog is a ring buffer holding a bounded number of the most recent
+// audit events.
+type auditLog struct {
+	mu     sync.Mutex
+	events []audit.Event
+	next   int
+	full   bool
+}
+
+// newAuditLog creates an auditLog that holds at most size events. If size is
+// 0 or lower, no events are kept.
+func newAuditLog(size int) *auditLog {
+	return &auditLog{events: make([]audit.Event, max(size, 0))}
+}
+
+// add adds an audit.Event to the log, overwriting the oldest event if the log
+// is full.
+func (l *auditLog) add(e audit.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.events) == 0 {
+		return
+	}
+	l.events[l.next] = e
+	if l.next++; l.next == len(l.events) {
+		l.next, l.full = 0, true
+	}
+}
+
+// recent returns up to n of the most recent events in the log, ordered from
+// oldest to newest.
+func (l *auditLog) recent(n int) []audit.Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	count := l.next
+	if l.full {
+		count = len(l.events)
+	}
+	n = min(max(n, 0), count)
+	events := make([]audit.Event, n)
+	for i := range n {
+		events[i] = l.events[(l.next-n+i+len(l.events))%len(l.events)]
+	}
+	return events
+}
+
+// RecentEvents returns up to n of the most recent audit events of the Server,
+// ordered from oldest to newest. The number of events kept is limited by
+// Config.AuditLogSize.
+func (srv *Server) RecentEvents(n int) []audit.Event {
+	return srv.audit.recent(n)
+}
+
+// recordEvent adds an audit.Event of the kind passed to the audit log of the
+// Server.
+func (srv *Server) recordEvent(kind, player, detail string) {
+	srv.audit.add(audit.Event{Time: time.Now(), Kind: kind, Player: player, Detail: detail})
+}
+
+// auditor implements player.Auditor. It records the commands executed by
+// players and the blocks broken by players for which
+// Config.AuditBlockBreaks returns true in the audit log of the Server.
+type auditor struct {
+	srv *Server
+}
+
+// CommandExecuted ...
+func (a auditor) CommandExecuted(p *player.Player, command cmd.Command, args []string) {
+	a.srv.recordEvent("command", p.Name(), strings.TrimSpace("/"+command.Name()+" "+strings.Join(args, " ")))
+}
+
+// BlockBroken ...
+func (a auditor) BlockBroken(p *player.Player, pos cube.Pos, b world.Block) {
+	if a.srv.conf.AuditBlockBreaks == nil || !a.srv.conf.AuditBlockBreaks(p) {
+		return
+	}
+	name, _ := b.EncodeBlock()
+	a.srv.recordEvent("break", p.Name(), fmt.Sprintf("%v at %v in %v", name, pos, p.Tx().World().Name()))
+}
@@ -0,0 +1,33 @@
+// Package audit holds the Event type of the audit log kept by a server, so
+// that packages such as cmd/builtin may present the log without depending on
+// the server package itself.
+package audit
+
+import (
+	"fmt"
+	"time"
+)
+
+// Event is a notable event that happened on a server, such as a player
+// joining or executing a command.
+type Event struct {
+	// Time is the time at which the event happened.
+	Time time.Time
+	// Kind is the kind of the event: "join", "resume", "quit", "command" or
+	// "break".
+	Kind string
+	// Player is the name of the player that caused the event.
+	Player string
+	// Detail holds additional information about the event, such as the
+	// command line executed or the block broken.
+	Detail string
+}
+
+// String returns a human-readable representation of the Event.
+func (e Event) String() string {
+	s := fmt.Sprintf("[%v] %v %v", e.Time.Format(time.DateTime), e.Kind, e.Player)
+	if e.Detail != "" {
+		s += ": " + e.Detail
+	}
+	return s
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+)
+
+// auditCommand is a command used to test the recording of commands in the
+// audit log.
+type auditCommand struct {
+	Amount int `cmd:"amount"`
+}
+
+func (auditCommand) Run(cmd.Source, *cmd.Output, *world.Tx) {}
+
+// commandArgsHandler is a player.Handler recording the arguments of every
+// command executed.
+type commandArgsHandler struct {
+	player.NopHandler
+	args []string
+}
+
+func (h *commandArgsHandler) HandleCommandExecution(_ *player.Context, _ cmd.Command, args []string) {
+	h.args = append(h.args, strings.Join(args, " "))
+}
+
+func TestRecentEvents(t *testing.T) {
+	srv := &Server{audit: newAuditLog(3)}
+	if events := srv.RecentEvents(10); len(events) != 0 {
+		t.Fatalf("expected no events, got %v", events)
+	}
+	for i := range 4 {
+		srv.recordEvent("quit", "Player"+strconv.Itoa(i), "")
+	}
+	events := srv.RecentEvents(10)
+	if len(events) != 3 {
+		t.Fatalf("expected events to be bounded to 3, got %v", events)
+	}
+	for i, e := range events {
+		if want := "Player" + strconv.Itoa(i+1); e.Player != want {
+			t.Fatalf("expected event %v to be of %v, got %v", i, want, e)
+		}
+	}
+	if events = srv.RecentEvents(1); len(events) != 1 || events[0].Player != "Player3" {
+		t.Fatalf("expected only the most recent event, got %v", events)
+	}
+}
+
+func TestAuditedPlayerActions(t *testing.T) {
+	cmd.Register(cmd.New("audittest", "", nil, auditCommand{}))
+
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	audited := true
+	srv := Config{Log: log, DisableResourceBuilding: true, AuditBlockBreaks: func(*player.Player) bool {
+		return audited
+	}}.New()
+	closeWorlds(t, srv)
+
+	p := acceptLogin(srv, uuid.New())
+	if events := srv.RecentEvents(10); len(events) != 1 || events[0].Kind != "join" {
+		t.Fatalf("expected join event, got %v", events)
+	}
+
+	h := &commandArgsHandler{}
+	p.handle.ExecWorld(func(tx *world.Tx, e world.Entity) {
+		pl := e.(*player.Player)
+		pl.Handle(h)
+		pl.SetGameMode(world.GameModeCreative)
+		// Neither commands that could not be parsed nor block breaks by
+		// players that are not audited are recorded.
+		pl.ExecuteCommand("/audittest five")
+		pos := tx.World().Spawn().Add(cube.Pos{1})
+		tx.SetBlock(pos, block.Stone{}, nil)
+		audited = false
+		pl.BreakBlock(pos)
+
+		pl.ExecuteCommand("/audittest 5")
+		tx.SetBlock(pos, block.Stone{}, nil)
+		audited = true
+		pl.BreakBlock(pos)
+	})
+	if len(h.args) != 2 || h.args[0] != "five" || h.args[1] != "5" {
+		t.Fatalf("expected handler to be passed the arguments of both commands, got %q", h.args)
+	}
+	events := srv.RecentEvents(10)
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %v", events)
+	}
+	if e := events[1]; e.Kind != "command" || e.Player != "Steve" || e.Detail != "/audittest 5" {
+		t.Fatalf("expected command event of Steve with arguments, got %v", e)
+	}
+	if e := events[2]; e.Kind != "break" || e.Player != "Steve" || !strings.HasPrefix(e.Detail, "minecraft:stone at") {
+		t.Fatalf("expected block break event of Steve, got %v", e)
+	}
+}
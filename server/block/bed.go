@@ -257,6 +257,5 @@ type RespawnBlock interface {
 
 // supportedFromBelow ...
 func supportedFromBelow(pos cube.Pos, tx *world.Tx) bool {
-	below := pos.Side(cube.FaceDown)
-	return tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx)
+	return tx.FaceSolid(pos.Side(cube.FaceDown), cube.FaceUp)
 }
@@ -0,0 +1,49 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestFaceSolid(t *testing.T) {
+	w := world.Config{Generator: world.NopGenerator{}, Provider: world.NopProvider{}}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		stone, bottom, top := cube.Pos{0, 1, 0}, cube.Pos{0, 3, 0}, cube.Pos{0, 5, 0}
+		tx.SetBlock(stone, Stone{}, nil)
+		tx.SetBlock(bottom, Slab{Block: Stone{}}, nil)
+		tx.SetBlock(top, Slab{Block: Stone{}, Top: true}, nil)
+
+		tests := []struct {
+			pos   cube.Pos
+			face  cube.Face
+			solid bool
+		}{
+			{stone, cube.FaceUp, true},
+			{stone, cube.FaceNorth, true},
+			{bottom, cube.FaceUp, false},
+			{bottom, cube.FaceDown, true},
+			{top, cube.FaceUp, true},
+			{top, cube.FaceDown, false},
+			{cube.Pos{0, 10, 0}, cube.FaceUp, false},
+		}
+		for _, test := range tests {
+			if solid := tx.FaceSolid(test.pos, test.face); solid != test.solid {
+				t.Errorf("expected face %v of %v at %v to be solid=%v", test.face, tx.Block(test.pos), test.pos, test.solid)
+			}
+		}
+
+		if pos, ok := tx.SolidGroundBelow(cube.Pos{0, 20, 0}); !ok || pos != top {
+			t.Errorf("expected solid ground at %v, got %v (%v)", top, pos, ok)
+		}
+		if pos, ok := tx.SolidGroundBelow(top); !ok || pos != stone {
+			t.Errorf("expected solid ground below bottom slab at %v, got %v (%v)", stone, pos, ok)
+		}
+		if _, ok := tx.SolidGroundBelow(stone); ok {
+			t.Errorf("expected no solid ground below %v", stone)
+		}
+	})
+}
@@ -56,6 +56,9 @@ func infinitelyBurning(pos cube.Pos, tx *world.Tx) bool {
 
 // burn attempts to burn a block.
 func (f Fire) burn(from, to cube.Pos, tx *world.Tx, r *rand.Rand, chanceBound int) {
+	if !tx.World().BlockBurn() {
+		return
+	}
 	if flammable, ok := tx.Block(to).(Flammable); ok && r.IntN(chanceBound) < flammable.FlammabilityInfo().Flammability {
 		if r.IntN(f.Age+10) < 5 && !rainingAround(to, tx) {
 			f.spread(from, to, tx, r)
@@ -132,6 +135,9 @@ func (f Fire) tick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
 		}
 	}
 
+	if !tx.World().FireSpread() {
+		return
+	}
 	for y := -1; y <= 4; y++ {
 		randomBound := 100
 		if y > 1 {
@@ -175,6 +181,9 @@ func (f Fire) tick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
 // spread attempts to spread fire from a cube.Pos to another. If the block burn or fire spreading events are cancelled,
 // this might end up not happening.
 func (f Fire) spread(from, to cube.Pos, tx *world.Tx, r *rand.Rand) {
+	if !tx.World().FireSpread() {
+		return
+	}
 	if _, air := tx.Block(to).(Air); !air {
 		ctx := event.C(tx)
 		if tx.World().Handler().HandleBlockBurn(ctx, to); ctx.Cancelled() {
@@ -0,0 +1,61 @@
+package block
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	_ "github.com/df-mc/dragonfly/server/world/biome"
+)
+
+// fireSpreads places fire next to a planks block and ticks it repeatedly,
+// reporting if the fire spread to any other block.
+func fireSpreads(enabled bool) bool {
+	w := world.Config{Generator: world.NopGenerator{}, Provider: world.NopProvider{}}.New()
+	defer w.Close()
+	w.SetFireSpread(enabled)
+	// Keep the planks from burning away and the fire from being extinguished
+	// by rain, so that the fire keeps burning.
+	w.SetBlockBurn(false)
+	w.ApplySettings(func(s *world.Settings) {
+		s.Raining, s.RainTime = false, 1<<30
+	})
+
+	pos, planks := cube.Pos{0, 64, 0}, cube.Pos{1, 64, 0}
+	var spread bool
+	<-w.Exec(func(tx *world.Tx) {
+		tx.SetBlock(pos.Side(cube.FaceDown), Stone{}, nil)
+		tx.SetBlock(planks, Planks{Wood: OakWood()}, nil)
+		tx.SetBlock(pos, Fire{}, nil)
+
+		r := rand.New(rand.NewPCG(1, 2))
+		for range 500 {
+			f, ok := tx.Block(pos).(Fire)
+			if !ok {
+				break
+			}
+			f.ScheduledTick(pos, tx, r)
+		}
+		for x := -1; x <= 1; x++ {
+			for y := -1; y <= 4; y++ {
+				for z := -1; z <= 1; z++ {
+					p := pos.Add(cube.Pos{x, y, z})
+					if _, ok := tx.Block(p).(Fire); ok && p != pos {
+						spread = true
+					}
+				}
+			}
+		}
+	})
+	return spread
+}
+
+func TestFireSpreadDisabled(t *testing.T) {
+	if !fireSpreads(true) {
+		t.Fatalf("expected fire to spread with fire spread enabled")
+	}
+	if fireSpreads(false) {
+		t.Fatalf("expected fire not to spread with fire spread disabled")
+	}
+}
@@ -0,0 +1,33 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestHardenLiquidLavaSourceNextToWater(t *testing.T) {
+	w := world.Config{Generator: world.NopGenerator{}, Provider: world.NopProvider{}}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	<-w.Exec(func(tx *world.Tx) {
+		tx.SetBlock(pos, Lava{Still: true, Depth: 8}, &world.SetOpts{DisableBlockUpdates: true})
+		tx.SetBlock(pos.Side(cube.FaceEast), Water{Still: true, Depth: 8}, &world.SetOpts{DisableBlockUpdates: true})
+
+		b, ok := tx.HardenLiquid(pos)
+		if !ok {
+			t.Fatalf("expected lava source next to water to harden")
+		}
+		if _, ok := b.(Obsidian); !ok {
+			t.Fatalf("expected obsidian to form, got %T", b)
+		}
+		if _, ok := tx.Block(pos).(Obsidian); !ok {
+			t.Fatalf("expected obsidian in the world, got %T", tx.Block(pos))
+		}
+		if _, ok := tx.HardenLiquid(pos.Side(cube.FaceWest)); ok {
+			t.Fatalf("expected air not to harden")
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestMoveBlockChest(t *testing.T) {
+	w := world.Config{Generator: world.NopGenerator{}, Provider: world.NopProvider{}}.New()
+	defer w.Close()
+
+	<-w.Exec(func(tx *world.Tx) {
+		from, to := cube.Pos{0, 10, 0}, cube.Pos{20, 10, 3}
+		c := NewChest()
+		_ = c.inventory.SetItem(4, item.NewStack(item.Diamond{}, 5))
+		tx.SetBlock(from, c, nil)
+
+		if !tx.MoveBlock(from, to, nil) {
+			t.Errorf("expected chest to be moved")
+			return
+		}
+		if _, ok := tx.Block(from).(Air); !ok {
+			t.Errorf("expected air at the original position, got %#v", tx.Block(from))
+		}
+		moved, ok := tx.Block(to).(Chest)
+		if !ok {
+			t.Errorf("expected chest at the new position, got %#v", tx.Block(to))
+			return
+		}
+		if s, _ := moved.Inventory(tx, to).Item(4); s.Count() != 5 {
+			t.Errorf("expected 5 diamonds in the moved chest, got %v", s)
+		}
+		if tx.MoveBlock(from, cube.Pos{0, 11, 0}, nil) {
+			t.Errorf("expected moving air to fail")
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package block
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestNearbyBlockEntities(t *testing.T) {
+	w := world.Config{Generator: world.NopGenerator{}, Provider: world.NopProvider{}}.New()
+	defer w.Close()
+
+	centre := cube.Pos{8, 64, 8}
+	isChest := func(b world.Block) bool {
+		_, ok := b.(Chest)
+		return ok
+	}
+	var found []cube.Pos
+	<-w.Exec(func(tx *world.Tx) {
+		opts := &world.SetOpts{DisableBlockUpdates: true}
+		// Chests within the radius, including one in a neighbouring chunk.
+		tx.SetBlock(centre.Add(cube.Pos{3, 0, 0}), NewChest(), opts)
+		tx.SetBlock(centre.Add(cube.Pos{0, -2, 0}), NewChest(), opts)
+		tx.SetBlock(centre.Add(cube.Pos{-9, 0, 0}), NewChest(), opts)
+		// Chests just outside the radius.
+		tx.SetBlock(centre.Add(cube.Pos{10, 0, 0}), NewChest(), opts)
+		tx.SetBlock(centre.Add(cube.Pos{6, 6, 6}), NewChest(), opts)
+		// A block entity that isn't a chest.
+		tx.SetBlock(centre.Add(cube.Pos{1, 0, 0}), NewBarrel(), opts)
+
+		for _, be := range tx.NearbyBlockEntities(centre, 9, isChest) {
+			found = append(found, be.Pos)
+		}
+		if n := len(tx.NearbyBlockEntities(centre, 9, nil)); n != 4 {
+			t.Errorf("expected 4 block entities without a match func, got %v", n)
+		}
+	})
+	want := []cube.Pos{
+		centre.Add(cube.Pos{0, -2, 0}),
+		centre.Add(cube.Pos{3, 0, 0}),
+		centre.Add(cube.Pos{-9, 0, 0}),
+	}
+	if !slices.Equal(found, want) {
+		t.Fatalf("expected chests %v sorted by distance, got %v", want, found)
+	}
+}
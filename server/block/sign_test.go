@@ -0,0 +1,37 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestBlockNBTSign(t *testing.T) {
+	w := world.Config{Generator: world.NopGenerator{}, Provider: world.NopProvider{}}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	<-w.Exec(func(tx *world.Tx) {
+		tx.SetBlock(pos, Sign{Wood: OakWood()}, nil)
+
+		data, ok := tx.BlockNBT(pos)
+		if !ok {
+			t.Fatalf("expected sign to have NBT data")
+		}
+		data["FrontText"].(map[string]any)["Text"] = "hello"
+		if !tx.SetBlockNBT(pos, data) {
+			t.Fatalf("expected sign NBT to be set")
+		}
+		if s, ok := tx.Block(pos).(Sign); !ok || s.Front.Text != "hello" {
+			t.Fatalf("expected sign with front text %q, got %#v", "hello", tx.Block(pos))
+		}
+
+		if _, ok := tx.BlockNBT(pos.Side(cube.FaceUp)); ok {
+			t.Fatalf("expected air not to have NBT data")
+		}
+		if tx.SetBlockNBT(pos.Side(cube.FaceUp), data) {
+			t.Fatalf("expected setting NBT on air to fail")
+		}
+	})
+}
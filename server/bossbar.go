@@ -0,0 +1,49 @@
+package server
+
+import (
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/bossbar"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+)
+
+// SendBossBar sends a boss bar to the online player with the UUID passed, as
+// if player.Player.SendBossBar was called. Any boss bar previously shown to
+// the player is replaced. False is returned if no player with that UUID is
+// online. tx should be the transaction that the caller is running in, or nil
+// if it is not running in one.
+func (srv *Server) SendBossBar(tx *world.Tx, id uuid.UUID, bar bossbar.BossBar) bool {
+	return srv.withPlayer(tx, id, func(p *player.Player) {
+		p.SendBossBar(bar)
+	})
+}
+
+// RemoveBossBar removes the boss bar shown to the online player with the UUID
+// passed, if any. False is returned if no player with that UUID is online. tx
+// should be the transaction that the caller is running in, or nil if it is not
+// running in one.
+func (srv *Server) RemoveBossBar(tx *world.Tx, id uuid.UUID) bool {
+	return srv.withPlayer(tx, id, func(p *player.Player) {
+		p.RemoveBossBar()
+	})
+}
+
+// withPlayer calls f with the online player with the UUID passed. If the
+// player is in the world of tx, f is called directly. Otherwise, a new
+// transaction is opened in the player's world. False is returned if no player
+// with that UUID is online.
+func (srv *Server) withPlayer(tx *world.Tx, id uuid.UUID, f func(p *player.Player)) bool {
+	handle, ok := srv.Player(id)
+	if !ok {
+		return false
+	}
+	if tx != nil {
+		if e, ok := handle.Entity(tx); ok {
+			f(e.(*player.Player))
+			return true
+		}
+	}
+	return handle.ExecWorld(func(tx *world.Tx, e world.Entity) {
+		f(e.(*player.Player))
+	})
+}
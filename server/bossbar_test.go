@@ -0,0 +1,95 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/player/bossbar"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+func TestSendBossBar(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	srv := Config{Log: log, DisableResourceBuilding: true}.New()
+	closeWorlds(t, srv)
+
+	var (
+		mu     sync.Mutex
+		events []packet.BossEvent
+	)
+	conn := newLoginConn(uuid.New())
+	conn.written = func(pk packet.Packet) {
+		if ev, ok := pk.(*packet.BossEvent); ok {
+			mu.Lock()
+			events = append(events, *ev)
+			mu.Unlock()
+		}
+	}
+	awaitEvent := func(f func(ev packet.BossEvent) bool) {
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			mu.Lock()
+			found := slices.ContainsFunc(events, f)
+			mu.Unlock()
+			if found {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected boss event to be sent to the player")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	acceptConn(srv, conn, srv.World())
+
+	if srv.SendBossBar(nil, uuid.New(), bossbar.New("Boss")) {
+		t.Fatalf("expected boss bar not to be sent to offline player")
+	}
+	if !srv.SendBossBar(nil, conn.id, bossbar.New("Boss").WithHealthPercentage(0.5)) {
+		t.Fatalf("expected boss bar to be sent")
+	}
+	awaitEvent(func(ev packet.BossEvent) bool {
+		return ev.EventType == packet.BossEventShow && ev.BossBarTitle == "Boss" && ev.HealthPercentage == 0.5
+	})
+	// Sending a boss bar hides the previous one first, so forget the events
+	// seen so far before checking that the bar is hidden.
+	mu.Lock()
+	events = nil
+	mu.Unlock()
+
+	// Calling from a transaction of the world of the player must not
+	// deadlock.
+	var removed bool
+	<-srv.World().Exec(func(tx *world.Tx) {
+		removed = srv.RemoveBossBar(tx, conn.id)
+	})
+	if !removed {
+		t.Fatalf("expected boss bar to be removed")
+	}
+	awaitEvent(func(ev packet.BossEvent) bool {
+		return ev.EventType == packet.BossEventHide
+	})
+
+	// Health percentages out of range are clamped rather than rejected.
+	mu.Lock()
+	events = nil
+	mu.Unlock()
+	if !srv.SendBossBar(nil, conn.id, bossbar.New("Boss").WithHealthPercentage(1.5)) {
+		t.Fatalf("expected boss bar to be sent")
+	}
+	awaitEvent(func(ev packet.BossEvent) bool {
+		return ev.EventType == packet.BossEventShow && ev.HealthPercentage == 1
+	})
+	if !srv.SendBossBar(nil, conn.id, bossbar.New("Boss").WithHealthPercentage(-0.5)) {
+		t.Fatalf("expected boss bar to be sent")
+	}
+	awaitEvent(func(ev packet.BossEvent) bool {
+		return ev.EventType == packet.BossEventShow && ev.HealthPercentage == 0
+	})
+}
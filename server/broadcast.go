@@ -0,0 +1,65 @@
+package server
+
+import (
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/title"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// BroadcastPopup sends a popup to all players online on the Server, as if
+// player.Player.SendPopup was called for each of them. The number of players
+// that the popup was sent to is returned. tx should be the transaction that
+// the caller is running in, or nil if it is not running in one. Players that
+// disconnect while the popup is being broadcast are skipped.
+func (srv *Server) BroadcastPopup(tx *world.Tx, a ...any) int {
+	return srv.broadcast(tx, func(p *player.Player) {
+		p.SendPopup(a...)
+	})
+}
+
+// BroadcastTip sends a tip to all players online on the Server, as if
+// player.Player.SendTip was called for each of them. The number of players
+// that the tip was sent to is returned. tx should be the transaction that the
+// caller is running in, or nil if it is not running in one.
+func (srv *Server) BroadcastTip(tx *world.Tx, a ...any) int {
+	return srv.broadcast(tx, func(p *player.Player) {
+		p.SendTip(a...)
+	})
+}
+
+// BroadcastActionBar sends an action bar message to all players online on the
+// Server. The number of players that the message was sent to is returned. tx
+// should be the transaction that the caller is running in, or nil if it is not
+// running in one.
+func (srv *Server) BroadcastActionBar(tx *world.Tx, a ...any) int {
+	t := title.New().WithActionText(a...)
+	return srv.broadcast(tx, func(p *player.Player) {
+		p.SendTitle(t)
+	})
+}
+
+// BroadcastSound plays a world.Sound to all players online on the Server at
+// their own position, regardless of the world they are in, for example to
+// signal the start of a round. Unlike World.PlaySound, players near each other
+// do not hear the sound more than once. The number of players that the sound
+// was played to is returned. tx should be the transaction that the caller is
+// running in, or nil if it is not running in one.
+func (srv *Server) BroadcastSound(tx *world.Tx, s world.Sound) int {
+	return srv.broadcast(tx, func(p *player.Player) {
+		p.PlaySound(s)
+	})
+}
+
+// broadcast calls f for every player online on the Server and returns the
+// number of players that f was called for. Players are visited through
+// Server.Players, so players in the world of tx are reached without opening
+// a new transaction, and players that were closed in the meantime are skipped
+// instead of waited for.
+func (srv *Server) broadcast(tx *world.Tx, f func(p *player.Player)) int {
+	n := 0
+	for p := range srv.Players(tx) {
+		f(p)
+		n++
+	}
+	return n
+}
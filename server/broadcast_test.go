@@ -0,0 +1,55 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+func TestBroadcastSound(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	// Generated chunks could flood the packet queues of the players, so no
+	// terrain is generated.
+	gen := func(world.Dimension) world.Generator { return world.NopGenerator{} }
+	srv := Config{Log: log, DisableResourceBuilding: true, Generator: gen}.New()
+	closeWorlds(t, srv)
+
+	var heard atomic.Int32
+	for _, w := range []*world.World{srv.World(), srv.Nether()} {
+		conn := newLoginConn(uuid.New())
+		conn.written = func(pk packet.Packet) {
+			if ev, ok := pk.(*packet.LevelSoundEvent); ok && ev.SoundType == packet.SoundEventExplode {
+				heard.Add(1)
+			}
+		}
+		acceptConn(srv, conn, w)
+	}
+
+	if n := srv.BroadcastSound(nil, sound.Explosion{}); n != 2 {
+		t.Fatalf("expected sound to be played to 2 players, got %v", n)
+	}
+	// Broadcasting from a transaction must not wait for the transaction of
+	// the players in its own world.
+	var sounds, popups int
+	<-srv.World().Exec(func(tx *world.Tx) {
+		sounds = srv.BroadcastSound(tx, sound.Explosion{})
+		popups = srv.BroadcastPopup(tx, "Round started")
+	})
+	if sounds != 2 || popups != 2 {
+		t.Fatalf("expected sound and popup to be sent to 2 players from a transaction, got %v and %v", sounds, popups)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for heard.Load() < 4 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected players to hear the sound 4 times, got %v", heard.Load())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"iter"
 	"time"
 
+	"github.com/df-mc/dragonfly/server/audit"
 	"github.com/df-mc/dragonfly/server/player"
 	"github.com/df-mc/dragonfly/server/world"
 )
@@ -13,9 +14,12 @@ type serverAdapter interface {
 	MaxPlayerCount() int
 	Close() error
 	World() *world.World
+	Worlds() []*world.World
 	StartTime() time.Time
 	WhitelistEnabled() bool
 	WhitelistEntries() ([]string, error)
 	WhitelistAdd(name string) (bool, error)
 	WhitelistRemove(name string) (bool, error)
+	WhitelistReload() error
+	RecentEvents(n int) []audit.Event
 }
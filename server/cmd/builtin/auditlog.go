@@ -0,0 +1,37 @@
+package builtin
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+type auditLogCommand struct {
+	srv   serverAdapter
+	Count cmd.Optional[int] `cmd:"count"`
+}
+
+func newAuditLogCommand(srv serverAdapter) cmd.Command {
+	return cmd.New("auditlog", "Shows recent server events, such as joins and commands.", nil, auditLogCommand{srv: srv})
+}
+
+func (a auditLogCommand) Run(_ cmd.Source, o *cmd.Output, _ *world.Tx) {
+	n := a.Count.LoadOr(10)
+	if n <= 0 {
+		o.Errort(cmd.MessageParameterInvalid, n)
+		return
+	}
+	events := a.srv.RecentEvents(n)
+	if len(events) == 0 {
+		o.Print("No events were recorded.")
+		return
+	}
+	for _, e := range events {
+		o.Print(e.String())
+	}
+}
+
+func (auditLogCommand) Allow(src cmd.Source) bool {
+	_, isPlayer := src.(*player.Player)
+	return !isPlayer
+}
@@ -0,0 +1,81 @@
+package builtin
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// forceLoadOwner is the owner of forced chunk tickets added using the
+// forceload command.
+const forceLoadOwner = "command"
+
+type forceLoadAddCommand struct {
+	Add    cmd.SubCommand     `cmd:"add"`
+	Name   string             `cmd:"name"`
+	X      int                `cmd:"x"`
+	Z      int                `cmd:"z"`
+	Radius cmd.Optional[uint] `cmd:"radius"`
+}
+
+type forceLoadRemoveCommand struct {
+	Remove cmd.SubCommand `cmd:"remove"`
+	Name   string         `cmd:"name"`
+}
+
+type forceLoadListCommand struct {
+	List cmd.SubCommand `cmd:"list"`
+}
+
+func newForceLoadCommand() cmd.Command {
+	return cmd.New(
+		"forceload",
+		"Manages chunks that are kept loaded.",
+		nil,
+		forceLoadAddCommand{},
+		forceLoadRemoveCommand{},
+		forceLoadListCommand{},
+	)
+}
+
+func (c forceLoadAddCommand) Run(_ cmd.Source, o *cmd.Output, tx *world.Tx) {
+	radius, _ := c.Radius.Load()
+	if radius > world.MaxTicketRadius {
+		o.Errorf("Radius must be at most %d chunks.", world.MaxTicketRadius)
+		return
+	}
+	centre := world.ChunkPos{int32(c.X >> 4), int32(c.Z >> 4)}
+	tx.ForceLoad(forceLoadOwner, c.Name, centre, int(radius))
+	o.Printf("Forcing chunks within %d chunk(s) of %v to stay loaded as %s.", radius, centre, c.Name)
+}
+
+func (forceLoadAddCommand) Allow(src cmd.Source) bool {
+	_, isPlayer := src.(*player.Player)
+	return !isPlayer
+}
+
+func (c forceLoadRemoveCommand) Run(_ cmd.Source, o *cmd.Output, tx *world.Tx) {
+	if !tx.ReleaseForce(forceLoadOwner, c.Name) {
+		o.Errorf("No forced chunks named %s.", c.Name)
+		return
+	}
+	o.Printf("Released forced chunks %s.", c.Name)
+}
+
+func (forceLoadRemoveCommand) Allow(src cmd.Source) bool {
+	_, isPlayer := src.(*player.Player)
+	return !isPlayer
+}
+
+func (forceLoadListCommand) Run(_ cmd.Source, o *cmd.Output, tx *world.Tx) {
+	tickets := tx.World().Tickets()
+	o.Printf("Forced chunk tickets: %d.", len(tickets))
+	for _, t := range tickets {
+		o.Printf("%s/%s: %d chunk(s) around %v", t.Owner, t.Name, t.Radius, t.Center)
+	}
+}
+
+func (forceLoadListCommand) Allow(src cmd.Source) bool {
+	_, isPlayer := src.(*player.Player)
+	return !isPlayer
+}
@@ -19,4 +19,9 @@ func Register(srv serverAdapter) {
 	cmd.Register(newGCCommand(srv))
 	cmd.Register(newWhitelistCommand(srv))
 	cmd.Register(newClearCommand())
+	cmd.Register(newForceLoadCommand())
+	cmd.Register(newSaveAllCommand(srv))
+	cmd.Register(newSaveOffCommand(srv))
+	cmd.Register(newSaveOnCommand(srv))
+	cmd.Register(newAuditLogCommand(srv))
 }
@@ -0,0 +1,86 @@
+package builtin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+type saveAllCommand struct {
+	srv serverAdapter
+}
+
+func newSaveAllCommand(srv serverAdapter) cmd.Command {
+	return cmd.New("save-all", "Saves all worlds to disk.", nil, saveAllCommand{srv: srv})
+}
+
+func (s saveAllCommand) Run(src cmd.Source, o *cmd.Output, _ *world.Tx) {
+	o.Print("Saving worlds...")
+	// Saving a world requires a transaction of that world, which cannot be
+	// opened while the transaction this command runs in is still open, so
+	// the worlds are saved on a separate goroutine.
+	go func() {
+		out := &cmd.Output{}
+		for _, w := range s.srv.Worlds() {
+			if w.ReadOnly() {
+				out.Printf("%v: saving is turned off", dimensionName(w))
+				continue
+			}
+			out.Printf("%v: saved %d chunks", dimensionName(w), w.SaveWithResult())
+		}
+		src.SendCommandOutput(out)
+	}()
+}
+
+func (saveAllCommand) Allow(src cmd.Source) bool {
+	_, isPlayer := src.(*player.Player)
+	return !isPlayer
+}
+
+type saveOffCommand struct {
+	srv serverAdapter
+}
+
+func newSaveOffCommand(srv serverAdapter) cmd.Command {
+	return cmd.New("save-off", "Turns off saving of all worlds, for example to back them up.", nil, saveOffCommand{srv: srv})
+}
+
+func (s saveOffCommand) Run(_ cmd.Source, o *cmd.Output, _ *world.Tx) {
+	for _, w := range s.srv.Worlds() {
+		w.SetReadOnly(true)
+	}
+	o.Print("Saving is now turned off.")
+}
+
+func (saveOffCommand) Allow(src cmd.Source) bool {
+	_, isPlayer := src.(*player.Player)
+	return !isPlayer
+}
+
+type saveOnCommand struct {
+	srv serverAdapter
+}
+
+func newSaveOnCommand(srv serverAdapter) cmd.Command {
+	return cmd.New("save-on", "Turns saving of all worlds back on.", nil, saveOnCommand{srv: srv})
+}
+
+func (s saveOnCommand) Run(_ cmd.Source, o *cmd.Output, _ *world.Tx) {
+	for _, w := range s.srv.Worlds() {
+		w.SetReadOnly(false)
+	}
+	o.Print("Saving is now turned on.")
+}
+
+func (saveOnCommand) Allow(src cmd.Source) bool {
+	_, isPlayer := src.(*player.Player)
+	return !isPlayer
+}
+
+// dimensionName returns the lower case name of the dimension of a world.
+func dimensionName(w *world.World) string {
+	return strings.ToLower(fmt.Sprint(w.Dimension()))
+}
@@ -26,6 +26,11 @@ type whitelistListCommand struct {
 	List cmd.SubCommand `cmd:"list"`
 }
 
+type whitelistReloadCommand struct {
+	srv    serverAdapter
+	Reload cmd.SubCommand `cmd:"reload"`
+}
+
 func newWhitelistCommand(srv serverAdapter) cmd.Command {
 	return cmd.New(
 		"whitelist",
@@ -34,6 +39,7 @@ func newWhitelistCommand(srv serverAdapter) cmd.Command {
 		whitelistAddCommand{srv: srv},
 		whitelistRemoveCommand{srv: srv},
 		whitelistListCommand{srv: srv},
+		whitelistReloadCommand{srv: srv},
 	)
 }
 
@@ -96,3 +102,16 @@ func (c whitelistListCommand) Run(_ cmd.Source, o *cmd.Output, _ *world.Tx) {
 		o.Print(strings.Join(entries, ", "))
 	}
 }
+
+func (c whitelistReloadCommand) Run(_ cmd.Source, o *cmd.Output, _ *world.Tx) {
+	if err := c.srv.WhitelistReload(); err != nil {
+		o.Error(err)
+		return
+	}
+	entries, err := c.srv.WhitelistEntries()
+	if err != nil {
+		o.Error(err)
+		return
+	}
+	o.Printf("Reloaded the whitelist: %d player(s).", len(entries))
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import "sync"
+
+// CaptureCommandOutput wraps the Source passed so that any Output sent to it is buffered instead of being
+// delivered. The function returned drains the buffer, returning all messages and errors sent since the last
+// call merged into a single Output. The buffered Output may then be inspected, rewritten and forwarded to
+// the original Source using Source.SendCommandOutput.
+// Note that the Source returned is not the same value as the Source passed, so Runnables that require a
+// specific Source type (such as a player) through an Allower may refuse to run with it.
+func CaptureCommandOutput(source Source) (Source, func() *Output) {
+	c := &captureSource{Source: source, o: &Output{}}
+	return c, c.drain
+}
+
+// captureSource is a Source that buffers all Output sent to it.
+type captureSource struct {
+	Source
+
+	mu sync.Mutex
+	o  *Output
+}
+
+// SendCommandOutput appends the messages and errors of the Output passed to the buffer.
+func (c *captureSource) SendCommandOutput(o *Output) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.o.messages = append(c.o.messages, o.messages...)
+	c.o.errors = append(c.o.errors, o.errors...)
+}
+
+// drain returns the buffered Output and resets the buffer.
+func (c *captureSource) drain() *Output {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	o := c.o
+	c.o = &Output{}
+	return o
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+type testSource struct{ sent int }
+
+func (s *testSource) Position() mgl64.Vec3 { return mgl64.Vec3{} }
+
+func (s *testSource) SendCommandOutput(*Output) { s.sent++ }
+
+type echo struct {
+	Text Varargs
+}
+
+func (e echo) Run(_ Source, o *Output, _ *world.Tx) {
+	o.Print(string(e.Text))
+}
+
+func TestCaptureCommandOutput(t *testing.T) {
+	src := &testSource{}
+	capture, drain := CaptureCommandOutput(src)
+
+	New("echo", "", nil, echo{}).Execute("hello world", capture, nil)
+	if src.sent != 0 {
+		t.Fatalf("expected captured output not to reach the source, got %v outputs", src.sent)
+	}
+	o := drain()
+	if o.MessageCount() != 1 || o.Messages()[0].String() != "hello world" {
+		t.Fatalf("expected captured message %q, got %v", "hello world", o.Messages())
+	}
+	if o.ErrorCount() != 0 {
+		t.Fatalf("expected no captured errors, got %v", o.Errors())
+	}
+	if o := drain(); o.MessageCount() != 0 || o.ErrorCount() != 0 {
+		t.Fatalf("expected empty output after draining, got %v messages and %v errors", o.MessageCount(), o.ErrorCount())
+	}
+}
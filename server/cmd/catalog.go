@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// CommandInfo holds information on a registered Command, as returned by
+// Catalog.
+type CommandInfo struct {
+	// Name is the name of the command.
+	Name string
+	// Aliases holds the aliases that the command may be called with in
+	// addition to its name.
+	Aliases []string
+	// Description and Usage are the description and usage of the command.
+	Description, Usage string
+	// Overloads holds, for every overload of the command that the Source
+	// passed to Catalog can execute, the hints of its parameters, such as
+	// '<target: target>' or '[count: int]'.
+	Overloads [][]string
+}
+
+// Catalog returns information on all registered commands, sorted by their
+// name. Only overloads that may be executed by the Source passed are included
+// in the parameter hints of each command.
+func Catalog(src Source) []CommandInfo {
+	var infos []CommandInfo
+	for alias, command := range Commands() {
+		if alias != command.Name() {
+			// Every command is registered by its name once, so we skip all
+			// other aliases to avoid duplicates.
+			continue
+		}
+		info := CommandInfo{
+			Name: command.Name(),
+			Aliases: slices.DeleteFunc(slices.Clone(command.Aliases()), func(a string) bool {
+				return a == command.Name()
+			}),
+			Description: command.Description(),
+			Usage:       command.Usage(),
+		}
+		for _, params := range command.Params(src) {
+			hints := make([]string, 0, len(params))
+			for _, p := range params {
+				hints = append(hints, p.Hint())
+			}
+			info.Overloads = append(info.Overloads, hints)
+		}
+		infos = append(infos, info)
+	}
+	slices.SortFunc(infos, func(a, b CommandInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return infos
+}
+
+// Hint returns a short description of the parameter for use in usage texts,
+// such as '<target: target>' for a required parameter or '[count: int]' for an
+// optional one.
+func (p ParamInfo) Hint() string {
+	t := paramTypeName(p.Value, p.Name)
+	if p.Optional {
+		return fmt.Sprintf("[%s: %s]%s", p.Name, t, p.Suffix)
+	}
+	return fmt.Sprintf("<%s: %s>%s", p.Name, t, p.Suffix)
+}
+
+// paramTypeName returns the name of the type of parameter value v, as used in
+// ParamInfo.Hint.
+func paramTypeName(v any, name string) string {
+	switch v.(type) {
+	case int, int8, int16, int32, int64:
+		return "int"
+	case uint, uint8, uint16, uint32, uint64:
+		return "uint"
+	case float32, float64:
+		return "float"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case Varargs:
+		return "text"
+	case []Target, Target:
+		return "target"
+	case mgl64.Vec3:
+		return "x y z"
+	case SubCommand:
+		return name
+	}
+	if param, ok := v.(Parameter); ok {
+		return param.Type()
+	}
+	if enum, ok := v.(Enum); ok {
+		return enum.Type()
+	}
+	return "value"
+}
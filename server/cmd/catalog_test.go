@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+type greet struct {
+	Name  string        `cmd:"name"`
+	Times Optional[int] `cmd:"times"`
+}
+
+func (greet) Run(Source, *Output, *world.Tx) {}
+
+func TestCatalog(t *testing.T) {
+	Register(New("greet", "Greets a player.", []string{"hi", "hello"}, greet{}))
+
+	var info CommandInfo
+	var found int
+	for _, c := range Catalog(&testSource{}) {
+		if c.Name == "greet" {
+			info = c
+			found++
+		}
+	}
+	if found != 1 {
+		t.Fatalf("expected greet to appear once in the catalog, got %v times", found)
+	}
+	if !slices.Equal(info.Aliases, []string{"hi", "hello"}) {
+		t.Fatalf("expected aliases [hi hello], got %v", info.Aliases)
+	}
+	if info.Description != "Greets a player." || info.Usage == "" {
+		t.Fatalf("expected description and usage to be set, got %q and %q", info.Description, info.Usage)
+	}
+	if want := [][]string{{"<name: string>", "[times: int]"}}; len(info.Overloads) != 1 || !slices.Equal(info.Overloads[0], want[0]) {
+		t.Fatalf("expected overloads %v, got %v", want, info.Overloads)
+	}
+}
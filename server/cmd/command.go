@@ -122,8 +122,9 @@ func (cmd Command) Aliases() []string {
 // to be run.
 // If parsing of all Runnables was unsuccessful, a command output with an error message is sent to the Source
 // passed, and the Run method of the Runnables are not called.
+// Execute returns true if one of the Runnables was run without adding an error to its output.
 // The Source passed must not be nil. The method will panic if a nil Source is passed.
-func (cmd Command) Execute(args string, source Source, tx *world.Tx) {
+func (cmd Command) Execute(args string, source Source, tx *world.Tx) bool {
 	if source == nil {
 		panic("execute: invalid command source: source must not be nil")
 	}
@@ -140,7 +141,7 @@ func (cmd Command) Execute(args string, source Source, tx *world.Tx) {
 		if err == nil {
 			// Command was executed successfully: We won't execute any of the other Runnable values passed, as
 			// we've already found an overload that works.
-			return
+			return output.ErrorCount() == 0
 		}
 		if line == nil {
 			// This Runnable was not runnable by the source passed. Only if no error was yet set, we set an
@@ -163,6 +164,7 @@ func (cmd Command) Execute(args string, source Source, tx *world.Tx) {
 		output.Error(leastArgsLeft.SyntaxError())
 	}
 	output.Error(leastErroneous)
+	return false
 }
 
 // ParamInfo holds the information of a parameter in a Runnable. Information of a parameter may be obtained
@@ -10,21 +10,23 @@ import (
 // is expected to include the leading slash. If the command cannot be found, an
 // appropriate error is sent back to the Source. The optional before function may
 // be supplied to intercept execution; returning false from it will stop execution.
-func ExecuteLine(source Source, commandLine string, tx *world.Tx, before func(Command, []string) bool) {
+// ExecuteLine returns true if the command was executed successfully, as reported
+// by Command.Execute.
+func ExecuteLine(source Source, commandLine string, tx *world.Tx, before func(Command, []string) bool) bool {
 	if source == nil {
 		panic("cmd.ExecuteLine: source must not be nil")
 	}
 	commandLine = strings.TrimSpace(commandLine)
 	if commandLine == "" {
-		return
+		return false
 	}
 	args := strings.Split(commandLine, " ")
 	if len(args) == 0 {
-		return
+		return false
 	}
 	name, ok := strings.CutPrefix(args[0], "/")
 	if !ok || name == "" {
-		return
+		return false
 	}
 
 	command, ok := ByAlias(name)
@@ -32,10 +34,10 @@ func ExecuteLine(source Source, commandLine string, tx *world.Tx, before func(Co
 		output := &Output{}
 		output.Errort(MessageUnknown, name)
 		source.SendCommandOutput(output)
-		return
+		return false
 	}
 	if before != nil && !before(command, args[1:]) {
-		return
+		return false
 	}
-	command.Execute(strings.Join(args[1:], " "), source, tx)
+	return command.Execute(strings.Join(args[1:], " "), source, tx)
 }
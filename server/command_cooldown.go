@@ -0,0 +1,116 @@
+package server
+
+import (
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// commandCooldowns implements player.CommandLimiter. It keeps track of the
+// last time each player executed a command that has a cooldown.
+type commandCooldowns struct {
+	mu        sync.Mutex
+	durations map[string]time.Duration
+	last      map[uuid.UUID]map[string]time.Time
+	now       func() time.Time
+	// pruneAt is the time after which the entries of all players are next
+	// pruned, so that players that left the server are eventually forgotten.
+	pruneAt time.Time
+}
+
+// newCommandCooldowns creates a commandCooldowns with the cooldowns per
+// command name passed.
+func newCommandCooldowns(durations map[string]time.Duration) *commandCooldowns {
+	c := &commandCooldowns{durations: make(map[string]time.Duration), last: make(map[uuid.UUID]map[string]time.Time), now: time.Now}
+	for name, d := range durations {
+		if d > 0 {
+			c.durations[name] = d
+		}
+	}
+	return c
+}
+
+// set sets the cooldown of the command with the name passed. A cooldown of 0
+// or less removes it.
+func (c *commandCooldowns) set(name string, d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d <= 0 {
+		delete(c.durations, name)
+		return
+	}
+	c.durations[name] = d
+}
+
+// AllowCommand reports if the player with the UUID passed may execute the
+// command with the name passed.
+func (c *commandCooldowns) AllowCommand(id uuid.UUID, name string) (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	d, ok := c.durations[name]
+	if !ok {
+		return 0, true
+	}
+	if t, ok := c.last[id][name]; ok {
+		if wait := t.Add(d).Sub(c.now()); wait > 0 {
+			return wait, false
+		}
+	}
+	return 0, true
+}
+
+// CommandExecuted records the time the player with the UUID passed executed
+// the command with the name passed, starting its cooldown.
+func (c *commandCooldowns) CommandExecuted(id uuid.UUID, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.durations[name]; !ok {
+		return
+	}
+	now := c.now()
+	last := c.last[id]
+	if last == nil {
+		last = make(map[string]time.Time)
+		c.last[id] = last
+	}
+	// Drop entries of commands whose cooldown has passed so that the map
+	// doesn't grow indefinitely.
+	c.prune(last, now)
+	last[name] = now
+
+	if now.After(c.pruneAt) {
+		// Players that executed a command but have not executed one since,
+		// for example because they left the server, are only forgotten here.
+		var longest time.Duration
+		for _, d := range c.durations {
+			longest = max(longest, d)
+		}
+		for player, last := range c.last {
+			if c.prune(last, now); len(last) == 0 {
+				delete(c.last, player)
+			}
+		}
+		c.pruneAt = now.Add(longest)
+	}
+}
+
+// prune removes the entries of commands whose cooldown has passed from the
+// map passed.
+func (c *commandCooldowns) prune(last map[string]time.Time, now time.Time) {
+	maps.DeleteFunc(last, func(n string, t time.Time) bool {
+		return now.Sub(t) >= c.durations[n]
+	})
+}
+
+// SetCommandCooldown sets the minimum time a player has to wait between two
+// executions of the command with the name passed. Passing a cooldown of 0 or
+// less removes the cooldown of the command. Only commands executed
+// successfully start the cooldown, so commands that could not be parsed or
+// that were cancelled through player.Handler.HandleCommandExecution do not.
+// Cooldowns of a player are kept when the player leaves the server, so that
+// they still apply after reconnecting.
+func (srv *Server) SetCommandCooldown(name string, cooldown time.Duration) {
+	srv.cooldowns.set(name, cooldown)
+}
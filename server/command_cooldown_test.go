@@ -0,0 +1,105 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+)
+
+func TestCommandCooldowns(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := newCommandCooldowns(map[string]time.Duration{"spawn": time.Second})
+	c.now = func() time.Time { return now }
+	a, b := uuid.New(), uuid.New()
+
+	if _, ok := c.AllowCommand(a, "spawn"); !ok {
+		t.Fatalf("expected first execution to be allowed")
+	}
+	// Only commands executed successfully start the cooldown.
+	if _, ok := c.AllowCommand(a, "spawn"); !ok {
+		t.Fatalf("expected execution to be allowed before the command was executed")
+	}
+	c.CommandExecuted(a, "spawn")
+	for range 5 {
+		now = now.Add(100 * time.Millisecond)
+		if _, ok := c.AllowCommand(a, "spawn"); ok {
+			t.Fatalf("expected execution within cooldown to be cancelled")
+		}
+	}
+	if wait, _ := c.AllowCommand(a, "spawn"); wait != 500*time.Millisecond {
+		t.Fatalf("expected 500ms left on cooldown, got %v", wait)
+	}
+	if _, ok := c.AllowCommand(b, "spawn"); !ok {
+		t.Fatalf("expected cooldown not to apply to other players")
+	}
+	if _, ok := c.AllowCommand(a, "help"); !ok {
+		t.Fatalf("expected command without cooldown to be allowed")
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if _, ok := c.AllowCommand(a, "spawn"); !ok {
+		t.Fatalf("expected execution after cooldown to be allowed")
+	}
+	c.CommandExecuted(a, "spawn")
+
+	// Players whose cooldowns have all passed are forgotten once another
+	// command is executed.
+	now = now.Add(2 * time.Second)
+	c.CommandExecuted(b, "spawn")
+	if _, ok := c.last[a]; ok {
+		t.Fatalf("expected player with expired cooldowns to be pruned")
+	}
+	if _, ok := c.last[b]; !ok {
+		t.Fatalf("expected player within cooldown not to be pruned")
+	}
+
+	c.set("spawn", 0)
+	if _, ok := c.AllowCommand(a, "spawn"); !ok {
+		t.Fatalf("expected execution to be allowed after removing cooldown")
+	}
+}
+
+// cooldownCommand is a command used to test command cooldowns, counting the
+// number of times it was run.
+type cooldownCommand struct {
+	runs *int
+}
+
+func (c cooldownCommand) Run(cmd.Source, *cmd.Output, *world.Tx) { *c.runs++ }
+
+func TestCommandCooldownExecution(t *testing.T) {
+	var runs int
+	cmd.Register(cmd.New("cooldowntest", "", nil, cooldownCommand{runs: &runs}))
+
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	srv := Config{Log: log, DisableResourceBuilding: true, CommandCooldowns: map[string]time.Duration{"cooldowntest": time.Hour}}.New()
+	closeWorlds(t, srv)
+
+	conn := newLoginConn(uuid.New())
+	p := acceptConn(srv, conn, srv.World())
+	p.handle.ExecWorld(func(tx *world.Tx, e world.Entity) {
+		// A command that could not be parsed does not start the cooldown.
+		e.(*player.Player).ExecuteCommand("/cooldowntest invalid")
+		e.(*player.Player).ExecuteCommand("/cooldowntest")
+		e.(*player.Player).ExecuteCommand("/cooldowntest")
+	})
+	if runs != 1 {
+		t.Fatalf("expected command to run once within its cooldown, got %v runs", runs)
+	}
+
+	// The cooldowns of a player are kept when it reconnects.
+	disconnect(t, srv, conn)
+	p = acceptConn(srv, conn, srv.World())
+	p.handle.ExecWorld(func(tx *world.Tx, e world.Entity) {
+		e.(*player.Player).ExecuteCommand("/cooldowntest")
+	})
+	if runs != 1 {
+		t.Fatalf("expected cooldown to apply after reconnecting, got %v runs", runs)
+	}
+}
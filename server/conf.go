@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
@@ -9,6 +10,7 @@ import (
 	_ "unsafe"
 
 	"strings"
+	"time"
 
 	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/entity"
@@ -69,6 +71,12 @@ type Config struct {
 	// MaxChunkRadius is the maximum view distance that each player may have,
 	// measured in chunks. A chunk radius generally leads to more memory usage.
 	MaxChunkRadius int
+	// DimensionMaxChunkRadius holds a lower maximum view distance for the
+	// worlds of specific dimensions, measured in chunks. Players in such a
+	// world have their view distance limited to the value set here instead of
+	// MaxChunkRadius. Values of 0 or lower, and values above MaxChunkRadius,
+	// are ignored.
+	DimensionMaxChunkRadius map[world.Dimension]int
 	// JoinMessage, QuitMessage and ShutdownMessage are the messages to send for
 	// when a player joins or quits the server and when the server shuts down,
 	// kicking all online players. If set, JoinMessage and QuitMessage must have
@@ -76,6 +84,11 @@ type Config struct {
 	// joining or quitting.
 	// ShutdownMessage is set to chat.MessageServerDisconnect if empty.
 	JoinMessage, QuitMessage, ShutdownMessage chat.Translation
+	// JoinMessageFunc and QuitMessageFunc, if set, are called when a player
+	// joins or quits the server to obtain the message to broadcast, together
+	// with its arguments, in place of JoinMessage and QuitMessage. Returning
+	// false suppresses the message for the player.
+	JoinMessageFunc, QuitMessageFunc func(p *player.Player) (chat.Translation, []any, bool)
 	// StatusProvider provides the server status shown to players in the server
 	// list. By default, StatusProvider will show the server name from the Name
 	// field and the current player count and maximum players.
@@ -109,6 +122,11 @@ type Config struct {
 	// count will be chosen automatically. Increase it alongside
 	// GeneratorWorkers if the logs report generator queue saturation.
 	GeneratorQueueSize int
+	// ResumeGeneration specifies if chunk generation that was not completed
+	// when the server was last stopped should be resumed on start. It has no
+	// effect if the WorldProvider does not implement
+	// world.GenerationQueueProvider.
+	ResumeGeneration bool
 	// OverworldSeed is the seed used by the default overworld generator when
 	// Generator is not supplied. A value of 0 is valid and results in a fixed
 	// world layout identical to Java's seed 0.
@@ -140,6 +158,66 @@ type Config struct {
 	// formatting directive such as %s, the name of the target dimension is passed as the
 	// first argument. Set this to an empty string to disable the notification entirely.
 	PortalDisabledMessage string
+	// ShutdownHookTimeout is the maximum time that each hook registered using
+	// Server.OnPreShutdown or Server.OnPostShutdown may run for before the
+	// Server stops waiting for it and continues shutting down. If left as 0,
+	// ShutdownHookTimeout defaults to 10 seconds.
+	ShutdownHookTimeout time.Duration
+	// QueryEngineLabel is the engine reported in the "server_engine" field of
+	// query responses. If left empty, a label derived from the build
+	// information of the binary is used.
+	QueryEngineLabel string
+	// QueryDisabledAddresses holds the addresses of listeners, as passed to
+	// them when they are started, that should not respond to query requests.
+	// By default, all listeners respond to query requests.
+	QueryDisabledAddresses []string
+	// QueryDimensionPlayerCounts specifies if query responses should include
+	// the number of players in each dimension, as the "players_overworld",
+	// "players_nether" and "players_end" fields. Dimensions that are disabled
+	// are not included. By default, these fields are left out.
+	QueryDimensionPlayerCounts bool
+	// CommandCooldowns holds the minimum time a player has to wait between
+	// two executions of a command, keyed by the name of the command.
+	// Cooldowns may be changed later using Server.SetCommandCooldown.
+	CommandCooldowns map[string]time.Duration
+	// AuditLogSize is the maximum number of events, such as players joining,
+	// quitting and executing commands, kept in the audit log returned by
+	// Server.RecentEvents. If set to 0, AuditLogSize defaults to 256. A
+	// negative value disables the audit log.
+	AuditLogSize int
+	// AuditBlockBreaks is called to check if the blocks broken by a player,
+	// typically an operator, are recorded in the audit log. If nil, no block
+	// breaks are recorded.
+	AuditBlockBreaks func(p *player.Player) bool
+	// DuplicateLoginPolicy specifies how a player logging in while a player
+	// with the same UUID is already online is handled. By default, the new
+	// login is rejected.
+	DuplicateLoginPolicy DuplicateLoginPolicy
+	// ReconnectGracePeriod is the time for which the data of a player that
+	// disconnected is held in memory. If the player reconnects within this
+	// period, with the same UUID and XUID, it resumes with the data held
+	// instead of the data stored in the PlayerProvider, so that a flaky
+	// connection does not lose any state. The data is only saved to the
+	// PlayerProvider once the period expires. Only the data of the player is
+	// held: its entity is still removed from the world and Handler.HandleQuit
+	// is still called when it disconnects. ReconnectGracePeriod is 0 by
+	// default, which disables holding player data.
+	ReconnectGracePeriod time.Duration
+	// SpawnProtectionRadius is the radius around the spawn position of the
+	// overworld in which players may not break or place blocks, unless
+	// SpawnProtectionBypass returns true for them. It may be changed later
+	// using World.SetSpawnProtectionRadius. SpawnProtectionRadius is 0 by
+	// default, which disables spawn protection.
+	SpawnProtectionRadius int
+	// SpawnProtectionBypass is called to check if a player, typically an
+	// operator, may break and place blocks in the spawn protection area. If
+	// nil, no player may do so.
+	SpawnProtectionBypass func(p *player.Player) bool
+	// StoreFolder is the folder that the data of the key-value stores
+	// returned by Server.Store is kept in, each in a sub-folder named after
+	// its namespace. StoreFolder defaults to "stores". The folder is only
+	// created once a store is first used.
+	StoreFolder string
 }
 
 // New creates a Server using fields of conf. The Server's worlds are created
@@ -173,9 +251,18 @@ func (conf Config) New() *Server {
 	if conf.MaxChunkRadius == 0 {
 		conf.MaxChunkRadius = 12
 	}
+	if conf.StoreFolder == "" {
+		conf.StoreFolder = "stores"
+	}
+	if conf.AuditLogSize == 0 {
+		conf.AuditLogSize = 256
+	}
 	if conf.ShutdownMessage.Zero() {
 		conf.ShutdownMessage = chat.MessageServerDisconnect
 	}
+	if conf.ShutdownHookTimeout <= 0 {
+		conf.ShutdownHookTimeout = time.Second * 10
+	}
 	if len(conf.Entities.Types()) == 0 {
 		conf.Entities = entity.DefaultRegistry
 	}
@@ -188,11 +275,19 @@ func (conf Config) New() *Server {
 	conf.Resources = slices.Clone(conf.Resources)
 
 	srv := &Server{
-		conf:       conf,
-		incoming:   make(chan incoming),
-		p:          make(map[uuid.UUID]*onlinePlayer),
-		dimensions: make(map[world.Dimension]*world.World),
-	}
+		conf:        conf,
+		incoming:    make(chan incoming),
+		p:           make(map[uuid.UUID]*onlinePlayer),
+		dimensions:  make(map[world.Dimension]*world.World),
+		cooldowns:   newCommandCooldowns(conf.CommandCooldowns),
+		audit:       newAuditLog(conf.AuditLogSize),
+		diagnostics: newDiagnosticsAggregator(),
+		stores:      make(map[string]*Store),
+		held:        make(map[uuid.UUID]*heldSession),
+		saving:      make(map[uuid.UUID]chan struct{}),
+		afterFunc:   time.AfterFunc,
+	}
+	srv.lifetime, srv.stopLifetime = context.WithCancel(context.Background())
 	if wl, ok := conf.Allower.(*Whitelist); ok {
 		srv.whitelist = wl
 	}
@@ -3,7 +3,6 @@ package console
 import (
 	"bufio"
 	"context"
-	"fmt"
 	"io"
 	"log/slog"
 	"os"
@@ -226,7 +225,7 @@ func (c *Console) parameterSuggestions(command cmd.Command, index int, word stri
 		param := overload[index]
 		suggestions := c.suggestionsForParam(param, src)
 		if len(suggestions) == 0 {
-			fallback = append(fallback, paramHint(param))
+			fallback = append(fallback, param.Hint())
 			continue
 		}
 		for _, suggestion := range suggestions {
@@ -262,7 +261,7 @@ func (c *Console) parameterSuggestions(command cmd.Command, index int, word stri
 }
 
 func (c *Console) suggestionsForParam(p cmd.ParamInfo, src cmd.Source) []prompt.Suggest {
-	hint := paramHint(p)
+	hint := p.Hint()
 	switch v := p.Value.(type) {
 	case cmd.SubCommand:
 		return []prompt.Suggest{{
@@ -320,44 +319,6 @@ func (c *Console) playerNames() []string {
 	return names
 }
 
-func paramHint(p cmd.ParamInfo) string {
-	t := paramTypeName(p.Value, p.Name)
-	if p.Optional {
-		return fmt.Sprintf("[%s: %s]%s", p.Name, t, p.Suffix)
-	}
-	return fmt.Sprintf("<%s: %s>%s", p.Name, t, p.Suffix)
-}
-
-func paramTypeName(v any, name string) string {
-	switch v.(type) {
-	case int, int8, int16, int32, int64:
-		return "int"
-	case uint, uint8, uint16, uint32, uint64:
-		return "uint"
-	case float32, float64:
-		return "float"
-	case string:
-		return "string"
-	case bool:
-		return "bool"
-	case cmd.Varargs:
-		return "text"
-	case []cmd.Target, cmd.Target:
-		return "target"
-	case mgl64.Vec3:
-		return "x y z"
-	case cmd.SubCommand:
-		return name
-	}
-	if param, ok := v.(cmd.Parameter); ok {
-		return param.Type()
-	}
-	if enum, ok := v.(cmd.Enum); ok {
-		return enum.Type()
-	}
-	return "value"
-}
-
 func uniqueStrings(values []string) []string {
 	seen := make(map[string]struct{}, len(values))
 	result := make([]string, 0, len(values))
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/google/uuid"
+)
+
+// diagnosticsSampleSize is the maximum number of diagnostics samples kept per
+// player for the DiagnosticsSummary.
+const diagnosticsSampleSize = 20
+
+// DiagnosticsSummary holds client-side metrics aggregated over the diagnostics
+// recently sent by all online players, as returned by
+// Server.DiagnosticsSummary. Only clients with diagnostics enabled send them.
+type DiagnosticsSummary struct {
+	// Players is the number of online players of which diagnostics were
+	// received.
+	Players int
+	// Samples is the total number of diagnostics samples the summary is
+	// computed over.
+	Samples int
+	// FramesPerSecond holds the average frames per second of the clients.
+	FramesPerSecond DiagnosticsMetric
+	// Latency holds the latency of the players in milliseconds at the time
+	// the diagnostics were received.
+	Latency DiagnosticsMetric
+	// ClientTickTime holds the average time in milliseconds that the clients
+	// spend simulating a single tick.
+	ClientTickTime DiagnosticsMetric
+	// ServerTickTime holds the average time in milliseconds that the clients
+	// report the server spending on simulating a single tick.
+	ServerTickTime DiagnosticsMetric
+}
+
+// DiagnosticsMetric holds the mean and percentiles of a single metric in a
+// DiagnosticsSummary.
+type DiagnosticsMetric struct {
+	Average, P5, P50, P95 float64
+}
+
+// diagnosticsSample is a single diagnostics update received from a player.
+type diagnosticsSample struct {
+	fps, latency, clientTick, serverTick float64
+}
+
+// diagnosticsRing is a ring buffer holding the most recent diagnostics samples
+// of a single player.
+type diagnosticsRing struct {
+	samples [diagnosticsSampleSize]diagnosticsSample
+	n, next int
+}
+
+// diagnosticsAggregator keeps the most recent diagnostics samples of every
+// online player.
+type diagnosticsAggregator struct {
+	mu      sync.Mutex
+	players map[uuid.UUID]*diagnosticsRing
+}
+
+// newDiagnosticsAggregator creates an empty diagnosticsAggregator.
+func newDiagnosticsAggregator() *diagnosticsAggregator {
+	return &diagnosticsAggregator{players: make(map[uuid.UUID]*diagnosticsRing)}
+}
+
+// add adds a diagnostics sample of the player with the UUID passed,
+// overwriting the oldest sample of the player if it has the maximum number of
+// samples.
+func (a *diagnosticsAggregator) add(id uuid.UUID, d session.Diagnostics, latency time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	r, ok := a.players[id]
+	if !ok {
+		r = &diagnosticsRing{}
+		a.players[id] = r
+	}
+	r.samples[r.next] = diagnosticsSample{
+		fps:        d.AverageFramesPerSecond,
+		latency:    float64(latency) / float64(time.Millisecond),
+		clientTick: d.AverageClientSimTickTime,
+		serverTick: d.AverageServerSimTickTime,
+	}
+	r.next = (r.next + 1) % diagnosticsSampleSize
+	r.n = min(r.n+1, diagnosticsSampleSize)
+}
+
+// remove removes all samples of the player with the UUID passed.
+func (a *diagnosticsAggregator) remove(id uuid.UUID) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.players, id)
+}
+
+// summary computes a DiagnosticsSummary over all samples held.
+func (a *diagnosticsAggregator) summary() DiagnosticsSummary {
+	a.mu.Lock()
+	var fps, latency, clientTick, serverTick []float64
+	for _, r := range a.players {
+		for _, s := range r.samples[:r.n] {
+			fps, latency = append(fps, s.fps), append(latency, s.latency)
+			clientTick, serverTick = append(clientTick, s.clientTick), append(serverTick, s.serverTick)
+		}
+	}
+	players := len(a.players)
+	a.mu.Unlock()
+
+	return DiagnosticsSummary{
+		Players:         players,
+		Samples:         len(fps),
+		FramesPerSecond: diagnosticsMetric(fps),
+		Latency:         diagnosticsMetric(latency),
+		ClientTickTime:  diagnosticsMetric(clientTick),
+		ServerTickTime:  diagnosticsMetric(serverTick),
+	}
+}
+
+// diagnosticsMetric computes the DiagnosticsMetric of the values passed,
+// sorting them in the process.
+func diagnosticsMetric(values []float64) DiagnosticsMetric {
+	if len(values) == 0 {
+		return DiagnosticsMetric{}
+	}
+	slices.Sort(values)
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	percentile := func(p float64) float64 {
+		// Nearest-rank percentile.
+		return values[min(int(p*float64(len(values))), len(values)-1)]
+	}
+	return DiagnosticsMetric{
+		Average: sum / float64(len(values)),
+		P5:      percentile(0.05),
+		P50:     percentile(0.5),
+		P95:     percentile(0.95),
+	}
+}
+
+// DiagnosticsSummary returns client-side metrics, such as frames per second
+// and latency, aggregated over the most recent diagnostics sent by all online
+// players. Only the last 20 diagnostics of every player are taken into
+// account. Clients only send diagnostics if they have client diagnostics
+// enabled in their settings, so the summary is empty if none of the players
+// do.
+func (srv *Server) DiagnosticsSummary() DiagnosticsSummary {
+	return srv.diagnostics.summary()
+}
+
+// handleDiagnostics records the diagnostics received from a player for the
+// DiagnosticsSummary of the Server.
+func (srv *Server) handleDiagnostics(c session.Controllable, d session.Diagnostics) {
+	var latency time.Duration
+	if p, ok := c.(*player.Player); ok {
+		latency = p.Latency()
+	}
+	srv.diagnostics.add(c.UUID(), d, latency)
+}
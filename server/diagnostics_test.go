@@ -0,0 +1,64 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+func TestDiagnosticsSummary(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	// Generated chunks could flood the packet queues of the players, so no
+	// terrain is generated.
+	gen := func(world.Dimension) world.Generator { return world.NopGenerator{} }
+	srv := Config{Log: log, DisableResourceBuilding: true, Generator: gen}.New()
+	closeWorlds(t, srv)
+
+	if s := srv.DiagnosticsSummary(); s.Players != 0 || s.Samples != 0 {
+		t.Fatalf("expected empty summary, got %+v", s)
+	}
+
+	conn, other := newLoginConn(uuid.New()), newLoginConn(uuid.New())
+	// The players are spawned in different worlds, so that the chunk closed
+	// when the first player leaves does not close the other player with it.
+	acceptConn(srv, conn, srv.World())
+	acceptConn(srv, other, srv.Nether())
+	// The first player sends more diagnostics than are kept, of which only the
+	// most recent 20 count.
+	for range 5 {
+		conn.packets <- &packet.ServerBoundDiagnostics{AverageFramesPerSecond: 1000}
+	}
+	for range diagnosticsSampleSize {
+		conn.packets <- &packet.ServerBoundDiagnostics{AverageFramesPerSecond: 60, AverageClientSimTickTime: 2}
+		other.packets <- &packet.ServerBoundDiagnostics{AverageFramesPerSecond: 30, AverageClientSimTickTime: 4}
+	}
+
+	// Packets are handled asynchronously, so wait until all of them arrived.
+	deadline := time.Now().Add(5 * time.Second)
+	for len(conn.packets) > 0 || len(other.packets) > 0 || srv.DiagnosticsSummary().FramesPerSecond.Average != 45 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected diagnostics sent by players to be handled, got %+v", srv.DiagnosticsSummary())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	s := srv.DiagnosticsSummary()
+	if s.Players != 2 || s.Samples != 2*diagnosticsSampleSize {
+		t.Fatalf("expected 2 players and %v samples, got %v and %v", 2*diagnosticsSampleSize, s.Players, s.Samples)
+	}
+	if s.FramesPerSecond.P5 != 30 || s.FramesPerSecond.P95 != 60 {
+		t.Fatalf("unexpected frames per second metric %+v", s.FramesPerSecond)
+	}
+	if s.ClientTickTime.Average != 3 {
+		t.Fatalf("unexpected client tick time %+v", s.ClientTickTime)
+	}
+
+	disconnect(t, srv, conn)
+	if s = srv.DiagnosticsSummary(); s.Players != 1 || s.FramesPerSecond.Average != 30 {
+		t.Fatalf("expected only diagnostics of the online player after disconnecting, got %+v", s)
+	}
+}
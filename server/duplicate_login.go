@@ -0,0 +1,63 @@
+package server
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+)
+
+// DuplicateLoginPolicy specifies how the Server handles a player logging in
+// with the UUID of a player that is already online, for example when
+// reconnecting before the previous session timed out.
+type DuplicateLoginPolicy int
+
+const (
+	// DuplicateLoginRejectNew refuses the new login, keeping the player that
+	// is already online connected. It is the default policy.
+	DuplicateLoginRejectNew DuplicateLoginPolicy = iota
+	// DuplicateLoginKickExisting disconnects the player that is already online
+	// and lets the new login continue once its data has been saved.
+	DuplicateLoginKickExisting
+)
+
+// duplicateLoginTimeout is the maximum time to wait for a player kicked by
+// DuplicateLoginKickExisting to be removed from the server.
+const duplicateLoginTimeout = time.Second * 5
+
+// admitLogin checks if a player with the UUID passed may log in according to
+// the DuplicateLoginPolicy of the Server. If a player with the same UUID is
+// already online and the policy is DuplicateLoginKickExisting, that player is
+// disconnected and admitLogin waits for it to be removed, so that its data is
+// saved before the new login loads it.
+func (srv *Server) admitLogin(id uuid.UUID) bool {
+	srv.pmu.RLock()
+	existing, ok := srv.p[id]
+	srv.pmu.RUnlock()
+	if !ok {
+		return true
+	}
+	if srv.conf.DuplicateLoginPolicy != DuplicateLoginKickExisting || existing.closed == nil {
+		return false
+	}
+	existing.handle.ExecWorld(func(tx *world.Tx, e world.Entity) {
+		e.(*player.Player).Disconnect("Logged in from another location.")
+	})
+	select {
+	case <-existing.closed:
+		return true
+	case <-time.After(duplicateLoginTimeout):
+		srv.conf.Log.Warn("Timed out waiting for player with duplicate login to disconnect.", "name", existing.name, "uuid", id)
+		return false
+	}
+}
+
+// rejectIncoming disconnects an incoming player that was not yet added to the
+// server because a player with the same UUID is already online.
+func (srv *Server) rejectIncoming(inc incoming) {
+	inc.s.Disconnect("Already logged in.")
+	inc.s.CloseConnection()
+	_ = inc.p.handle.Close()
+	srv.pwg.Done()
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// loginConn is a stubConn with a fixed identity of which ReadPacket returns
+// the packets sent on its packets channel until the connection is closed.
+type loginConn struct {
+	stubConn
+	id     uuid.UUID
+	closed chan struct{}
+	// packets holds packets returned by ReadPacket, so that tests may send
+	// packets as if the client sent them.
+	packets chan packet.Packet
+	// written, if not nil, is called for every packet written.
+	written func(pk packet.Packet)
+}
+
+func newLoginConn(id uuid.UUID) *loginConn {
+	return &loginConn{id: id, closed: make(chan struct{}), packets: make(chan packet.Packet, 64)}
+}
+
+func (c *loginConn) IdentityData() login.IdentityData {
+	return login.IdentityData{Identity: c.id.String(), DisplayName: "Steve"}
+}
+
+func (c *loginConn) ReadPacket() (packet.Packet, error) {
+	select {
+	case pk := <-c.packets:
+		return pk, nil
+	case <-c.closed:
+		return nil, io.EOF
+	}
+}
+
+func (c *loginConn) WritePacket(pk packet.Packet) error {
+	if c.written != nil {
+		c.written(pk)
+	}
+	return nil
+}
+
+func (c *loginConn) Close() error {
+	select {
+	case <-c.closed:
+	default:
+		close(c.closed)
+	}
+	return nil
+}
+
+// acceptLogin passes a new connection for the UUID passed to the server and
+// accepts it as if it had finished logging in.
+func acceptLogin(srv *Server, id uuid.UUID) *onlinePlayer {
+	return acceptConn(srv, newLoginConn(id), srv.World())
+}
+
+// acceptConn accepts the loginConn passed as if it had finished logging in,
+// spawning its player in the World passed.
+func acceptConn(srv *Server, conn *loginConn, w *world.World) *onlinePlayer {
+	inc := srv.createPlayer(conn.id, conn, player.Config{Position: w.Spawn().Vec3Centre()}, w)
+	go func() {
+		srv.incoming <- inc
+	}()
+	for range srv.Accept() {
+		break
+	}
+	return inc.p
+}
+
+func TestDuplicateLoginPolicy(t *testing.T) {
+	for _, policy := range []DuplicateLoginPolicy{DuplicateLoginRejectNew, DuplicateLoginKickExisting} {
+		log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+		srv := Config{Log: log, DisableResourceBuilding: true, DuplicateLoginPolicy: policy}.New()
+		closeWorlds(t, srv)
+
+		id := uuid.New()
+		existing := acceptLogin(srv, id)
+		if srv.admitLogin(uuid.New()) != true {
+			t.Fatalf("policy %v: expected login of a player not online to be admitted", policy)
+		}
+
+		admitted := srv.admitLogin(id)
+		srv.pmu.RLock()
+		current, online := srv.p[id]
+		srv.pmu.RUnlock()
+
+		switch policy {
+		case DuplicateLoginRejectNew:
+			if admitted {
+				t.Fatalf("expected duplicate login to be rejected")
+			}
+			if !online || current != existing {
+				t.Fatalf("expected existing player to stay online")
+			}
+		case DuplicateLoginKickExisting:
+			if !admitted {
+				t.Fatalf("expected duplicate login to be admitted")
+			}
+			if online {
+				t.Fatalf("expected existing player to be kicked")
+			}
+			if next := acceptLogin(srv, id); next == existing {
+				t.Fatalf("expected new player to be registered")
+			}
+		}
+	}
+}
@@ -126,8 +126,7 @@ func (e *Ent) SetNameTag(s string) {
 // Tick ticks Ent, progressing its lifetime and closing the entity if it is
 // in the void.
 func (e *Ent) Tick(tx *world.Tx, current int64) {
-	y := e.data.Pos[1]
-	if y < float64(tx.Range()[0]) && current%10 == 0 {
+	if tx.InVoid(e.data.Pos[1]) && current%10 == 0 {
 		_ = e.CloseIn(tx)
 		return
 	}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// stateViewer is a world.Viewer that records the entities of which it was
+// shown a state change.
+type stateViewer struct {
+	world.NopViewer
+	updated []world.Entity
+}
+
+func (v *stateViewer) ViewEntityState(e world.Entity) { v.updated = append(v.updated, e) }
+
+func TestSetEntityNameNotifiesViewers(t *testing.T) {
+	w := newTestWorld(t, world.Config{})
+	v := &stateViewer{}
+	loader := world.NewLoader(1, w, v)
+	<-w.Exec(func(tx *world.Tx) { loader.Move(tx, mgl64.Vec3{}) })
+	waitChunkLoaded(t, w, loader, world.ChunkPos{})
+
+	var (
+		e        world.Entity
+		ok       bool
+		name     string
+		metadata map[string]any
+	)
+	<-w.Exec(func(tx *world.Tx) {
+		e = tx.AddEntity(NewText("old", mgl64.Vec3{8, 64, 8}))
+		v.updated = nil
+		ok = tx.SetEntityName(e, "Custom")
+		name, metadata = tx.EntityName(e), tx.EntityMetadata(e)
+	})
+	if !ok {
+		t.Fatalf("expected text entity to support name tags")
+	}
+	if name != "Custom" || metadata["name"] != "Custom" {
+		t.Fatalf("expected name Custom, got %q and metadata %v", name, metadata)
+	}
+	if len(v.updated) != 1 || v.updated[0] != e {
+		t.Fatalf("expected viewer to be notified of the name change once, got %v", len(v.updated))
+	}
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// newTestWorld creates a world.World with the world.Config passed, without
+// provider or generator, and closes it once the test finishes.
+func newTestWorld(t *testing.T, conf world.Config) *world.World {
+	t.Helper()
+	conf.Provider, conf.Generator = world.NopProvider{}, world.NopGenerator{}
+	w := conf.New()
+	t.Cleanup(func() {
+		if err := w.Close(); err != nil {
+			t.Fatalf("failed closing world: %v", err)
+		}
+	})
+	return w
+}
+
+// waitChunkLoaded keeps loading chunks with the world.Loader passed until it
+// has loaded the chunk at the world.ChunkPos passed, failing the test if this
+// takes longer than 5 seconds.
+func waitChunkLoaded(t *testing.T, w *world.World, loader *world.Loader, pos world.ChunkPos) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var loaded bool
+		<-w.Exec(func(tx *world.Tx) {
+			loader.Load(tx, 16)
+			_, loaded = loader.Chunk(pos)
+		})
+		if loaded {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("chunk %v was never loaded", pos)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
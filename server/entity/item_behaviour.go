@@ -23,7 +23,10 @@ type ItemBehaviourConfig struct {
 	// default is time.Minute * 5.
 	ExistenceDuration time.Duration
 	// PickupDelay specifies how much time must expire before the item can be
-	// picked up by collectors. The default is time.Second / 2.
+	// picked up by collectors. If left as 0, the world.Config.ItemPickupDelay
+	// of the world that the item is in is used, which defaults to
+	// time.Second / 2. A negative PickupDelay allows the item to be picked up
+	// immediately.
 	PickupDelay time.Duration
 }
 
@@ -39,14 +42,11 @@ func (conf ItemBehaviourConfig) New() *ItemBehaviour {
 	}
 	i = nbtconv.Item(nbtconv.WriteItem(i, true), nil)
 
-	if conf.PickupDelay == 0 {
-		conf.PickupDelay = time.Second / 2
-	}
 	if conf.ExistenceDuration == 0 {
 		conf.ExistenceDuration = time.Minute * 5
 	}
 
-	b := &ItemBehaviour{conf: conf, i: i, pickupDelay: conf.PickupDelay}
+	b := &ItemBehaviour{conf: conf, i: i, pickupDelay: conf.PickupDelay, worldPickupDelay: conf.PickupDelay == 0}
 	b.passive = PassiveBehaviourConfig{
 		Gravity:           conf.Gravity,
 		Drag:              conf.Drag,
@@ -63,6 +63,9 @@ type ItemBehaviour struct {
 	i       item.Stack
 
 	pickupDelay time.Duration
+	// worldPickupDelay specifies if the pickup delay should still be set to
+	// the default of the world that the item is in.
+	worldPickupDelay bool
 }
 
 // Item returns the item.Stack held by the entity.
@@ -102,13 +105,42 @@ func (i *ItemBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
 
 // tick checks if the item can be picked up or merged with nearby item stacks.
 func (i *ItemBehaviour) tick(e *Ent, tx *world.Tx) {
-	if i.pickupDelay == 0 {
+	if i.worldPickupDelay {
+		i.pickupDelay, i.worldPickupDelay = tx.World().ItemPickupDelay(), false
+	}
+	if i.advancePickupDelay() {
 		i.checkNearby(e, tx)
-	} else if i.pickupDelay < math.MaxInt16*(time.Second/20) {
-		i.pickupDelay -= time.Second / 20
 	}
 }
 
+// pickupDelayTicks returns the pickup delay of the item in ticks, as stored
+// in NBT. 0 is returned if the item still uses the pickup delay of the world,
+// so that it keeps doing so once loaded again. An expired delay is returned as
+// -1 to keep it from being mistaken for the world default.
+func (i *ItemBehaviour) pickupDelayTicks() int64 {
+	if i.worldPickupDelay {
+		return 0
+	}
+	if i.pickupDelay <= 0 {
+		return -1
+	}
+	return max(int64(i.pickupDelay/(time.Second/20)), 1)
+}
+
+// advancePickupDelay reduces the pickup delay of the item by one tick. It
+// returns true if the delay had already expired, meaning the item may be
+// picked up this tick. A pickup delay of math.MaxInt16 ticks or more never
+// expires.
+func (i *ItemBehaviour) advancePickupDelay() bool {
+	if i.pickupDelay <= 0 {
+		return true
+	}
+	if i.pickupDelay < math.MaxInt16*(time.Second/20) {
+		i.pickupDelay = max(i.pickupDelay-time.Second/20, 0)
+	}
+	return false
+}
+
 // checkNearby checks the nearby entities for item collectors and other item
 // stacks. If a collector is found in range, the item will be picked up. If
 // another item stack with the same item type is found in range, the item
@@ -2,9 +2,12 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
 )
 
 func TestHazardConsumesItems(t *testing.T) {
@@ -29,3 +32,47 @@ func TestHazardConsumesItems(t *testing.T) {
 		})
 	}
 }
+
+func TestItemPickupDelay(t *testing.T) {
+	b := ItemBehaviourConfig{Item: item.NewStack(item.Apple{}, 1), PickupDelay: time.Second * 2}.New()
+	for tick := 0; tick < 40; tick++ {
+		if b.advancePickupDelay() {
+			t.Fatalf("expected item not to be collectible after %v ticks", tick)
+		}
+	}
+	if !b.advancePickupDelay() {
+		t.Fatalf("expected item to be collectible after the pickup delay elapsed")
+	}
+}
+
+func TestItemWorldPickupDelay(t *testing.T) {
+	w := newTestWorld(t, world.Config{ItemPickupDelay: time.Second})
+
+	// itemAfter spawns an item that uses the pickup delay of the world, ticks
+	// it the number of times passed and returns its pickup delay after
+	// saving it to NBT and loading it again.
+	itemAfter := func(ticks int) (delay time.Duration, worldDelay bool) {
+		<-w.Exec(func(tx *world.Tx) {
+			e := tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: mgl64.Vec3{0, 64}}, item.NewStack(item.Apple{}, 1))).(*Ent)
+			for range ticks {
+				e.Behaviour().(*ItemBehaviour).tick(e, tx)
+			}
+			data := &world.EntityData{}
+			ItemType.DecodeNBT(ItemType.EncodeNBT(e.data), data)
+			b := data.Data.(*ItemBehaviour)
+			delay, worldDelay = b.pickupDelay, b.worldPickupDelay
+			_ = e.CloseIn(tx)
+		})
+		return delay, worldDelay
+	}
+
+	if _, worldDelay := itemAfter(0); !worldDelay {
+		t.Fatalf("expected an item that was never ticked to keep using the pickup delay of the world")
+	}
+	if delay, worldDelay := itemAfter(1); worldDelay || delay != time.Second-time.Second/20 {
+		t.Fatalf("expected pickup delay of %v after 1 tick, got %v (world delay: %v)", time.Second-time.Second/20, delay, worldDelay)
+	}
+	if delay, worldDelay := itemAfter(25); worldDelay || delay > 0 {
+		t.Fatalf("expected expired pickup delay to stay expired, got %v (world delay: %v)", delay, worldDelay)
+	}
+}
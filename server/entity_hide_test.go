@@ -0,0 +1,116 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// spawnRecorder records the entities spawned and despawned for a player, and
+// the messages sent to it, in the order they were written.
+type spawnRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *spawnRecorder) record(pk packet.Packet) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch pk := pk.(type) {
+	case *packet.AddActor:
+		r.events = append(r.events, "spawn")
+	case *packet.RemoveActor:
+		r.events = append(r.events, "despawn")
+	case *packet.Text:
+		r.events = append(r.events, pk.Message)
+	}
+}
+
+// await waits until the message passed was recorded and returns the events
+// recorded until then.
+func (r *spawnRecorder) await(t *testing.T, msg string) []string {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		r.mu.Lock()
+		for i, ev := range r.events {
+			if ev == msg {
+				events := append([]string(nil), r.events[:i+1]...)
+				r.mu.Unlock()
+				return events
+			}
+		}
+		r.mu.Unlock()
+		if time.Now().After(deadline) {
+			t.Fatalf("expected message %q to be sent", msg)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestEntityHiddenByPlayerAndWorld(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	gen := func(world.Dimension) world.Generator { return world.NopGenerator{} }
+	srv := Config{Log: log, DisableResourceBuilding: true, Generator: gen}.New()
+	closeWorlds(t, srv)
+
+	rec := &spawnRecorder{}
+	conn := newLoginConn(uuid.New())
+	conn.written = rec.record
+	p := acceptConn(srv, conn, srv.World())
+
+	// The session of the player is the only viewer of its chunk once it has
+	// loaded it.
+	var viewer world.Viewer
+	deadline := time.Now().Add(5 * time.Second)
+	for viewer == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected player to view the chunk it spawned in")
+		}
+		time.Sleep(10 * time.Millisecond)
+		p.handle.ExecWorld(func(tx *world.Tx, e world.Entity) {
+			if viewers := tx.Viewers(e.Position()); len(viewers) == 1 {
+				viewer = viewers[0]
+			}
+		})
+	}
+
+	p.handle.ExecWorld(func(tx *world.Tx, e world.Entity) {
+		pl := e.(*player.Player)
+		text := tx.AddEntity(entity.NewText("hidden", pl.Position()))
+		// Showing the entity through the world must not show it while it is
+		// hidden through the player, and the other way around.
+		pl.HideEntity(text)
+		tx.HideEntityFrom(text, viewer)
+		tx.ShowEntityTo(text, viewer)
+		pl.Message("world shown")
+		pl.ShowEntity(text)
+		pl.Message("player shown")
+
+		tx.HideEntityFrom(text, viewer)
+		pl.HideEntity(text)
+		pl.ShowEntity(text)
+		pl.Message("player shown again")
+		tx.ShowEntityTo(text, viewer)
+		pl.Message("world shown again")
+	})
+
+	want := []string{"spawn", "despawn", "world shown", "spawn", "player shown", "despawn", "player shown again", "spawn", "world shown again"}
+	got := rec.await(t, "world shown again")
+	if len(got) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected events %v, got %v", want, got)
+		}
+	}
+}
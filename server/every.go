@@ -0,0 +1,46 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Every runs fn every interval on a separate goroutine until either the
+// function returned is called or the Server is closed, whichever comes first.
+// The context passed to fn is cancelled at that moment, and fn is not called
+// again afterwards. Panics in fn are recovered and logged, after which fn is
+// still called on the next interval. Every panics if interval is not
+// positive.
+func (srv *Server) Every(interval time.Duration, fn func(ctx context.Context)) (cancel func()) {
+	if interval <= 0 {
+		panic("server.Every: interval must be positive")
+	}
+	ctx, cancel := context.WithCancel(srv.lifetime)
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				if ctx.Err() != nil {
+					return
+				}
+				srv.runPeriodic(ctx, fn)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return cancel
+}
+
+// runPeriodic calls fn, recovering and logging any panic that occurs in it.
+func (srv *Server) runPeriodic(ctx context.Context, fn func(ctx context.Context)) {
+	defer func() {
+		if r := recover(); r != nil {
+			srv.conf.Log.Error(fmt.Sprintf("Periodic task: panic: %v", r))
+		}
+	}()
+	fn(ctx)
+}
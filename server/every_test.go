@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEvery(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	srv := Config{Log: log, DisableResourceBuilding: true}.New()
+	closeWorlds(t, srv)
+
+	var calls atomic.Int32
+	cancel := srv.Every(time.Millisecond*5, func(context.Context) {
+		if calls.Add(1) == 1 {
+			panic("first call panics")
+		}
+	})
+	waitCalls := func(n int32) {
+		deadline := time.Now().Add(time.Second * 5)
+		for calls.Load() < n {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected at least %v calls, got %v", n, calls.Load())
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	// The function must keep being called after panicking.
+	waitCalls(3)
+	cancel()
+
+	// A call may have been in progress while cancelling.
+	time.Sleep(time.Millisecond * 20)
+	n := calls.Load()
+	time.Sleep(time.Millisecond * 50)
+	if calls.Load() != n {
+		t.Fatalf("expected function not to be called after cancelling")
+	}
+
+	// Closing the server also stops the function.
+	var stopped atomic.Bool
+	calls.Store(0)
+	srv.Every(time.Millisecond*5, func(context.Context) {
+		if stopped.Load() {
+			t.Errorf("expected function not to be called after server closed")
+		}
+		calls.Add(1)
+	})
+	waitCalls(1)
+	srv.stopLifetime()
+	time.Sleep(time.Millisecond * 20)
+	stopped.Store(true)
+	time.Sleep(time.Millisecond * 50)
+}
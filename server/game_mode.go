@@ -0,0 +1,29 @@
+package server
+
+import (
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+)
+
+// SetPlayerGameMode changes the game mode of the online player with the UUID
+// passed, for example to move players to spectator mode in a minigame. False
+// is returned if the player is not online. tx should be the transaction that
+// the caller is running in, or nil if it is not running in one.
+func (srv *Server) SetPlayerGameMode(tx *world.Tx, id uuid.UUID, mode world.GameMode) bool {
+	return srv.withPlayer(tx, id, func(p *player.Player) {
+		p.SetGameMode(mode)
+	})
+}
+
+// PlayerGameMode returns the game mode of the online player with the UUID
+// passed. False is returned if the player is not online. tx should be the
+// transaction that the caller is running in, or nil if it is not running in
+// one.
+func (srv *Server) PlayerGameMode(tx *world.Tx, id uuid.UUID) (world.GameMode, bool) {
+	var mode world.GameMode
+	ok := srv.withPlayer(tx, id, func(p *player.Player) {
+		mode = p.GameMode()
+	})
+	return mode, ok
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+)
+
+func TestSetPlayerGameMode(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	srv := Config{Log: log, DisableResourceBuilding: true}.New()
+	closeWorlds(t, srv)
+
+	sess := session.Config{Log: log, MaxChunkRadius: 1}.New(stubConn{})
+	t.Cleanup(sess.CloseConnection)
+	id := uuid.New()
+	conf := player.Config{Session: sess, Skin: skin.New(64, 64), GameMode: world.GameModeSurvival}
+	handle := world.EntitySpawnOpts{ID: id}.New(player.Type, conf)
+	sess.SetHandle(handle, conf.Skin)
+	<-srv.World().Exec(func(tx *world.Tx) {
+		tx.AddEntity(handle)
+	})
+	srv.p[id] = &onlinePlayer{name: "Steve", handle: handle}
+
+	if srv.SetPlayerGameMode(nil, uuid.New(), world.GameModeSpectator) {
+		t.Fatalf("expected game mode of offline player not to be set")
+	}
+	if _, ok := srv.PlayerGameMode(nil, uuid.New()); ok {
+		t.Fatalf("expected no game mode for offline player")
+	}
+	if mode, ok := srv.PlayerGameMode(nil, id); !ok || mode != world.GameModeSurvival {
+		t.Fatalf("expected survival game mode, got %v (%v)", mode, ok)
+	}
+	if !srv.SetPlayerGameMode(nil, id, world.GameModeSpectator) {
+		t.Fatalf("expected game mode to be set")
+	}
+	if mode, ok := srv.PlayerGameMode(nil, id); !ok || mode != world.GameModeSpectator {
+		t.Fatalf("expected spectator game mode, got %v (%v)", mode, ok)
+	}
+
+	// Calling from a transaction of the world of the player must not
+	// deadlock.
+	var (
+		mode world.GameMode
+		ok   bool
+	)
+	<-srv.World().Exec(func(tx *world.Tx) {
+		srv.SetPlayerGameMode(tx, id, world.GameModeCreative)
+		mode, ok = srv.PlayerGameMode(tx, id)
+	})
+	if !ok || mode != world.GameModeCreative {
+		t.Fatalf("expected creative game mode, got %v (%v)", mode, ok)
+	}
+}
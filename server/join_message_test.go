@@ -0,0 +1,82 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/google/uuid"
+)
+
+// messageRecorder is a chat.Subscriber that records all messages written to
+// the chat.
+type messageRecorder struct {
+	id       uuid.UUID
+	mu       sync.Mutex
+	messages []string
+}
+
+func (r *messageRecorder) UUID() uuid.UUID { return r.id }
+
+func (r *messageRecorder) Message(a ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, fmt.Sprint(a...))
+}
+
+// await waits until the message passed was written to the chat.
+func (r *messageRecorder) await(t *testing.T, msg string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		r.mu.Lock()
+		found := slices.Contains(r.messages, msg)
+		r.mu.Unlock()
+		if found {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected message %q to be broadcast, got %q", msg, r.messages)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestJoinMessageFunc(t *testing.T) {
+	rec := &messageRecorder{id: uuid.New()}
+	chat.Global.Subscribe(rec)
+	t.Cleanup(func() { chat.Global.Unsubscribe(rec) })
+
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	srv := Config{
+		Log:                     log,
+		DisableResourceBuilding: true,
+		JoinMessage:             chat.MessageJoin,
+		QuitMessage:             chat.MessageQuit,
+		JoinMessageFunc: func(p *player.Player) (chat.Translation, []any, bool) {
+			// Prepend the rank of the player to its name.
+			return chat.MessageJoin, []any{"[Admin] " + p.Name()}, true
+		},
+	}.New()
+	closeWorlds(t, srv)
+
+	conn := newLoginConn(uuid.New())
+	acceptConn(srv, conn, srv.World())
+	rec.await(t, chat.MessageJoin.F("[Admin] Steve").String())
+
+	// Without a QuitMessageFunc, the default QuitMessage is broadcast when
+	// the player leaves.
+	disconnect(t, srv, conn)
+	rec.await(t, chat.MessageQuit.F("Steve").String())
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if slices.Contains(rec.messages, chat.MessageJoin.F("Steve").String()) {
+		t.Fatalf("expected default join message to be replaced, got %q", rec.messages)
+	}
+}
@@ -3,6 +3,8 @@ package bossbar
 import (
 	"fmt"
 	"strings"
+
+	"github.com/go-gl/mathgl/mgl64"
 )
 
 // BossBar represents a boss bar that may be sent to a player. It is shown as a purple bar with text above
@@ -26,14 +28,10 @@ func (bar BossBar) Text() string {
 	return bar.text
 }
 
-// WithHealthPercentage sets the health percentage of the boss bar. The value passed must be between 0 and 1.
-// If a value out of that range is passed, WithHealthPercentage panics.
-// The new BossBar with the changed health percentage is returned.
+// WithHealthPercentage sets the health percentage of the boss bar. The value passed is clamped between 0
+// and 1. The new BossBar with the changed health percentage is returned.
 func (bar BossBar) WithHealthPercentage(v float64) BossBar {
-	if v < 0 || v > 1 {
-		panic("boss bar: value out of range: health percentage must be between 0.0 and 1.0")
-	}
-	bar.health = v
+	bar.health = mgl64.Clamp(v, 0, 1)
 	return bar
 }
 
@@ -0,0 +1,105 @@
+package player
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	_ "unsafe"
+)
+
+func init() {
+	worldFinaliseBlockRegistry()
+}
+
+//go:linkname worldFinaliseBlockRegistry github.com/df-mc/dragonfly/server/world.finaliseBlockRegistry
+func worldFinaliseBlockRegistry()
+
+// placeRecorder is a Handler that records if HandleBlockPlace was called.
+type placeRecorder struct {
+	NopHandler
+	placed bool
+}
+
+func (h *placeRecorder) HandleBlockPlace(*Context, cube.Pos, world.Block) { h.placed = true }
+
+func TestBuildPolicyDeniesPlacement(t *testing.T) {
+	w := world.Config{Generator: world.NopGenerator{}, Provider: world.NopProvider{}}.New()
+	defer w.Close()
+
+	denied, allowed := cube.Pos{1, 10, 0}, cube.Pos{-1, 10, 0}
+	w.SetBuildPolicy(func(e world.Entity, pos cube.Pos, action world.BuildAction) bool {
+		return pos != denied || action != world.BuildActionPlace
+	})
+
+	<-w.Exec(func(tx *world.Tx) {
+		spawn := mgl64.Vec3{0.5, 10, 0.5}
+		handle := world.EntitySpawnOpts{Position: spawn, ID: uuid.New()}.New(Type, Config{Position: spawn, GameMode: world.GameModeSurvival})
+		p := tx.AddEntity(handle).(*Player)
+		h := &placeRecorder{}
+		p.Handle(h)
+
+		if p.placeBlock(denied, block.Stone{}, true) {
+			t.Errorf("expected placement denied by build policy to fail")
+		}
+		if _, ok := tx.Block(denied).(block.Air); !ok {
+			t.Errorf("expected no block to be placed at %v, got %v", denied, tx.Block(denied))
+		}
+		if h.placed {
+			t.Errorf("expected placement denied by build policy not to reach the handler")
+		}
+		if !p.placeBlock(allowed, block.Stone{}, true) {
+			t.Errorf("expected placement allowed by build policy to succeed")
+		}
+		if !h.placed {
+			t.Errorf("expected allowed placement to reach the handler")
+		}
+	})
+}
+
+func TestSpawnProtectionDeniesPlacement(t *testing.T) {
+	var bypass bool
+	w := world.Config{
+		Generator:             world.NopGenerator{},
+		Provider:              world.NopProvider{},
+		SpawnProtectionRadius: 4,
+		SpawnProtectionBypass: func(world.Entity) bool { return bypass },
+	}.New()
+	defer w.Close()
+	w.SetSpawn(cube.Pos{0, 10, 0})
+
+	protected, outside, bypassed := cube.Pos{4, 10, -3}, cube.Pos{5, 10, 0}, cube.Pos{-4, 10, 4}
+	var deniedInside, placedInside, placedOutside, placedBypassed bool
+	<-w.Exec(func(tx *world.Tx) {
+		spawn := mgl64.Vec3{0.5, 10, 0.5}
+		handle := world.EntitySpawnOpts{Position: spawn, ID: uuid.New()}.New(Type, Config{Position: spawn, GameMode: world.GameModeSurvival})
+		p := tx.AddEntity(handle).(*Player)
+		h := &placeRecorder{}
+		p.Handle(h)
+
+		deniedInside = !p.placeBlock(protected, block.Stone{}, true)
+		_, air := tx.Block(protected).(block.Air)
+		placedInside = !air || h.placed
+		placedOutside = p.placeBlock(outside, block.Stone{}, true)
+
+		bypass = true
+		placedBypassed = p.placeBlock(bypassed, block.Stone{}, true)
+	})
+	if !deniedInside || placedInside {
+		t.Fatalf("expected placement within spawn protection radius to be cancelled")
+	}
+	if !placedOutside {
+		t.Fatalf("expected placement outside spawn protection radius to succeed")
+	}
+	if !placedBypassed {
+		t.Fatalf("expected placement by entity bypassing spawn protection to succeed")
+	}
+
+	w.SetSpawnProtectionRadius(0)
+	if r := w.SpawnProtectionRadius(); r != 0 {
+		t.Fatalf("expected spawn protection radius 0, got %v", r)
+	}
+}
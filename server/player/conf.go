@@ -2,6 +2,7 @@ package player
 
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/item/inventory"
@@ -45,6 +46,33 @@ type Config struct {
 	FireTicks              int64
 	FallDistance           float64
 	Effects                []effect.Effect
+	// CommandLimiter, if non-nil, is consulted before every command the
+	// player executes. It may be used to rate limit commands.
+	CommandLimiter CommandLimiter
+	// Auditor, if non-nil, is notified of commands executed and blocks
+	// broken by the player. It may be used to keep an audit log.
+	Auditor Auditor
+}
+
+// CommandLimiter limits how often a player may execute commands.
+type CommandLimiter interface {
+	// AllowCommand reports if the player with the UUID passed may execute
+	// the command with the name passed now. If not, the time left until the
+	// command may be executed again is returned.
+	AllowCommand(id uuid.UUID, name string) (wait time.Duration, ok bool)
+	// CommandExecuted is called after the player with the UUID passed
+	// successfully executed the command with the name passed.
+	CommandExecuted(id uuid.UUID, name string)
+}
+
+// Auditor is notified of notable actions of a player after they happened.
+type Auditor interface {
+	// CommandExecuted is called after the player successfully executed the
+	// command passed with the arguments passed.
+	CommandExecuted(p *Player, command cmd.Command, args []string)
+	// BlockBroken is called after the player broke the block passed at the
+	// position passed.
+	BlockBroken(p *Player, pos cube.Pos, b world.Block)
 }
 
 // Apply applies fields from a Config to a world.EntityData, filling out empty
@@ -67,6 +95,8 @@ func (cfg Config) Apply(data *world.EntityData) {
 		effects:             entity.NewEffectManager(conf.Effects...),
 		locale:              conf.Locale,
 		cooldowns:           make(map[string]time.Time),
+		commandLimiter:      conf.CommandLimiter,
+		auditor:             conf.Auditor,
 		mc:                  &entity.MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
 		tc:                  &entity.TravelComputer{},
 		heldSlot:            &slot,
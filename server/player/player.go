@@ -69,7 +69,9 @@ type playerData struct {
 	airSupplyTicks    int
 	maxAirSupplyTicks int
 
-	cooldowns map[string]time.Time
+	cooldowns      map[string]time.Time
+	commandLimiter CommandLimiter
+	auditor        Auditor
 
 	speed               float64
 	flightSpeed         float64
@@ -305,6 +307,20 @@ func (p *Player) FallDistance() float64 {
 	return p.fallDistance
 }
 
+// SetPlayerListName changes the name shown for the player in the player list
+// of all players, for example to show a rank or a coloured name. Passing an
+// empty name resets it to the name of the player.
+func (p *Player) SetPlayerListName(name string) {
+	p.session().SetPlayerListName(name)
+}
+
+// PlayerListName returns the name shown for the player in the player list, as
+// set using SetPlayerListName. An empty string is returned if the name of the
+// player is shown.
+func (p *Player) PlayerListName() string {
+	return p.session().PlayerListName()
+}
+
 // SendTitle sends a title to the player. The title may be configured to change the duration it is displayed
 // and the text it shows.
 // If non-empty, the subtitle is shown in a smaller font below the title. The same counts for the action text
@@ -366,13 +382,36 @@ func (p *Player) ExecuteCommand(commandLine string) {
 	if p.Dead() {
 		return
 	}
-	cmd.ExecuteLine(p, commandLine, p.tx, func(command cmd.Command, args []string) bool {
+	var (
+		executed cmd.Command
+		args     []string
+	)
+	ok := cmd.ExecuteLine(p, commandLine, p.tx, func(command cmd.Command, a []string) bool {
+		if p.commandLimiter != nil {
+			// Commands refused by the limiter never reach the Handler.
+			if wait, ok := p.commandLimiter.AllowCommand(p.UUID(), command.Name()); !ok {
+				o := &cmd.Output{}
+				o.Errorf("You must wait %.1f seconds before using /%v again.", wait.Seconds(), command.Name())
+				p.SendCommandOutput(o)
+				return false
+			}
+		}
 		ctx := event.C(p)
-		if p.Handler().HandleCommandExecution(ctx, command, args); ctx.Cancelled() {
+		if p.Handler().HandleCommandExecution(ctx, command, a); ctx.Cancelled() {
 			return false
 		}
+		executed, args = command, a
 		return true
 	})
+	if !ok {
+		return
+	}
+	if p.commandLimiter != nil {
+		p.commandLimiter.CommandExecuted(p.UUID(), executed.Name())
+	}
+	if p.auditor != nil {
+		p.auditor.CommandExecuted(p, executed, args)
+	}
 }
 
 // Transfer transfers the player to a server at the address passed. If the address could not be resolved, an
@@ -2252,6 +2291,10 @@ func (p *Player) placeBlock(pos cube.Pos, b world.Block, ignoreBBox bool) bool {
 		return false
 	}
 
+	if !p.tx.MayBuild(p, pos, world.BuildActionPlace) {
+		p.resendBlocks(pos, cube.Faces()...)
+		return false
+	}
 	ctx := event.C(p)
 	if p.Handler().HandleBlockPlace(ctx, pos, b); ctx.Cancelled() {
 		p.resendBlocks(pos, cube.Faces()...)
@@ -2309,6 +2352,10 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 		p.resendBlocks(pos)
 		return
 	}
+	if !p.tx.MayBuild(p, pos, world.BuildActionBreak) {
+		p.resendBlocks(pos)
+		return
+	}
 	held, _ := p.HeldItems()
 	drops := p.drops(held, b)
 
@@ -2341,6 +2388,9 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 		opts := world.EntitySpawnOpts{Position: pos.Vec3Centre(), Velocity: mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1}}
 		p.tx.AddEntity(entity.NewItem(opts, drop))
 	}
+	if p.auditor != nil {
+		p.auditor.BlockBroken(p, pos, b)
+	}
 
 	p.Exhaust(0.005)
 	if block.BreaksInstantly(b, held) {
@@ -2557,6 +2607,12 @@ func (p *Player) SetVelocity(velocity mgl64.Vec3) {
 	releaseBorrowedViewers(p.tx, viewers, release)
 }
 
+// BroadcastsVelocity reports if SetVelocity shows the new velocity to the
+// viewers of the player, which it does unless the player has no session.
+func (p *Player) BroadcastsVelocity() bool {
+	return p.session() != session.Nop
+}
+
 // Rotation returns the yaw and pitch of the player in degrees. Yaw is horizontal rotation (rotation around the
 // vertical axis, 0 when facing forward), pitch is vertical rotation (rotation around the horizontal axis, also 0
 // when facing forward).
@@ -2794,7 +2850,7 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 	p.tickFood()
 	p.tickAirSupply()
 
-	if p.Position()[1] < float64(p.tx.Range()[0]) {
+	if p.tx.InVoid(p.Position()[1]) {
 		p.Hurt(4, entity.VoidDamageSource{})
 	}
 	if p.insideOfSolid() {
@@ -2,6 +2,7 @@ package playerdb
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/df-mc/dragonfly/server/player"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/goleveldb/leveldb"
@@ -17,6 +18,9 @@ type Provider struct {
 	db *leveldb.DB
 }
 
+// Compile time check to make sure Provider implements player.DataProvider.
+var _ player.DataProvider = (*Provider)(nil)
+
 // NewProvider creates a new player data provider that saves and loads data using
 // a LevelDB database.
 func NewProvider(path string) (*Provider, error) {
@@ -55,6 +59,30 @@ func (p *Provider) Load(id uuid.UUID, world func(world.Dimension) *world.World)
 	return conf, w, nil
 }
 
+// SaveData ...
+func (p *Provider) SaveData(id uuid.UUID, namespace string, data []byte) error {
+	if data == nil {
+		return p.db.Delete(dataKey(id, namespace), nil)
+	}
+	return p.db.Put(dataKey(id, namespace), data, nil)
+}
+
+// LoadData ...
+func (p *Provider) LoadData(id uuid.UUID, namespace string) ([]byte, error) {
+	b, err := p.db.Get(dataKey(id, namespace), nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, nil
+	}
+	return b, err
+}
+
+// dataKey returns the key under which the custom data of a player in a
+// namespace is stored. The key is prefixed so that it never collides with the
+// key of the player's Config, which is the UUID alone.
+func dataKey(id uuid.UUID, namespace string) []byte {
+	return append(append([]byte("data"), id[:]...), namespace...)
+}
+
 // Close ...
 func (p *Provider) Close() error {
 	return p.db.Close()
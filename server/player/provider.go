@@ -20,6 +20,20 @@ type Provider interface {
 	io.Closer
 }
 
+// DataProvider is a Provider that can additionally store custom data of
+// players, such as the currency or rank of a player on a server. The data is
+// kept alongside the data of the player, but separately from its Config, in
+// namespaces so that multiple users of the provider do not conflict.
+type DataProvider interface {
+	Provider
+	// SaveData saves the custom data of a player in a namespace. Passing nil
+	// data removes the data saved.
+	SaveData(uuid uuid.UUID, namespace string, data []byte) error
+	// LoadData loads the custom data of a player in a namespace. If no data
+	// was saved, LoadData returns nil data and a nil error.
+	LoadData(uuid uuid.UUID, namespace string) ([]byte, error)
+}
+
 // Compile time check to make sure NopProvider implements Provider.
 var _ Provider = (*NopProvider)(nil)
 
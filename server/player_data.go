@@ -0,0 +1,34 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/google/uuid"
+)
+
+// SavePlayerData saves custom data of a player, such as its currency or rank,
+// in a namespace in the PlayerProvider of the Server, alongside the data of
+// the player itself. Passing nil data removes the data saved. The player does
+// not need to be online. An error is returned if the PlayerProvider does not
+// implement player.DataProvider.
+func (srv *Server) SavePlayerData(id uuid.UUID, namespace string, data []byte) error {
+	prov, ok := srv.conf.PlayerProvider.(player.DataProvider)
+	if !ok {
+		return fmt.Errorf("save player data: player provider %T does not support custom data", srv.conf.PlayerProvider)
+	}
+	return prov.SaveData(id, namespace, data)
+}
+
+// LoadPlayerData loads custom data of a player saved in a namespace using
+// SavePlayerData. Because the data is kept in the PlayerProvider, it may be
+// loaded at any time, including while the player is joining. Nil data is
+// returned if no data was saved. An error is returned if the PlayerProvider
+// does not implement player.DataProvider.
+func (srv *Server) LoadPlayerData(id uuid.UUID, namespace string) ([]byte, error) {
+	prov, ok := srv.conf.PlayerProvider.(player.DataProvider)
+	if !ok {
+		return nil, fmt.Errorf("load player data: player provider %T does not support custom data", srv.conf.PlayerProvider)
+	}
+	return prov.LoadData(id, namespace)
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/player/playerdb"
+	"github.com/google/uuid"
+)
+
+func TestPlayerDataRoundTrip(t *testing.T) {
+	prov, err := playerdb.NewProvider(filepath.Join(t.TempDir(), "players"))
+	if err != nil {
+		t.Fatalf("open player provider: %v", err)
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	srv := Config{Log: log, DisableResourceBuilding: true, PlayerProvider: prov}.New()
+	closeWorlds(t, srv)
+	t.Cleanup(func() { _ = prov.Close() })
+
+	id := uuid.New()
+	if data, err := srv.LoadPlayerData(id, "economy"); err != nil || data != nil {
+		t.Fatalf("expected no data before saving, got %v, %v", data, err)
+	}
+	if err := srv.SavePlayerData(id, "economy", []byte("balance=100")); err != nil {
+		t.Fatalf("save player data: %v", err)
+	}
+	if err := srv.SavePlayerData(id, "ranks", []byte("vip")); err != nil {
+		t.Fatalf("save player data: %v", err)
+	}
+	if data, err := srv.LoadPlayerData(id, "economy"); err != nil || !bytes.Equal(data, []byte("balance=100")) {
+		t.Fatalf("expected economy data to round-trip, got %q, %v", data, err)
+	}
+	if data, err := srv.LoadPlayerData(id, "ranks"); err != nil || !bytes.Equal(data, []byte("vip")) {
+		t.Fatalf("expected ranks data to round-trip, got %q, %v", data, err)
+	}
+	if data, err := srv.LoadPlayerData(uuid.New(), "economy"); err != nil || data != nil {
+		t.Fatalf("expected no data for another player, got %q, %v", data, err)
+	}
+
+	if err := srv.SavePlayerData(id, "economy", nil); err != nil {
+		t.Fatalf("remove player data: %v", err)
+	}
+	if data, err := srv.LoadPlayerData(id, "economy"); err != nil || data != nil {
+		t.Fatalf("expected data to be removed, got %q, %v", data, err)
+	}
+}
+
+func TestPlayerDataUnsupportedProvider(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	srv := Config{Log: log, DisableResourceBuilding: true}.New()
+	closeWorlds(t, srv)
+
+	if err := srv.SavePlayerData(uuid.New(), "economy", []byte("x")); err == nil {
+		t.Fatalf("expected error saving data with a provider without custom data support")
+	}
+}
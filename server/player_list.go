@@ -0,0 +1,63 @@
+package server
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+)
+
+// maxPlayerListNameLength is the maximum length in runes of a name shown in
+// the player list.
+const maxPlayerListNameLength = 64
+
+// validPlayerListName checks if a name may be shown in the player list.
+func validPlayerListName(name string) bool {
+	return utf8.RuneCountInString(name) <= maxPlayerListNameLength && !strings.ContainsAny(name, "\r\n")
+}
+
+// SetPlayerListName changes the name shown in the player list of all players
+// for the online player with the UUID passed, for example to show a rank or a
+// coloured name. Passing an empty name resets it to the name of the player.
+// False is returned if the player is not online or if the name is longer than
+// 64 characters or contains a line break. tx should be the transaction that
+// the caller is running in, or nil if it is not running in one.
+func (srv *Server) SetPlayerListName(tx *world.Tx, id uuid.UUID, name string) bool {
+	if !validPlayerListName(name) {
+		return false
+	}
+	return srv.withPlayer(tx, id, func(p *player.Player) {
+		p.SetPlayerListName(name)
+	})
+}
+
+// AddPlayerListEntry adds an entry that does not belong to an online player
+// to the player list of all players, including players that join later. The
+// entry may be removed again using RemovePlayerListEntry. If sk is the zero
+// value, a blank skin is used. False is returned if the name is empty, longer
+// than 64 characters or contains a line break, or if a player with the UUID
+// passed is online.
+func (srv *Server) AddPlayerListEntry(id uuid.UUID, name string, sk skin.Skin) bool {
+	if name == "" || !validPlayerListName(name) {
+		return false
+	}
+	if _, ok := srv.Player(id); ok {
+		return false
+	}
+	if sk.Bounds().Empty() {
+		sk = skin.New(64, 64)
+	}
+	session.AddPlayerListEntry(id, name, sk)
+	return true
+}
+
+// RemovePlayerListEntry removes an entry added using AddPlayerListEntry from
+// the player list of all players. False is returned if no entry with the UUID
+// passed was added.
+func (srv *Server) RemovePlayerListEntry(id uuid.UUID) bool {
+	return session.RemovePlayerListEntry(id)
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// stubConn is a session.Conn that discards all packets written to it.
+type stubConn struct{}
+
+func (stubConn) Close() error { return nil }
+func (stubConn) IdentityData() login.IdentityData {
+	return login.IdentityData{Identity: uuid.NewString(), DisplayName: "Steve"}
+}
+func (stubConn) ClientData() login.ClientData                               { return login.ClientData{} }
+func (stubConn) ClientCacheEnabled() bool                                   { return false }
+func (stubConn) ChunkRadius() int                                           { return 1 }
+func (stubConn) Latency() time.Duration                                     { return 0 }
+func (stubConn) Flush() error                                               { return nil }
+func (stubConn) RemoteAddr() net.Addr                                       { return &net.TCPAddr{} }
+func (stubConn) ReadPacket() (packet.Packet, error)                         { return nil, io.EOF }
+func (stubConn) WritePacket(packet.Packet) error                            { return nil }
+func (stubConn) StartGameContext(context.Context, minecraft.GameData) error { return nil }
+
+func TestSetPlayerListName(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	srv := Config{Log: log, DisableResourceBuilding: true}.New()
+	closeWorlds(t, srv)
+
+	sess := session.Config{Log: log, MaxChunkRadius: 1}.New(stubConn{})
+	t.Cleanup(sess.CloseConnection)
+	id := uuid.New()
+	conf := player.Config{Session: sess, Skin: skin.New(64, 64)}
+	handle := world.EntitySpawnOpts{ID: id}.New(player.Type, conf)
+	sess.SetHandle(handle, conf.Skin)
+	<-srv.World().Exec(func(tx *world.Tx) {
+		tx.AddEntity(handle)
+	})
+	srv.p[id] = &onlinePlayer{name: "Steve", handle: handle}
+
+	if srv.SetPlayerListName(nil, uuid.New(), "[Admin] Steve") {
+		t.Fatalf("expected list name of offline player not to be set")
+	}
+	if srv.SetPlayerListName(nil, id, strings.Repeat("a", maxPlayerListNameLength+1)) || srv.SetPlayerListName(nil, id, "a\nb") {
+		t.Fatalf("expected invalid list name not to be set")
+	}
+	if !srv.SetPlayerListName(nil, id, "[Admin] Steve") {
+		t.Fatalf("expected list name to be set")
+	}
+	handle.ExecWorld(func(tx *world.Tx, e world.Entity) {
+		if name := e.(*player.Player).PlayerListName(); name != "[Admin] Steve" {
+			t.Fatalf("expected list name [Admin] Steve, got %q", name)
+		}
+		// Setting the name from a transaction of the world of the player
+		// must not deadlock.
+		if !srv.SetPlayerListName(tx, id, "[Mod] Steve") {
+			t.Fatalf("expected list name to be set from a transaction")
+		}
+		if name := e.(*player.Player).PlayerListName(); name != "[Mod] Steve" {
+			t.Fatalf("expected list name [Mod] Steve, got %q", name)
+		}
+	})
+
+	entry := uuid.New()
+	if srv.AddPlayerListEntry(id, "Fake", skin.Skin{}) || srv.AddPlayerListEntry(entry, "", skin.Skin{}) {
+		t.Fatalf("expected invalid player list entry not to be added")
+	}
+	if !srv.AddPlayerListEntry(entry, "Fake", skin.Skin{}) {
+		t.Fatalf("expected player list entry to be added")
+	}
+	if !srv.RemovePlayerListEntry(entry) || srv.RemovePlayerListEntry(entry) {
+		t.Fatalf("expected player list entry to be removed exactly once")
+	}
+}
@@ -18,12 +18,26 @@ type packetConn struct {
 	log  Logger
 	host string
 	port int
+	// disabled specifies if query handling is disabled for this connection,
+	// in which case query packets are passed on like other packets.
+	disabled bool
 
 	mu     sync.Mutex
 	tokens map[string]token
 	rng    *rand.Rand
+
+	// closed is closed when the packetConn is closed, stopping the goroutine
+	// sweeping expired tokens. sweepDone is closed once that goroutine has
+	// stopped.
+	closed    chan struct{}
+	closeOnce sync.Once
+	sweepDone chan struct{}
 }
 
+// tokenLifetime is the duration after which a token issued to an address
+// expires.
+const tokenLifetime = 30 * time.Second
+
 // Logger provides the logging capabilities used by the query implementation.
 type Logger interface {
 	Debug(msg string, args ...any)
@@ -42,7 +56,7 @@ func (c *packetConn) ReadFrom(p []byte) (int, net.Addr, error) {
 		if err != nil || n == 0 {
 			return n, addr, err
 		}
-		if c.handleQuery(p[:n], addr) {
+		if !c.disabled && c.handleQuery(p[:n], addr) {
 			continue
 		}
 		return n, addr, nil
@@ -73,6 +87,10 @@ func (c *packetConn) handleQuery(b []byte, addr net.Addr) bool {
 		if !c.validateToken(addr.String(), token) {
 			return true
 		}
+		if isBasicStat(b[7:]) {
+			c.writeBasicInfo(addr, sequence)
+			return true
+		}
 		c.writeInfo(addr, sequence)
 		return true
 	default:
@@ -95,7 +113,7 @@ func (c *packetConn) newToken(addr string) int32 {
 	value := int32(c.rng.Int31())
 	c.tokens[addr] = token{
 		value:  value,
-		expiry: time.Now().Add(30 * time.Second),
+		expiry: time.Now().Add(tokenLifetime),
 	}
 	return value
 }
@@ -114,6 +132,52 @@ func (c *packetConn) validateToken(addr string, value int32) bool {
 	return true
 }
 
+// startSweep starts a goroutine that removes expired tokens every interval,
+// until the packetConn is closed. If interval is 0 or lower, no goroutine is
+// started and expired tokens are only removed when they are validated.
+func (c *packetConn) startSweep(interval time.Duration) {
+	c.closed, c.sweepDone = make(chan struct{}), make(chan struct{})
+	if interval <= 0 {
+		close(c.sweepDone)
+		return
+	}
+	go func() {
+		defer close(c.sweepDone)
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-c.closed:
+				return
+			case now := <-t.C:
+				c.sweepTokens(now)
+			}
+		}
+	}()
+}
+
+// sweepTokens removes all tokens that expired before the time passed.
+func (c *packetConn) sweepTokens(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for addr, token := range c.tokens {
+		if now.After(token.expiry) {
+			delete(c.tokens, addr)
+		}
+	}
+}
+
+// Close stops the goroutine sweeping expired tokens and closes the wrapped
+// PacketConn.
+func (c *packetConn) Close() error {
+	c.closeOnce.Do(func() {
+		if c.closed != nil {
+			close(c.closed)
+		}
+	})
+	return c.PacketConn.Close()
+}
+
 // writeHandshake constructs the handshake response that contains the issued
 // token.
 func (c *packetConn) writeHandshake(addr net.Addr, sequence, token int32) {
@@ -165,6 +229,49 @@ func (c *packetConn) writeInfo(addr net.Addr, sequence int32) {
 	}
 }
 
+// writeBasicInfo renders the short server information payload sent in response
+// to a basic stat request. Unlike the full payload, values are written in a
+// fixed order without their keys and the host port is encoded as a
+// little-endian short. Legacy tools read the values by position: MOTD, game
+// type, map, player count, max players, host port and host IP.
+func (c *packetConn) writeBasicInfo(addr net.Addr, sequence int32) {
+	data := collectData(c.host, c.port)
+
+	buf := bytes.NewBuffer(make([]byte, 0, 128))
+	buf.WriteByte(queryTypeInformation)
+	_ = binary.Write(buf, binary.BigEndian, sequence)
+
+	for _, v := range []string{
+		data.HostName,
+		data.GameType,
+		data.WorldName,
+		strconv.Itoa(data.PlayerCount),
+		strconv.Itoa(data.MaxPlayers),
+	} {
+		buf.WriteString(v)
+		buf.WriteByte(0x00)
+	}
+	_ = binary.Write(buf, binary.LittleEndian, uint16(data.HostPort))
+	buf.WriteString(data.HostIP)
+	buf.WriteByte(0x00)
+
+	if _, err := c.PacketConn.WriteTo(buf.Bytes(), addr); err != nil {
+		c.log.Debug("query basic info write failed", "err", err, "raddr", addr.String())
+	}
+}
+
+// isBasicStat checks if the payload following the sequence number of an
+// information request is that of a basic stat request. A full stat request
+// pads the challenge token with four additional bytes, while a basic stat
+// request carries the token alone.
+func isBasicStat(payload []byte) bool {
+	if len(payload) <= 4 {
+		return true
+	}
+	_, err := strconv.ParseInt(string(payload), 10, 32)
+	return err == nil
+}
+
 func parseTokenValue(payload []byte) (int32, bool) {
 	trimmed := payload
 	if len(trimmed) >= 4 {
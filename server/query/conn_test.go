@@ -1,6 +1,7 @@
 package query
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"net"
@@ -206,3 +207,175 @@ func isClosedError(err error) bool {
 	}
 	return strings.Contains(err.Error(), "use of closed network connection")
 }
+
+func TestHandleQueryBasicAndFullStat(t *testing.T) {
+	lastSnapshot.Store(nil)
+	RegisterProvider(func(host string, port int) Data {
+		return Data{
+			HostName:    "Test Server",
+			Version:     "1.21.100",
+			WorldName:   "Overworld",
+			PlayerCount: 2,
+			MaxPlayers:  20,
+			PlayerNames: []string{"Alex", "Steve"},
+			HostIP:      host,
+			HostPort:    port,
+		}
+	})
+	t.Cleanup(func() {
+		RegisterProvider(nil)
+		lastSnapshot.Store(nil)
+	})
+
+	recorder := &packetRecorder{}
+	pc := &packetConn{
+		PacketConn: recorder,
+		log:        nopLogger{},
+		host:       "127.0.0.1",
+		port:       19132,
+	}
+	addr := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 43210}
+
+	request := func(padding bool) []byte {
+		pc.mu.Lock()
+		pc.tokens = map[string]token{addr.String(): {value: 1234567, expiry: time.Now().Add(time.Minute)}}
+		pc.mu.Unlock()
+
+		payload := append([]byte(nil), queryVersion[:]...)
+		payload = append(payload, queryTypeInformation)
+		payload = binary.BigEndian.AppendUint32(payload, 7)
+		payload = binary.BigEndian.AppendUint32(payload, 1234567)
+		if padding {
+			payload = append(payload, 0x00, 0x00, 0x00, 0x00)
+		}
+		if !pc.handleQuery(payload, addr) {
+			t.Fatalf("expected query information request to be handled")
+		}
+		return recorder.writes[len(recorder.writes)-1]
+	}
+
+	basic := request(false)
+	if basic[0] != queryTypeInformation || binary.BigEndian.Uint32(basic[1:5]) != 7 {
+		t.Fatalf("unexpected basic stat header: %v", basic[:5])
+	}
+	// Read the response by position, like legacy basic stat tools do.
+	rest := basic[5:]
+	readString := func() string {
+		i := bytes.IndexByte(rest, 0x00)
+		if i < 0 {
+			t.Fatalf("expected null terminated string in basic stat response, got %q", rest)
+		}
+		v := string(rest[:i])
+		rest = rest[i+1:]
+		return v
+	}
+	want := []string{"Test Server", "SMP", "Overworld", "2", "20"}
+	for i, v := range want {
+		if got := readString(); got != v {
+			t.Fatalf("unexpected basic stat field %v: got %q, want %q", i, got, v)
+		}
+	}
+	if len(rest) < 2 {
+		t.Fatalf("expected host port in basic stat response")
+	}
+	port := binary.LittleEndian.Uint16(rest[:2])
+	rest = rest[2:]
+	if port != 19132 {
+		t.Fatalf("unexpected basic stat host port: got %v, want %v", port, 19132)
+	}
+	if ip := readString(); ip != "127.0.0.1" || len(rest) != 0 {
+		t.Fatalf("unexpected basic stat host ip: %q, followed by %q", ip, rest)
+	}
+	if strings.Contains(string(basic), "Alex") {
+		t.Fatalf("expected basic stat response not to include player names")
+	}
+
+	full := request(true)
+	if !bytes.Equal(full[5:16], append(querySplitNum[:], 0x80, 0x00)) {
+		t.Fatalf("expected full stat response to carry the split num padding, got %v", full[5:16])
+	}
+	for _, s := range []string{"hostname\x00Test Server\x00", "numplayers\x002\x00", "Alex\x00Steve\x00"} {
+		if !strings.Contains(string(full), s) {
+			t.Fatalf("expected full stat response to contain %q", s)
+		}
+	}
+}
+
+func TestQueryDisabledAddress(t *testing.T) {
+	SetDisabledAddresses("127.0.0.1:0")
+	t.Cleanup(func() { SetDisabledAddresses() })
+
+	pc, err := (&packetListener{}).ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen packet: %v", err)
+	}
+	defer pc.Close()
+	if !pc.(*packetConn).disabled {
+		t.Fatalf("expected query to be disabled for listener")
+	}
+
+	client, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen packet: %v", err)
+	}
+	defer client.Close()
+
+	handshake := []byte{queryVersion[0], queryVersion[1], queryTypeHandshake, 0, 0, 0, 1}
+	if _, err := client.WriteTo(handshake, pc.LocalAddr()); err != nil {
+		t.Fatalf("write handshake: %v", err)
+	}
+
+	_ = pc.SetReadDeadline(time.Now().Add(time.Second))
+	buf := make([]byte, 64)
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("expected handshake to be passed through, got error: %v", err)
+	}
+	if !bytes.Equal(buf[:n], handshake) {
+		t.Fatalf("expected handshake to be passed through unchanged, got %x", buf[:n])
+	}
+
+	_ = client.SetReadDeadline(time.Now().Add(time.Millisecond * 100))
+	if _, _, err := client.ReadFrom(buf); err == nil {
+		t.Fatalf("expected handshake not to be answered")
+	}
+}
+
+func TestTokenSweepRemovesExpiredTokens(t *testing.T) {
+	c := &packetConn{PacketConn: &packetRecorder{}, log: nopLogger{}}
+	c.newToken("127.0.0.1:1")
+	c.newToken("127.0.0.1:2")
+	c.mu.Lock()
+	expired := c.tokens["127.0.0.1:1"]
+	expired.expiry = time.Now().Add(-time.Second)
+	c.tokens["127.0.0.1:1"] = expired
+	c.mu.Unlock()
+
+	c.startSweep(time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		c.mu.Lock()
+		_, expiredLeft := c.tokens["127.0.0.1:1"]
+		_, validLeft := c.tokens["127.0.0.1:2"]
+		c.mu.Unlock()
+		if !validLeft {
+			t.Fatalf("expected token that did not expire to be kept")
+		}
+		if !expiredLeft {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected expired token to be removed by the sweep")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	select {
+	case <-c.sweepDone:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected sweep to stop after closing the connection")
+	}
+}
@@ -1,6 +1,8 @@
 package query
 
 import (
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -24,7 +26,8 @@ type Data struct {
 	// WorldName holds the name of the primary world exposed by the server.
 	WorldName string
 	// Engine identifies the software that powers the server. When empty the
-	// package falls back to the compiled engineLabel.
+	// package falls back to the label set using SetEngineLabel or the
+	// compiled engineLabel.
 	Engine string
 	// Version represents the protocol version string advertised to clients.
 	Version string
@@ -47,6 +50,10 @@ type Data struct {
 	GameID string
 	// WhitelistEnabled indicates whether the server whitelist is enabled.
 	WhitelistEnabled bool
+	// Extra holds additional key/value pairs sent to query clients. They are
+	// sent after the standard pairs, sorted by key. Keys that collide with
+	// standard keys are ignored.
+	Extra map[string]string
 }
 
 type keyValue struct {
@@ -92,7 +99,7 @@ func (d *Data) applyDefaults() {
 		d.HostIP = "0.0.0.0"
 	}
 	if d.Engine == "" {
-		d.Engine = engineLabel
+		d.Engine = engine()
 	}
 	if d.Version == "" {
 		d.Version = protocol.CurrentVersion
@@ -144,6 +151,12 @@ func (d Data) keyValues() []keyValue {
 	} else {
 		values = append(values, keyValue{"plugins", ""})
 	}
+	for _, k := range slices.Sorted(maps.Keys(d.Extra)) {
+		if slices.ContainsFunc(values, func(v keyValue) bool { return v.key == k }) || k == "players" {
+			continue
+		}
+		values = append(values, keyValue{k, d.Extra[k]})
+	}
 	if len(d.PlayerNames) > 0 {
 		values = append(values, keyValue{"players", strings.Join(d.PlayerNames, ", ")})
 	}
@@ -155,7 +168,7 @@ func (d Data) keyValues() []keyValue {
 func defaultData(host string, port int) Data {
 	data := Data{
 		HostName: "Minecraft Server",
-		Engine:   engineLabel,
+		Engine:   engine(),
 		Version:  protocol.CurrentVersion,
 		HostIP:   canonicalHost(host),
 		HostPort: port,
@@ -181,6 +194,25 @@ func loadSnapshot() (Data, bool) {
 	return cloneData(*snap), true
 }
 
+// Snapshot returns the key/value pairs and player names of the data last sent
+// to query clients, as they were sent. It may be used to report the status of
+// the server over another transport, such as an HTTP endpoint. Both return
+// values are nil if no query data was collected yet. The values returned are
+// copies that may be modified freely.
+func Snapshot() (map[string]string, []string) {
+	snap, ok := loadSnapshot()
+	if !ok {
+		return nil, nil
+	}
+	snap.applyDefaults()
+	kv := snap.keyValues()
+	values := make(map[string]string, len(kv))
+	for _, v := range kv {
+		values[v.key] = v.value
+	}
+	return values, snap.PlayerNames
+}
+
 // cloneData deep-copies the Data structure so that cached snapshots remain
 // immutable.
 func cloneData(data Data) Data {
@@ -188,5 +220,6 @@ func cloneData(data Data) Data {
 	if data.PlayerNames != nil {
 		cp.PlayerNames = append([]string(nil), data.PlayerNames...)
 	}
+	cp.Extra = maps.Clone(data.Extra)
 	return cp
 }
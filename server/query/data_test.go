@@ -0,0 +1,101 @@
+package query
+
+import "testing"
+
+func engineValue(t *testing.T, data Data) string {
+	t.Helper()
+	for _, kv := range data.keyValues() {
+		if kv.key == "server_engine" {
+			return kv.value
+		}
+	}
+	t.Fatalf("expected server_engine key to be present")
+	return ""
+}
+
+func TestSetEngineLabel(t *testing.T) {
+	lastSnapshot.Store(nil)
+	RegisterProvider(nil)
+	SetEngineLabel("Branded (1.0)")
+	t.Cleanup(func() {
+		SetEngineLabel("")
+		RegisterProvider(nil)
+		lastSnapshot.Store(nil)
+	})
+
+	// Without a provider or snapshot, the default data is used.
+	if got := engineValue(t, collectData("0.0.0.0", 19132)); got != "Branded (1.0)" {
+		t.Fatalf("expected custom engine label without provider, got %q", got)
+	}
+
+	RegisterProvider(func(host string, port int) Data {
+		return Data{HostName: "Test", HostIP: host, HostPort: port}
+	})
+	if got := engineValue(t, collectData("0.0.0.0", 19132)); got != "Branded (1.0)" {
+		t.Fatalf("expected custom engine label with provider, got %q", got)
+	}
+
+	// The snapshot taken from the provider keeps the label.
+	RegisterProvider(nil)
+	if got := engineValue(t, collectData("0.0.0.0", 19132)); got != "Branded (1.0)" {
+		t.Fatalf("expected custom engine label from snapshot, got %q", got)
+	}
+
+	SetEngineLabel("")
+	lastSnapshot.Store(nil)
+	if got := engineValue(t, collectData("0.0.0.0", 19132)); got != engineLabel {
+		t.Fatalf("expected build engine label after reset, got %q", got)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	lastSnapshot.Store(nil)
+	t.Cleanup(func() {
+		RegisterProvider(nil)
+		lastSnapshot.Store(nil)
+	})
+	if kv, players := Snapshot(); kv != nil || players != nil {
+		t.Fatalf("expected no snapshot, got %v %v", kv, players)
+	}
+
+	count := 1
+	RegisterProvider(func(host string, port int) Data {
+		names := []string{"Steve", "Alex"}[:count]
+		return Data{HostName: "Test", PlayerCount: count, PlayerNames: names, HostIP: host, HostPort: port}
+	})
+	collectData("127.0.0.1", 19132)
+	count = 2
+	collectData("127.0.0.1", 19132)
+
+	kv, players := Snapshot()
+	if kv["hostname"] != "Test" || kv["numplayers"] != "2" || kv["hostport"] != "19132" {
+		t.Fatalf("expected snapshot of latest data, got %v", kv)
+	}
+	if len(players) != 2 || players[0] != "Steve" || players[1] != "Alex" {
+		t.Fatalf("expected players [Steve Alex], got %v", players)
+	}
+	players[0] = "Herobrine"
+	if _, again := Snapshot(); again[0] != "Steve" {
+		t.Fatalf("expected snapshot not to be modified through returned players")
+	}
+}
+
+func TestExtraKeyValues(t *testing.T) {
+	data := Data{
+		HostName:    "Test",
+		PlayerNames: []string{"Steve"},
+		Extra:       map[string]string{"players_nether": "1", "hostname": "Other", "players_end": "0"},
+	}
+	var keys []string
+	values := map[string]string{}
+	for _, kv := range data.keyValues() {
+		keys = append(keys, kv.key)
+		values[kv.key] = kv.value
+	}
+	if values["hostname"] != "Test" {
+		t.Fatalf("expected extra pairs not to override standard pairs, got hostname %q", values["hostname"])
+	}
+	if n := len(keys); n < 3 || keys[n-3] != "players_end" || keys[n-2] != "players_nether" || keys[n-1] != "players" {
+		t.Fatalf("expected sorted extra pairs before the player list, got %v", keys)
+	}
+}
@@ -3,6 +3,8 @@ package query
 import (
 	"context"
 	"net"
+	"sync/atomic"
+	"time"
 
 	"github.com/sandertv/go-raknet"
 	"github.com/sandertv/gophertunnel/minecraft"
@@ -79,10 +81,59 @@ func (l *packetListener) ListenPacket(network, address string) (net.PacketConn,
 	if local != nil {
 		port = local.Port
 	}
-	return &packetConn{
+	c := &packetConn{
 		PacketConn: conn,
 		log:        l.log,
 		host:       host,
 		port:       port,
-	}, nil
+		disabled:   disabledFor(address),
+	}
+	c.startSweep(time.Duration(tokenSweepInterval.Load()))
+	return c, nil
+}
+
+// tokenSweepInterval holds the interval set using SetTokenSweepInterval.
+var tokenSweepInterval atomic.Int64
+
+func init() {
+	tokenSweepInterval.Store(int64(tokenLifetime))
+}
+
+// SetTokenSweepInterval sets the interval at which expired query tokens are
+// removed from memory. Tokens are issued to every address that sends a query
+// handshake and expire after 30 seconds, so a server queried by many
+// addresses would otherwise keep tokens of addresses that never return. The
+// interval defaults to 30 seconds. An interval of 0 or lower disables the
+// sweep. SetTokenSweepInterval only affects listeners started after it is
+// called.
+func SetTokenSweepInterval(interval time.Duration) {
+	tokenSweepInterval.Store(int64(interval))
+}
+
+// disabledAddresses holds the bind addresses set using SetDisabledAddresses.
+var disabledAddresses atomic.Pointer[map[string]struct{}]
+
+// SetDisabledAddresses disables query handling for listeners bound to any of
+// the addresses passed, such as ":19132" or "127.0.0.1:19132". The addresses
+// must match the address passed when the listener is started exactly. Query
+// packets received by such listeners are passed on like any other packet. By
+// default, query is enabled for all listeners. SetDisabledAddresses only
+// affects listeners started after it is called.
+func SetDisabledAddresses(addresses ...string) {
+	m := make(map[string]struct{}, len(addresses))
+	for _, addr := range addresses {
+		m[addr] = struct{}{}
+	}
+	disabledAddresses.Store(&m)
+}
+
+// disabledFor checks if query handling is disabled for listeners bound to the
+// address passed.
+func disabledFor(address string) bool {
+	m := disabledAddresses.Load()
+	if m == nil {
+		return false
+	}
+	_, ok := (*m)[address]
+	return ok
 }
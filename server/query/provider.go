@@ -39,6 +39,29 @@ func loadProvider() ProviderFunc {
 // engineLabel constructs the engine identifier that is shown by query clients.
 var engineLabel = buildEngineLabel()
 
+// engineOverride holds the engine label set using SetEngineLabel, if any.
+var engineOverride atomic.Pointer[string]
+
+// SetEngineLabel overrides the engine label reported to query clients when
+// the Data supplied does not specify an engine, including when no provider is
+// registered. Passing an empty string restores the label derived from the
+// build information.
+func SetEngineLabel(label string) {
+	if label == "" {
+		engineOverride.Store(nil)
+		return
+	}
+	engineOverride.Store(&label)
+}
+
+// engine returns the engine label reported to query clients by default.
+func engine() string {
+	if label := engineOverride.Load(); label != nil {
+		return *label
+	}
+	return engineLabel
+}
+
 // buildEngineLabel inspects build metadata to determine the engine label that
 // is reported through the query interface. The build information is optional,
 // so sane defaults are supplied when it cannot be determined.
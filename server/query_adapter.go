@@ -1,7 +1,9 @@
 package server
 
 import (
-	"sort"
+	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/df-mc/dragonfly/server/query"
@@ -9,8 +11,35 @@ import (
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 )
 
+// QueryPlayerProvider provides the names of players that should be listed in
+// query responses in addition to the players connected to the Server, such as
+// players on backend servers behind a proxy.
+type QueryPlayerProvider interface {
+	// ExtraPlayers returns the names of the extra players to list. Names of
+	// players already connected to the Server are only listed once.
+	ExtraPlayers() []string
+}
+
+// AddQueryPlayerProvider registers a QueryPlayerProvider whose players are
+// listed in query responses and counted towards the number of players online.
+func (srv *Server) AddQueryPlayerProvider(p QueryPlayerProvider) {
+	srv.qmu.Lock()
+	defer srv.qmu.Unlock()
+	srv.queryPlayers = append(srv.queryPlayers, p)
+}
+
+// QuerySnapshot returns the key/value pairs and player names that were last
+// sent to query clients. It gives programmatic access to the data query
+// clients see, for example to serve it over an HTTP endpoint. Both return
+// values are nil if no query request was handled yet.
+func QuerySnapshot() (map[string]string, []string) {
+	return query.Snapshot()
+}
+
 // registerQueryServer exposes the Server instance to the Bedrock query listener.
 func registerQueryServer(srv *Server) {
+	query.SetEngineLabel(srv.conf.QueryEngineLabel)
+	query.SetDisabledAddresses(srv.conf.QueryDisabledAddresses...)
 	query.RegisterProvider(func(host string, port int) query.Data {
 		return srv.buildQueryData(host, port)
 	})
@@ -32,7 +61,7 @@ func (srv *Server) buildQueryData(host string, port int) query.Data {
 	if pluginString == "" {
 		pluginString = "Adamant"
 	}
-	difficulty := "NORMAL"
+	difficulty := difficultyName(srv)
 
 	srv.pmu.RLock()
 	playerNames := make([]string, 0, len(srv.p))
@@ -40,7 +69,20 @@ func (srv *Server) buildQueryData(host string, port int) query.Data {
 		playerNames = append(playerNames, p.name)
 	}
 	srv.pmu.RUnlock()
-	sort.Strings(playerNames)
+	slices.Sort(playerNames)
+
+	srv.qmu.Lock()
+	providers := slices.Clone(srv.queryPlayers)
+	srv.qmu.Unlock()
+	if len(providers) > 0 {
+		online := len(playerNames)
+		for _, p := range providers {
+			playerNames = append(playerNames, p.ExtraPlayers()...)
+		}
+		slices.Sort(playerNames)
+		playerNames = slices.Compact(playerNames)
+		playerCount += len(playerNames) - online
+	}
 
 	return query.Data{
 		HostName:    status.ServerName,
@@ -55,7 +97,27 @@ func (srv *Server) buildQueryData(host string, port int) query.Data {
 		Plugins:     pluginString,
 		PlayerNames: playerNames,
 		Version:     protocol.CurrentVersion,
+		Engine:      srv.conf.QueryEngineLabel,
+		Extra:       srv.dimensionPlayerCounts(),
+	}
+}
+
+// dimensionPlayerCounts returns the "players_<dimension>" key/value pairs
+// reporting the number of players in each dimension of the server, or nil if
+// Config.QueryDimensionPlayerCounts is not set. The counts are read using
+// World.PlayerCount, so that query requests never open transactions.
+func (srv *Server) dimensionPlayerCounts() map[string]string {
+	if !srv.conf.QueryDimensionPlayerCounts {
+		return nil
 	}
+	counts := make(map[string]string, len(srv.dimensions))
+	for dim, w := range srv.dimensions {
+		if w == nil {
+			continue
+		}
+		counts["players_"+strings.ToLower(fmt.Sprint(dim))] = strconv.Itoa(w.PlayerCount())
+	}
+	return counts
 }
 
 // defaultGameModeName translates the configured default game mode into the
@@ -79,6 +141,25 @@ func defaultGameModeName(srv *Server) string {
 	return "SURVIVAL"
 }
 
+// difficultyName returns the name of the difficulty of the default world of
+// the server as reported in query responses.
+func difficultyName(srv *Server) string {
+	if srv == nil || srv.world == nil {
+		return "NORMAL"
+	}
+	if id, ok := world.DifficultyID(srv.world.Difficulty()); ok {
+		switch id {
+		case 0:
+			return "PEACEFUL"
+		case 1:
+			return "EASY"
+		case 3:
+			return "HARD"
+		}
+	}
+	return "NORMAL"
+}
+
 // plugins returns the names of active plugins. The function remains in place so
 // that the query adapter can be wired into a future plugin system.
 func (srv *Server) plugins() []string {
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+)
+
+type extraQueryPlayers []string
+
+func (e extraQueryPlayers) ExtraPlayers() []string { return e }
+
+func TestQueryPlayerProviderExtraPlayers(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	srv := Config{Log: log, DisableResourceBuilding: true}.New()
+	closeWorlds(t, srv)
+
+	srv.AddQueryPlayerProvider(extraQueryPlayers{"Steve", "Alex"})
+	// Names contributed by multiple providers should only be listed once.
+	srv.AddQueryPlayerProvider(extraQueryPlayers{"Alex"})
+
+	data := srv.buildQueryData("127.0.0.1", 19132)
+	if want := []string{"Alex", "Steve"}; !slices.Equal(data.PlayerNames, want) {
+		t.Fatalf("expected player names %v, got %v", want, data.PlayerNames)
+	}
+	if data.PlayerCount != 2 {
+		t.Fatalf("expected player count 2, got %v", data.PlayerCount)
+	}
+}
+
+func TestQueryDifficulty(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	srv := Config{Log: log, DisableResourceBuilding: true}.New()
+	closeWorlds(t, srv)
+
+	srv.World().SetDifficulty(world.DifficultyHard)
+	if data := srv.buildQueryData("127.0.0.1", 19132); data.Difficulty != "HARD" {
+		t.Fatalf("expected difficulty HARD, got %v", data.Difficulty)
+	}
+	srv.World().SetDifficulty(world.DifficultyPeaceful)
+	if data := srv.buildQueryData("127.0.0.1", 19132); data.Difficulty != "PEACEFUL" {
+		t.Fatalf("expected difficulty PEACEFUL, got %v", data.Difficulty)
+	}
+}
+
+func TestQueryDimensionPlayerCounts(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	srv := Config{Log: log, DisableResourceBuilding: true}.New()
+	closeWorlds(t, srv)
+	if data := srv.buildQueryData("127.0.0.1", 19132); data.Extra != nil {
+		t.Fatalf("expected no dimension player counts by default, got %v", data.Extra)
+	}
+
+	srv = Config{Log: log, DisableResourceBuilding: true, DisableEnd: true, QueryDimensionPlayerCounts: true}.New()
+	closeWorlds(t, srv)
+	data := srv.buildQueryData("127.0.0.1", 19132)
+	if want := map[string]string{"players_overworld": "0", "players_nether": "0"}; !maps.Equal(data.Extra, want) {
+		t.Fatalf("expected dimension player counts %v, got %v", want, data.Extra)
+	}
+
+	acceptConn(srv, newLoginConn(uuid.New()), srv.Nether())
+	want := map[string]string{"players_overworld": "0", "players_nether": "1"}
+	deadline := time.Now().Add(5 * time.Second)
+	for data = srv.buildQueryData("127.0.0.1", 19132); !maps.Equal(data.Extra, want); data = srv.buildQueryData("127.0.0.1", 19132) {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected dimension player counts %v, got %v", want, data.Extra)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
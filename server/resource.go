@@ -0,0 +1,51 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/sandertv/gophertunnel/minecraft/resource"
+)
+
+// resourcePackAdder is implemented by Listeners that can offer additional
+// resource packs to connections after they were created, such as the
+// *minecraft.Listener used by default.
+type resourcePackAdder interface {
+	AddResourcePack(pack *resource.Pack)
+}
+
+// AddResourcePack adds a resource pack to the packs offered to players joining
+// the Server. The pack is offered by all Listeners of the Server that support
+// adding packs after creation. Players already online are not affected and
+// only receive the pack when they join again. Resource packs cannot be removed
+// once added, as players may already be downloading them. An error is returned
+// if the pack is nil or a pack with the same UUID was already added.
+func (srv *Server) AddResourcePack(pack *resource.Pack) error {
+	if pack == nil {
+		return errors.New("add resource pack: pack must not be nil")
+	}
+	srv.rmu.Lock()
+	defer srv.rmu.Unlock()
+
+	if slices.ContainsFunc(srv.conf.Resources, func(p *resource.Pack) bool {
+		return p.UUID() == pack.UUID()
+	}) {
+		return fmt.Errorf("add resource pack: pack %v was already added", pack.UUID())
+	}
+	srv.conf.Resources = append(srv.conf.Resources, pack)
+	for _, l := range srv.listeners {
+		if adder, ok := l.(resourcePackAdder); ok {
+			adder.AddResourcePack(pack)
+		}
+	}
+	return nil
+}
+
+// ResourcePacks returns the resource packs offered to players joining the
+// Server.
+func (srv *Server) ResourcePacks() []*resource.Pack {
+	srv.rmu.Lock()
+	defer srv.rmu.Unlock()
+	return slices.Clone(srv.conf.Resources)
+}
@@ -71,6 +71,43 @@ type Server struct {
 	// wg is used to wait for all Listeners to be closed and their respective
 	// goroutines to be finished.
 	wg sync.WaitGroup
+
+	// hmu guards preShutdown and postShutdown, the hooks registered using
+	// OnPreShutdown and OnPostShutdown.
+	hmu                       sync.Mutex
+	preShutdown, postShutdown []shutdownHook
+
+	// rmu guards conf.Resources, which may be extended using AddResourcePack.
+	rmu sync.Mutex
+
+	// qmu guards queryPlayers, the providers registered using
+	// AddQueryPlayerProvider.
+	qmu          sync.Mutex
+	queryPlayers []QueryPlayerProvider
+
+	cooldowns   *commandCooldowns
+	audit       *auditLog
+	diagnostics *diagnosticsAggregator
+
+	// heldMu guards held, the data of players that disconnected less than
+	// Config.ReconnectGracePeriod ago, and saving, which holds a channel for
+	// every held session whose data is being saved after expiring. The
+	// channel is closed once the data is saved.
+	heldMu sync.Mutex
+	held   map[uuid.UUID]*heldSession
+	saving map[uuid.UUID]chan struct{}
+	// afterFunc schedules the expiry of held sessions. It is time.AfterFunc,
+	// unless replaced in tests.
+	afterFunc func(d time.Duration, f func()) *time.Timer
+
+	// smu guards stores, the Stores opened using Store.
+	smu    sync.Mutex
+	stores map[string]*Store
+
+	// lifetime is cancelled by stopLifetime when the Server starts closing,
+	// stopping all functions registered using Every.
+	lifetime     context.Context
+	stopLifetime context.CancelFunc
 }
 
 // incoming holds data of a player that is connecting to the server.
@@ -86,6 +123,8 @@ type onlinePlayer struct {
 	handle *world.EntityHandle
 	xuid   string
 	name   string
+	// closed is closed once the player has been removed from the server.
+	closed chan struct{}
 }
 
 // New creates a Server using a default Config. The Server's worlds are created
@@ -142,8 +181,16 @@ func (srv *Server) Accept() iter.Seq[*player.Player] {
 				return
 			}
 			srv.pmu.Lock()
+			if _, ok := srv.p[inc.p.handle.UUID()]; ok {
+				// Another login with the same UUID was accepted after this
+				// one passed admitLogin.
+				srv.pmu.Unlock()
+				srv.rejectIncoming(inc)
+				continue
+			}
 			srv.p[inc.p.handle.UUID()] = inc.p
 			srv.pmu.Unlock()
+			srv.recordEvent("join", inc.p.name, "")
 
 			ret := false
 			<-inc.w.Exec(func(tx *world.Tx) {
@@ -185,6 +232,19 @@ func (srv *Server) End() *world.World {
 	return srv.dimensions[world.End]
 }
 
+// Worlds returns all worlds of the server: the default world returned by the
+// World method first, followed by the worlds of the other dimensions that
+// are enabled.
+func (srv *Server) Worlds() []*world.World {
+	worlds := []*world.World{srv.world}
+	for _, dim := range []world.Dimension{world.Overworld, world.Nether, world.End} {
+		if w := srv.dimensions[dim]; w != nil && w != srv.world {
+			worlds = append(worlds, w)
+		}
+	}
+	return worlds
+}
+
 // MaxPlayerCount returns the maximum amount of players that are allowed to
 // play on the server at the same time. Players trying to join when the server
 // is full will be refused to enter. If the config has a maximum player count
@@ -229,6 +289,15 @@ func (srv *Server) WhitelistRemove(name string) (bool, error) {
 	return srv.whitelist.Remove(name)
 }
 
+// WhitelistReload re-reads the whitelist file, replacing the names currently
+// present in the whitelist.
+func (srv *Server) WhitelistReload() error {
+	if srv.whitelist == nil {
+		return ErrWhitelistUnavailable
+	}
+	return srv.whitelist.Reload()
+}
+
 // WhitelistEntries returns the list of names currently present in the whitelist.
 func (srv *Server) WhitelistEntries() ([]string, error) {
 	if srv.whitelist == nil {
@@ -344,18 +413,26 @@ func (srv *Server) Close() error {
 // close stops the server, storing player and world data to disk.
 func (srv *Server) close() {
 	srv.conf.Log.Info("Server closing...")
+	srv.stopLifetime()
+
+	srv.conf.Log.Debug("Running pre-shutdown hooks...")
+	srv.runPreShutdownHooks()
 
 	srv.conf.Log.Debug("Disconnecting players...")
 	for p := range srv.Players(nil) {
 		p.Disconnect(chat.MessageServerDisconnect.Resolve(p.Locale()))
 	}
 	srv.pwg.Wait()
+	srv.releaseHeldSessions()
 
 	srv.conf.Log.Debug("Closing player provider...")
 	if err := srv.conf.PlayerProvider.Close(); err != nil {
 		srv.conf.Log.Error("Close player provider: " + err.Error())
 	}
 
+	srv.conf.Log.Debug("Closing stores...")
+	srv.closeStores()
+
 	srv.conf.Log.Debug("Closing worlds...")
 	closed := make(map[*world.World]struct{})
 	for _, w := range srv.dimensions {
@@ -377,6 +454,9 @@ func (srv *Server) close() {
 			srv.conf.Log.Error("Close listener: " + err.Error())
 		}
 	}
+
+	srv.conf.Log.Debug("Running post-shutdown hooks...")
+	srv.runPostShutdownHooks()
 }
 
 // listen makes the Server listen for new connections from the Listener passed.
@@ -404,9 +484,7 @@ func (srv *Server) listen(l Listener) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if msg, ok := srv.conf.Allower.Allow(c.RemoteAddr(), c.IdentityData(), c.ClientData()); !ok {
-				_ = c.WritePacket(&packet.Disconnect{HideDisconnectionScreen: msg == "", Message: msg})
-				_ = c.Close()
+			if !srv.allow(c) {
 				return
 			}
 			srv.finaliseConn(ctx, c, l)
@@ -414,6 +492,18 @@ func (srv *Server) listen(l Listener) {
 	}
 }
 
+// allow checks if the Allower of the Server allows a connection to join. If
+// not, the connection is disconnected with the reason returned by the Allower
+// and closed.
+func (srv *Server) allow(c session.Conn) bool {
+	msg, ok := srv.conf.Allower.Allow(c.RemoteAddr(), c.IdentityData(), c.ClientData())
+	if !ok {
+		_ = c.WritePacket(&packet.Disconnect{HideDisconnectionScreen: msg == "", Message: msg})
+		_ = c.Close()
+	}
+	return ok
+}
+
 // startListening starts making the EncodeBlock listener listen, accepting new
 // connections from players.
 func (srv *Server) startListening() {
@@ -480,8 +570,58 @@ func (srv *Server) wait() {
 // channel.
 func (srv *Server) finaliseConn(ctx context.Context, conn session.Conn, l Listener) {
 	id := uuid.MustParse(conn.IdentityData().Identity)
+	if !srv.admitLogin(id) {
+		_ = l.Disconnect(conn, "Already logged in.")
+		srv.conf.Log.Debug("spawn failed: already logged in", "raddr", conn.RemoteAddr())
+		return
+	}
 	data := srv.defaultGameData()
 
+	var (
+		d player.Config
+		w *world.World
+	)
+	held, resumed := srv.resumeSession(id, conn.IdentityData().XUID)
+	if resumed {
+		d, w = held.conf, held.w
+	} else {
+		d, w = srv.loadPlayer(id)
+	}
+
+	data.PlayerPosition = vec64To32(d.Position).Add(mgl32.Vec3{0, 1.62})
+	dim, _ := world.DimensionID(w.Dimension())
+	data.Dimension = int32(dim)
+	data.Yaw, data.Pitch = float32(d.Rotation.Yaw()), float32(d.Rotation.Pitch())
+
+	data.EmoteChatMuted = srv.conf.MuteEmoteChat
+
+	if err := conn.StartGameContext(ctx, data); err != nil {
+		if resumed {
+			srv.restoreSession(id, held)
+		}
+		_ = l.Disconnect(conn, "Connection timeout.")
+
+		srv.conf.Log.Debug("spawn failed: "+err.Error(), "raddr", conn.RemoteAddr())
+		return
+	}
+	if _, ok := srv.Player(id); ok {
+		if resumed {
+			srv.restoreSession(id, held)
+		}
+		_ = l.Disconnect(conn, "Already logged in.")
+		srv.conf.Log.Debug("spawn failed: already logged in", "raddr", conn.RemoteAddr())
+		return
+	}
+	_ = conn.WritePacket(&packet.ItemRegistry{Items: srv.customItems})
+	srv.incoming <- srv.createPlayer(id, conn, d, w)
+	if resumed {
+		srv.recordEvent("resume", held.name, "")
+	}
+}
+
+// loadPlayer loads the data of the player with the UUID passed from the
+// PlayerProvider, together with the world to spawn the player in.
+func (srv *Server) loadPlayer(id uuid.UUID) (player.Config, *world.World) {
 	var (
 		fallback  bool
 		requested world.Dimension
@@ -508,27 +648,7 @@ func (srv *Server) finaliseConn(ctx context.Context, conn session.Conn, l Listen
 		d.Velocity = mgl64.Vec3{}
 		srv.conf.Log.Info("Relocating player from disabled dimension.", "requested", fmt.Sprint(requested), "target", fmt.Sprint(w.Dimension()))
 	}
-
-	data.PlayerPosition = vec64To32(d.Position).Add(mgl32.Vec3{0, 1.62})
-	dim, _ := world.DimensionID(w.Dimension())
-	data.Dimension = int32(dim)
-	data.Yaw, data.Pitch = float32(d.Rotation.Yaw()), float32(d.Rotation.Pitch())
-
-	data.EmoteChatMuted = srv.conf.MuteEmoteChat
-
-	if err := conn.StartGameContext(ctx, data); err != nil {
-		_ = l.Disconnect(conn, "Connection timeout.")
-
-		srv.conf.Log.Debug("spawn failed: "+err.Error(), "raddr", conn.RemoteAddr())
-		return
-	}
-	if _, ok := srv.Player(id); ok {
-		_ = l.Disconnect(conn, "Already logged in.")
-		srv.conf.Log.Debug("spawn failed: already logged in", "raddr", conn.RemoteAddr())
-		return
-	}
-	_ = conn.WritePacket(&packet.ItemRegistry{Items: srv.customItems})
-	srv.incoming <- srv.createPlayer(id, conn, d, w)
+	return d, w
 }
 
 // defaultGameData returns a minecraft.GameData as sent for a new player. It
@@ -593,8 +713,15 @@ func (srv *Server) checkNetIsolation() {
 // of the session from the server.
 func (srv *Server) handleSessionClose(tx *world.Tx, c session.Controllable) {
 	srv.pmu.Lock()
-	_, ok := srv.p[c.UUID()]
-	delete(srv.p, c.UUID())
+	p, ok := srv.p[c.UUID()]
+	if ok && p.handle != c.H() {
+		// A different player with the same UUID was registered since, which
+		// must not be removed.
+		ok = false
+	}
+	if ok {
+		delete(srv.p, c.UUID())
+	}
 	srv.pmu.Unlock()
 	if !ok {
 		// When a player disconnects immediately after a session is started, it
@@ -602,10 +729,17 @@ func (srv *Server) handleSessionClose(tx *world.Tx, c session.Controllable) {
 		// need to be careful not to crash when this happens.
 		return
 	}
+	srv.recordEvent("quit", p.name, "")
+	srv.diagnostics.remove(c.UUID())
 
-	if err := srv.conf.PlayerProvider.Save(c.UUID(), c.(*player.Player).Data(), tx.World()); err != nil {
+	if srv.conf.ReconnectGracePeriod > 0 {
+		srv.holdSession(c.UUID(), p, c.(*player.Player).Data(), tx.World())
+	} else if err := srv.conf.PlayerProvider.Save(c.UUID(), c.(*player.Player).Data(), tx.World()); err != nil {
 		srv.conf.Log.Error("Save player data: " + err.Error())
 	}
+	if p.closed != nil {
+		close(p.closed)
+	}
 	srv.pwg.Done()
 }
 
@@ -614,14 +748,7 @@ func (srv *Server) handleSessionClose(tx *world.Tx, c session.Controllable) {
 func (srv *Server) createPlayer(id uuid.UUID, conn session.Conn, conf player.Config, w *world.World) incoming {
 	srv.pwg.Add(1)
 
-	s := session.Config{
-		Log:            srv.conf.Log,
-		MaxChunkRadius: srv.conf.MaxChunkRadius,
-		EmoteChatMuted: srv.conf.MuteEmoteChat,
-		JoinMessage:    srv.conf.JoinMessage,
-		QuitMessage:    srv.conf.QuitMessage,
-		HandleStop:     srv.handleSessionClose,
-	}.New(conn)
+	s := srv.sessionConfig().New(conn)
 
 	conf.Name = conn.IdentityData().DisplayName
 	conf.XUID = conn.IdentityData().XUID
@@ -629,10 +756,45 @@ func (srv *Server) createPlayer(id uuid.UUID, conn session.Conn, conf player.Con
 	conf.Locale, _ = language.Parse(strings.Replace(conn.ClientData().LanguageCode, "_", "-", 1))
 	conf.Skin = srv.parseSkin(conn.ClientData())
 	conf.Session = s
+	conf.CommandLimiter = srv.cooldowns
+	conf.Auditor = auditor{srv: srv}
 
 	handle := world.EntitySpawnOpts{Position: conf.Position, ID: id}.New(player.Type, conf)
 	s.SetHandle(handle, conf.Skin)
-	return incoming{s: s, w: w, conf: conf, p: &onlinePlayer{name: conf.Name, xuid: conf.XUID, handle: handle}}
+	return incoming{s: s, w: w, conf: conf, p: &onlinePlayer{name: conf.Name, xuid: conf.XUID, handle: handle, closed: make(chan struct{})}}
+}
+
+// sessionConfig returns the session.Config used for the sessions of players
+// joining the server.
+func (srv *Server) sessionConfig() session.Config {
+	conf := session.Config{
+		Log:               srv.conf.Log,
+		MaxChunkRadius:    srv.conf.MaxChunkRadius,
+		EmoteChatMuted:    srv.conf.MuteEmoteChat,
+		JoinMessage:       srv.conf.JoinMessage,
+		QuitMessage:       srv.conf.QuitMessage,
+		HandleStop:        srv.handleSessionClose,
+		HandleDiagnostics: srv.handleDiagnostics,
+	}
+	if f := srv.conf.JoinMessageFunc; f != nil {
+		conf.JoinMessageFunc = playerMessageFunc(f)
+	}
+	if f := srv.conf.QuitMessageFunc; f != nil {
+		conf.QuitMessageFunc = playerMessageFunc(f)
+	}
+	return conf
+}
+
+// playerMessageFunc converts a join or quit message function accepting a
+// *player.Player to one accepting a session.Controllable.
+func playerMessageFunc(f func(p *player.Player) (chat.Translation, []any, bool)) func(c session.Controllable) (chat.Translation, []any, bool) {
+	return func(c session.Controllable) (chat.Translation, []any, bool) {
+		p, ok := c.(*player.Player)
+		if !ok {
+			return chat.Translation{}, nil, false
+		}
+		return f(p)
+	}
 }
 
 // createWorld loads a world with a specific dimension using the provider set
@@ -651,9 +813,11 @@ func (srv *Server) createWorld(dim world.Dimension) *world.World {
 		Generator:          gen,
 		GeneratorWorkers:   srv.conf.GeneratorWorkers,
 		GeneratorQueueSize: srv.conf.GeneratorQueueSize,
+		ResumeGeneration:   srv.conf.ResumeGeneration,
 		RandomTickSpeed:    srv.conf.RandomTickSpeed,
 		ReadOnly:           srv.conf.ReadOnlyWorld,
 		Entities:           srv.conf.Entities,
+		MaxChunkRadius:     srv.conf.DimensionMaxChunkRadius[dim],
 		PortalDestination: func(target world.Dimension) *world.World {
 			resolved := target
 			if target == world.Nether && sourceDim == world.Nether {
@@ -684,6 +848,10 @@ func (srv *Server) createWorld(dim world.Dimension) *world.World {
 			return srv.world
 		},
 	}
+	if dim == world.Overworld {
+		conf.SpawnProtectionRadius = srv.conf.SpawnProtectionRadius
+		conf.SpawnProtectionBypass = srv.spawnProtectionBypass
+	}
 	w := conf.New()
 	if binder, ok := gen.(interface{ BindWorld(*world.World) }); ok {
 		binder.BindWorld(w)
@@ -692,6 +860,13 @@ func (srv *Server) createWorld(dim world.Dimension) *world.World {
 	return w
 }
 
+// spawnProtectionBypass checks if an entity may bypass the spawn protection of
+// the overworld using Config.SpawnProtectionBypass. Only players may do so.
+func (srv *Server) spawnProtectionBypass(e world.Entity) bool {
+	p, ok := e.(*player.Player)
+	return ok && srv.conf.SpawnProtectionBypass != nil && srv.conf.SpawnProtectionBypass(p)
+}
+
 func (srv *Server) registerWorld(dim world.Dimension, w *world.World) {
 	if w == nil {
 		return
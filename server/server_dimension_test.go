@@ -11,6 +11,13 @@ import (
 func closeWorlds(tb testing.TB, srv *Server) {
 	tb.Helper()
 	tb.Cleanup(func() {
+		// Players still online are disconnected first: Their sessions remove
+		// them from their world when closing, which would otherwise load
+		// chunks in a world that was already closed.
+		for p := range srv.Players(nil) {
+			p.Disconnect("")
+		}
+		srv.pwg.Wait()
 		for _, w := range srv.dimensions {
 			if w != nil {
 				_ = w.Close()
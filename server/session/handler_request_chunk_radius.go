@@ -15,6 +15,9 @@ func (*RequestChunkRadiusHandler) Handle(p packet.Packet, s *Session, tx *world.
 	if pk.ChunkRadius > s.maxChunkRadius {
 		pk.ChunkRadius = s.maxChunkRadius
 	}
+	if m := int32(tx.World().MaxChunkRadius()); m > 0 && pk.ChunkRadius > m {
+		pk.ChunkRadius = m
+	}
 	s.chunkRadius = pk.ChunkRadius
 
 	s.chunkLoader.ChangeRadius(tx, int(pk.ChunkRadius))
@@ -9,9 +9,9 @@ import (
 type ServerBoundDiagnosticsHandler struct{}
 
 // Handle ...
-func (h *ServerBoundDiagnosticsHandler) Handle(p packet.Packet, _ *Session, _ *world.Tx, c Controllable) error {
+func (h *ServerBoundDiagnosticsHandler) Handle(p packet.Packet, s *Session, _ *world.Tx, c Controllable) error {
 	pk := p.(*packet.ServerBoundDiagnostics)
-	c.UpdateDiagnostics(Diagnostics{
+	d := Diagnostics{
 		AverageFramesPerSecond:        float64(pk.AverageFramesPerSecond),
 		AverageServerSimTickTime:      float64(pk.AverageServerSimTickTime),
 		AverageClientSimTickTime:      float64(pk.AverageClientSimTickTime),
@@ -21,6 +21,10 @@ func (h *ServerBoundDiagnosticsHandler) Handle(p packet.Packet, _ *Session, _ *w
 		AverageEndFrameTime:           float64(pk.AverageEndFrameTime),
 		AverageRemainderTimePercent:   float64(pk.AverageRemainderTimePercent),
 		AverageUnaccountedTimePercent: float64(pk.AverageUnaccountedTimePercent),
-	})
+	}
+	if s.conf.HandleDiagnostics != nil {
+		s.conf.HandleDiagnostics(c, d)
+	}
+	c.UpdateDiagnostics(d)
 	return nil
 }
@@ -39,23 +39,41 @@ func (s *Session) StopShowingEntity(e world.Entity) {
 	if !ok {
 		s.hiddenEntities[e.H().UUID()] = struct{}{}
 	}
+	_, worldHidden := s.worldHidden[e.H().UUID()]
 	s.entityMutex.Unlock()
 
-	if !ok {
+	if !ok && !worldHidden {
 		s.HideEntity(e)
 	}
 }
 
+// SetEntityHidden marks the entity of the world.EntityHandle passed as hidden
+// from, or visible to, the Session, without spawning or despawning it. It is
+// used by world.Tx.HideEntityFrom. An entity hidden using StopShowingEntity
+// stays hidden until StartShowingEntity is called, regardless of calls to
+// SetEntityHidden.
+func (s *Session) SetEntityHidden(h *world.EntityHandle, hidden bool) {
+	s.entityMutex.Lock()
+	defer s.entityMutex.Unlock()
+	if hidden {
+		s.worldHidden[h.UUID()] = struct{}{}
+		return
+	}
+	delete(s.worldHidden, h.UUID())
+}
+
 // StartShowingEntity starts showing a world.Entity to the Session that was previously hidden using StopShowingEntity.
+// The entity is not shown if it is also hidden from the Session using world.Tx.HideEntityFrom.
 func (s *Session) StartShowingEntity(e world.Entity) {
 	s.entityMutex.Lock()
 	_, ok := s.hiddenEntities[e.H().UUID()]
 	if ok {
 		delete(s.hiddenEntities, e.H().UUID())
 	}
+	_, worldHidden := s.worldHidden[e.H().UUID()]
 	s.entityMutex.Unlock()
 
-	if ok {
+	if ok && !worldHidden {
 		s.ViewEntity(e)
 		s.ViewEntityState(e)
 		s.ViewEntityItems(e)
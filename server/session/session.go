@@ -59,6 +59,10 @@ type Session struct {
 	entityRuntimeIDs map[*world.EntityHandle]uint64
 	entities         map[uint64]*world.EntityHandle
 	hiddenEntities   map[uuid.UUID]struct{}
+	// worldHidden holds the entities hidden from the Session using
+	// world.Tx.HideEntityFrom. It is kept apart from hiddenEntities, so that
+	// showing an entity through one does not show it if hidden by the other.
+	worldHidden map[uuid.UUID]struct{}
 
 	// heldSlot is the slot in the inventory that the controllable is holding.
 	heldSlot                     *uint32
@@ -69,6 +73,10 @@ type Session struct {
 	// spawn for the player list, but otherwise updated immediately when the
 	// player is viewed.
 	joinSkin skin.Skin
+	// listName is the name shown for the player in the player list of other
+	// players. If empty, the display name of the player is shown. listName is
+	// guarded by the mutex of the sessionList.
+	listName string
 
 	breakingPos cube.Pos
 
@@ -149,8 +157,16 @@ type Config struct {
 	EmoteChatMuted bool
 
 	JoinMessage, QuitMessage chat.Translation
+	// JoinMessageFunc and QuitMessageFunc, if set, are called to obtain the
+	// message broadcast when the Controllable joins or quits, taking
+	// precedence over JoinMessage and QuitMessage. Returning false suppresses
+	// the message.
+	JoinMessageFunc, QuitMessageFunc func(c Controllable) (chat.Translation, []any, bool)
 
 	HandleStop func(*world.Tx, Controllable)
+	// HandleDiagnostics, if set, is called with the diagnostics sent by the
+	// client, before they are passed to the Controllable.
+	HandleDiagnostics func(c Controllable, d Diagnostics)
 }
 
 func (conf Config) New(conn Conn) *Session {
@@ -173,6 +189,7 @@ func (conf Config) New(conn Conn) *Session {
 		entityRuntimeIDs:       map[*world.EntityHandle]uint64{},
 		entities:               map[uint64]*world.EntityHandle{},
 		hiddenEntities:         map[uuid.UUID]struct{}{},
+		worldHidden:            map[uuid.UUID]struct{}{},
 		blobs:                  map[uint64][]byte{},
 		chunkRadius:            int32(r),
 		maxChunkRadius:         int32(conf.MaxChunkRadius),
@@ -256,9 +273,7 @@ func (s *Session) Spawn(c Controllable, tx *world.Tx) {
 	s.sendInv(s.armour.Inventory(), protocol.WindowIDArmour)
 
 	chat.Global.Subscribe(c)
-	if !s.conf.JoinMessage.Zero() {
-		chat.Global.Writet(s.conf.JoinMessage, s.conn.IdentityData().DisplayName)
-	}
+	s.broadcastMessage(c, s.conf.JoinMessageFunc, s.conf.JoinMessage)
 
 	runnable := s.sendAvailableCommands(c)
 	enums, enumValues := s.enums(c)
@@ -267,6 +282,22 @@ func (s *Session) Spawn(c Controllable, tx *world.Tx) {
 	go s.handlePackets()
 }
 
+// broadcastMessage broadcasts a join or quit message for the Controllable
+// passed. If f is not nil, the message returned by it is broadcast. Otherwise
+// the default message def is broadcast, with the name of the player as its
+// only argument, unless it is zero.
+func (s *Session) broadcastMessage(c Controllable, f func(c Controllable) (chat.Translation, []any, bool), def chat.Translation) {
+	if f != nil {
+		if t, args, ok := f(c); ok && !t.Zero() {
+			chat.Global.Writet(t, args...)
+		}
+		return
+	}
+	if !def.Zero() {
+		chat.Global.Writet(def, s.conn.IdentityData().DisplayName)
+	}
+}
+
 // Close closes the session, which in turn closes the controllable and the connection that the session
 // manages. Close ensures the method only runs code on the first call.
 func (s *Session) Close(tx *world.Tx, c Controllable) {
@@ -290,9 +321,7 @@ func (s *Session) close(tx *world.Tx, c Controllable) {
 
 	s.chunkLoader.Close(tx)
 
-	if !s.conf.QuitMessage.Zero() {
-		chat.Global.Writet(s.conf.QuitMessage, s.conn.IdentityData().DisplayName)
-	}
+	s.broadcastMessage(c, s.conf.QuitMessageFunc, s.conf.QuitMessage)
 	chat.Global.Unsubscribe(c)
 
 	// Note: Be aware of where RemoveEntity is called. This must not be done too
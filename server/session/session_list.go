@@ -1,6 +1,7 @@
 package session
 
 import (
+	"maps"
 	"slices"
 	"sync"
 
@@ -16,6 +17,9 @@ var sessions = new(sessionList)
 type sessionList struct {
 	mu sync.Mutex
 	s  []*Session
+	// entries holds the entries added to the player list using
+	// AddPlayerListEntry, which do not belong to a session.
+	entries map[uuid.UUID]protocol.PlayerListEntry
 }
 
 func (l *sessionList) Add(s *Session) {
@@ -31,6 +35,9 @@ func (l *sessionList) Add(s *Session) {
 	// Show the new session to itself.
 	l.sendSessionTo(s, s)
 	l.s = append(l.s, s)
+	if len(l.entries) > 0 {
+		s.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionAdd, Entries: slices.Collect(maps.Values(l.entries))})
+	}
 }
 
 func (l *sessionList) Remove(s *Session) {
@@ -55,6 +62,79 @@ func (l *sessionList) Lookup(id uuid.UUID) (*Session, bool) {
 	return nil, false
 }
 
+// setListName changes the name of the session passed in the player list and
+// resends its entry to all sessions.
+func (l *sessionList) setListName(s *Session, name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s.listName = name
+	if !slices.Contains(l.s, s) {
+		return
+	}
+	for _, other := range l.s {
+		other.entityMutex.RLock()
+		runtimeID := other.entityRuntimeIDs[s.ent]
+		other.entityMutex.RUnlock()
+
+		other.writePacket(&packet.PlayerList{
+			ActionType: packet.PlayerListActionRemove,
+			Entries:    []protocol.PlayerListEntry{{UUID: s.ent.UUID()}},
+		})
+		other.writePacket(&packet.PlayerList{
+			ActionType: packet.PlayerListActionAdd,
+			Entries:    []protocol.PlayerListEntry{l.entryOf(s, runtimeID)},
+		})
+	}
+}
+
+// addEntry adds an entry that does not belong to a session to the player list
+// of all sessions.
+func (l *sessionList) addEntry(entry protocol.PlayerListEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.entries == nil {
+		l.entries = make(map[uuid.UUID]protocol.PlayerListEntry)
+	}
+	l.entries[entry.UUID] = entry
+	for _, other := range l.s {
+		other.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionAdd, Entries: []protocol.PlayerListEntry{entry}})
+	}
+}
+
+// removeEntry removes an entry added using addEntry from the player list of
+// all sessions. False is returned if no such entry existed.
+func (l *sessionList) removeEntry(id uuid.UUID) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.entries[id]; !ok {
+		return false
+	}
+	delete(l.entries, id)
+	for _, other := range l.s {
+		other.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionRemove, Entries: []protocol.PlayerListEntry{{UUID: id}}})
+	}
+	return true
+}
+
+// entryOf returns the player list entry of the session passed, using the
+// runtime ID that the session is known by.
+func (l *sessionList) entryOf(s *Session, runtimeID uint64) protocol.PlayerListEntry {
+	name := s.listName
+	if name == "" {
+		name = s.conn.IdentityData().DisplayName
+	}
+	return protocol.PlayerListEntry{
+		UUID:           s.ent.UUID(),
+		EntityUniqueID: int64(runtimeID),
+		Username:       name,
+		XUID:           s.conn.IdentityData().XUID,
+		Skin:           skinToProtocol(s.joinSkin),
+	}
+}
+
 func (l *sessionList) sendSessionTo(s, to *Session) {
 	runtimeID := uint64(selfEntityRuntimeID)
 
@@ -69,13 +149,7 @@ func (l *sessionList) sendSessionTo(s, to *Session) {
 
 	to.writePacket(&packet.PlayerList{
 		ActionType: packet.PlayerListActionAdd,
-		Entries: []protocol.PlayerListEntry{{
-			UUID:           s.ent.UUID(),
-			EntityUniqueID: int64(runtimeID),
-			Username:       s.conn.IdentityData().DisplayName,
-			XUID:           s.conn.IdentityData().XUID,
-			Skin:           skinToProtocol(s.joinSkin),
-		}},
+		Entries:    []protocol.PlayerListEntry{l.entryOf(s, runtimeID)},
 	})
 }
 
@@ -137,3 +211,38 @@ func skinToProtocol(s skin.Skin) protocol.Skin {
 		GeometryDataEngineVersion: []byte(protocol.CurrentVersion),
 	}
 }
+
+// AddPlayerListEntry adds an entry with the UUID, name and skin passed to the
+// player list of all players, including players that join later. The entry
+// does not belong to a player and may be removed again using
+// RemovePlayerListEntry. Adding an entry with the UUID of an existing entry
+// replaces it.
+func AddPlayerListEntry(id uuid.UUID, name string, sk skin.Skin) {
+	sessions.addEntry(protocol.PlayerListEntry{UUID: id, Username: name, Skin: skinToProtocol(sk)})
+}
+
+// RemovePlayerListEntry removes an entry added using AddPlayerListEntry from
+// the player list of all players. False is returned if no entry with the UUID
+// passed was added.
+func RemovePlayerListEntry(id uuid.UUID) bool {
+	return sessions.removeEntry(id)
+}
+
+// SetPlayerListName changes the name shown for the player of the Session in
+// the player list of all players. Passing an empty name resets it to the
+// display name of the player.
+func (s *Session) SetPlayerListName(name string) {
+	if s == Nop {
+		return
+	}
+	sessions.setListName(s, name)
+}
+
+// PlayerListName returns the name shown for the player of the Session in the
+// player list, as set using SetPlayerListName. An empty string is returned if
+// the display name of the player is shown.
+func (s *Session) PlayerListName() string {
+	sessions.mu.Lock()
+	defer sessions.mu.Unlock()
+	return s.listName
+}
@@ -3,6 +3,7 @@ package session
 import (
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/scoreboard"
+	"github.com/go-gl/mathgl/mgl64"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 	"golang.org/x/text/language"
@@ -137,8 +138,10 @@ func (s *Session) RemoveScoreboard() {
 }
 
 // SendBossBar sends a boss bar to the player with the text passed and the health percentage of the bar.
-// SendBossBar removes any boss bar that might be active before sending the new one.
+// SendBossBar removes any boss bar that might be active before sending the new one. The health percentage is
+// clamped between 0 and 1.
 func (s *Session) SendBossBar(text string, colour uint8, healthPercentage float64) {
+	healthPercentage = mgl64.Clamp(healthPercentage, 0, 1)
 	s.RemoveBossBar()
 	s.writePacket(&packet.BossEvent{
 		BossEntityUniqueID: selfEntityRuntimeID,
@@ -39,12 +39,14 @@ type OffsetEntity interface {
 	NetworkOffset() float64
 }
 
-// entityHidden checks if a world.Entity is being explicitly hidden from the Session.
+// entityHidden checks if a world.Entity is being explicitly hidden from the Session, either using
+// StopShowingEntity or world.Tx.HideEntityFrom.
 func (s *Session) entityHidden(e world.Entity) bool {
 	s.entityMutex.RLock()
 	_, ok := s.hiddenEntities[e.H().UUID()]
+	_, worldHidden := s.worldHidden[e.H().UUID()]
 	s.entityMutex.RUnlock()
-	return ok
+	return ok || worldHidden
 }
 
 // ViewEntity ...
@@ -56,37 +58,104 @@ func (s *Session) ViewEntity(e world.Entity) {
 	if s.entityHidden(e) {
 		return
 	}
-	var runtimeID uint64
+	runtimeID := s.assignEntityRuntimeID(e)
+	entry, listed := s.playerListEntry(e, runtimeID)
+	if listed {
+		s.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionAdd, Entries: []protocol.PlayerListEntry{entry}})
+	}
+	s.spawnEntity(e, runtimeID)
+	if listed {
+		s.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionRemove, Entries: []protocol.PlayerListEntry{{UUID: entry.UUID}}})
+	}
+}
 
+// ViewEntities ...
+func (s *Session) ViewEntities(entities []world.Entity) {
+	// Runtime IDs of entities that are not spawned are left 0, which is never
+	// assigned to an entity.
+	runtimeIDs := make([]uint64, len(entities))
+	var added, removed []protocol.PlayerListEntry
+	for i, e := range entities {
+		if e.H() == s.ent || s.entityHidden(e) {
+			continue
+		}
+		runtimeIDs[i] = s.assignEntityRuntimeID(e)
+		if entry, ok := s.playerListEntry(e, runtimeIDs[i]); ok {
+			added = append(added, entry)
+			removed = append(removed, protocol.PlayerListEntry{UUID: entry.UUID})
+		}
+	}
+	// Players that are not backed by a session need to be in the player list
+	// to be spawned. They are all added and removed with a single packet.
+	if len(added) != 0 {
+		s.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionAdd, Entries: added})
+	}
+	for i, e := range entities {
+		if e.H() == s.ent {
+			s.ViewEntityState(e)
+		} else if runtimeIDs[i] != 0 {
+			s.spawnEntity(e, runtimeIDs[i])
+		}
+	}
+	if len(removed) != 0 {
+		s.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionRemove, Entries: removed})
+	}
+	for i, e := range entities {
+		if runtimeIDs[i] != 0 {
+			s.ViewEntityItems(e)
+			s.ViewEntityArmour(e)
+		}
+	}
+}
+
+// assignEntityRuntimeID assigns a new runtime ID to the world.Entity passed
+// and returns it. Controllable entities keep the runtime ID they were
+// assigned before, if any.
+func (s *Session) assignEntityRuntimeID(e world.Entity) uint64 {
 	_, controllable := e.(Controllable)
 
 	s.entityMutex.Lock()
+	defer s.entityMutex.Unlock()
 	if id, ok := s.entityRuntimeIDs[e.H()]; ok && controllable {
-		runtimeID = id
-	} else {
-		s.currentEntityRuntimeID += 1
-		runtimeID = s.currentEntityRuntimeID
-		s.entityRuntimeIDs[e.H()] = runtimeID
-		s.entities[runtimeID] = e.H()
+		return id
 	}
-	s.entityMutex.Unlock()
+	s.currentEntityRuntimeID += 1
+	runtimeID := s.currentEntityRuntimeID
+	s.entityRuntimeIDs[e.H()] = runtimeID
+	s.entities[runtimeID] = e.H()
+	return runtimeID
+}
+
+// playerListEntry returns the player list entry that must be present for the
+// world.Entity passed to be spawned. Only Controllable entities that are not
+// backed by a Session, such as NPCs, need one, so false is returned for any
+// other entity.
+func (s *Session) playerListEntry(e world.Entity, runtimeID uint64) (protocol.PlayerListEntry, bool) {
+	v, ok := e.(Controllable)
+	if !ok {
+		return protocol.PlayerListEntry{}, false
+	}
+	if _, actualPlayer := sessions.Lookup(v.UUID()); actualPlayer {
+		return protocol.PlayerListEntry{}, false
+	}
+	return protocol.PlayerListEntry{
+		UUID:           v.UUID(),
+		EntityUniqueID: int64(runtimeID),
+		Username:       v.Name(),
+		Skin:           skinToProtocol(v.Skin()),
+	}, true
+}
 
+// spawnEntity sends the packet spawning the world.Entity passed with the
+// runtime ID passed. Controllable entities not backed by a Session must be
+// in the player list when spawnEntity is called.
+func (s *Session) spawnEntity(e world.Entity, runtimeID uint64) {
 	yaw, pitch := e.Rotation().Elem()
 	metadata := s.parseEntityMetadata(e)
 
 	id := e.H().Type().EncodeEntity()
 	switch v := e.(type) {
 	case Controllable:
-		_, actualPlayer := sessions.Lookup(v.UUID())
-		if !actualPlayer {
-			s.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionAdd, Entries: []protocol.PlayerListEntry{{
-				UUID:           v.UUID(),
-				EntityUniqueID: int64(runtimeID),
-				Username:       v.Name(),
-				Skin:           skinToProtocol(v.Skin()),
-			}}})
-		}
-
 		s.writePacket(&packet.AddPlayer{
 			EntityMetadata:  metadata,
 			EntityRuntimeID: runtimeID,
@@ -105,11 +174,7 @@ func (s *Session) ViewEntity(e world.Entity) {
 				}},
 			},
 		})
-		if !actualPlayer {
-			s.writePacket(&packet.PlayerList{ActionType: packet.PlayerListActionRemove, Entries: []protocol.PlayerListEntry{{
-				UUID: v.UUID(),
-			}}})
-		} else {
+		if _, actualPlayer := sessions.Lookup(v.UUID()); actualPlayer {
 			s.ViewSkin(e)
 		}
 		return
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+)
+
+// heldSession holds the data of a player that disconnected less than
+// Config.ReconnectGracePeriod ago.
+type heldSession struct {
+	name, xuid string
+	conf       player.Config
+	w          *world.World
+	timer      *time.Timer
+}
+
+// holdSession holds the data of a player that disconnected until it reconnects
+// or Config.ReconnectGracePeriod expires, after which the data is saved to the
+// PlayerProvider.
+func (srv *Server) holdSession(id uuid.UUID, p *onlinePlayer, conf player.Config, w *world.World) {
+	h := &heldSession{name: p.name, xuid: p.xuid, conf: conf, w: w}
+
+	srv.heldMu.Lock()
+	defer srv.heldMu.Unlock()
+	if prev, ok := srv.held[id]; ok {
+		prev.timer.Stop()
+	}
+	srv.held[id] = h
+	h.timer = srv.afterFunc(srv.conf.ReconnectGracePeriod, func() {
+		srv.expireSession(id, h)
+	})
+}
+
+// resumeSession takes the data held for the player with the UUID passed, if it
+// disconnected less than Config.ReconnectGracePeriod ago. The XUID passed must
+// match that of the player that disconnected. The data is no longer held
+// afterwards: if the player fails to spawn, restoreSession must be called to
+// prevent the data from being lost. If the data of the player is being saved
+// because the grace period expired, resumeSession waits for the save to
+// finish, so that the data may be loaded from the PlayerProvider instead.
+func (srv *Server) resumeSession(id uuid.UUID, xuid string) (*heldSession, bool) {
+	srv.heldMu.Lock()
+	h, ok := srv.held[id]
+	if !ok || h.xuid != xuid {
+		saving := srv.saving[id]
+		srv.heldMu.Unlock()
+		if saving != nil {
+			<-saving
+		}
+		return nil, false
+	}
+	// Whoever removes the session from held owns its data. If the timer fired
+	// already, expireSession finds the session gone and returns.
+	delete(srv.held, id)
+	srv.heldMu.Unlock()
+	h.timer.Stop()
+	return h, true
+}
+
+// restoreSession holds the data of a session taken using resumeSession again,
+// for a new Config.ReconnectGracePeriod, after the player failed to spawn.
+// If a newer session was held for the player in the meantime, the data of h
+// is outdated and dropped.
+func (srv *Server) restoreSession(id uuid.UUID, h *heldSession) {
+	srv.heldMu.Lock()
+	defer srv.heldMu.Unlock()
+	if _, ok := srv.held[id]; ok {
+		return
+	}
+	srv.held[id] = h
+	h.timer = srv.afterFunc(srv.conf.ReconnectGracePeriod, func() {
+		srv.expireSession(id, h)
+	})
+}
+
+// expireSession saves the data of a held session to the PlayerProvider once
+// Config.ReconnectGracePeriod expired.
+func (srv *Server) expireSession(id uuid.UUID, h *heldSession) {
+	srv.heldMu.Lock()
+	if srv.held[id] != h {
+		srv.heldMu.Unlock()
+		return
+	}
+	delete(srv.held, id)
+	done := make(chan struct{})
+	srv.saving[id] = done
+	srv.heldMu.Unlock()
+
+	srv.saveHeldSession(id, h)
+
+	srv.heldMu.Lock()
+	if srv.saving[id] == done {
+		delete(srv.saving, id)
+	}
+	srv.heldMu.Unlock()
+	close(done)
+}
+
+// releaseHeldSessions saves the data of all held sessions to the
+// PlayerProvider and waits for sessions expiring concurrently to finish
+// saving. It is called when the Server is closed, before the PlayerProvider
+// is closed.
+func (srv *Server) releaseHeldSessions() {
+	srv.heldMu.Lock()
+	held := maps.Clone(srv.held)
+	clear(srv.held)
+	saving := slices.Collect(maps.Values(srv.saving))
+	srv.heldMu.Unlock()
+
+	for id, h := range held {
+		h.timer.Stop()
+		srv.saveHeldSession(id, h)
+	}
+	for _, done := range saving {
+		<-done
+	}
+}
+
+// saveHeldSession saves the data of a held session to the PlayerProvider.
+func (srv *Server) saveHeldSession(id uuid.UUID, h *heldSession) {
+	if err := srv.conf.PlayerProvider.Save(id, h.conf, h.w); err != nil {
+		srv.conf.Log.Error("Save player data: " + err.Error())
+	}
+}
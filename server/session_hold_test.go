@@ -0,0 +1,199 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+)
+
+// saveRecorder is a player.Provider that records the players saved.
+type saveRecorder struct {
+	player.NopProvider
+	mu    sync.Mutex
+	saved map[uuid.UUID]int
+}
+
+func (r *saveRecorder) Save(id uuid.UUID, _ player.Config, _ *world.World) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.saved[id]++
+	return nil
+}
+
+func (r *saveRecorder) count(id uuid.UUID) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.saved[id]
+}
+
+// disconnect closes the connection of an online player and waits for the
+// player to be removed from the server.
+func disconnect(t *testing.T, srv *Server, conn *loginConn) {
+	t.Helper()
+	_ = conn.Close()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, ok := srv.Player(conn.id); !ok {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected player to be removed after disconnecting")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// manualExpiry replaces Server.afterFunc, so that held sessions only expire
+// once expire is called.
+type manualExpiry struct {
+	mu      sync.Mutex
+	pending []scheduledExpiry
+}
+
+// scheduledExpiry is a function scheduled using manualExpiry.afterFunc, with
+// the timer returned for it.
+type scheduledExpiry struct {
+	t *time.Timer
+	f func()
+}
+
+func (m *manualExpiry) afterFunc(_ time.Duration, f func()) *time.Timer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// The timer never fires by itself, but it may still be stopped.
+	t := time.AfterFunc(math.MaxInt64, func() {})
+	m.pending = append(m.pending, scheduledExpiry{t: t, f: f})
+	return t
+}
+
+// expire runs all functions scheduled of which the timer was not stopped, as
+// if their duration passed.
+func (m *manualExpiry) expire() {
+	m.mu.Lock()
+	pending := m.pending
+	m.pending = nil
+	m.mu.Unlock()
+
+	for _, e := range pending {
+		if e.t.Stop() {
+			e.f()
+		}
+	}
+}
+
+func TestReconnectGracePeriod(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	prov := &saveRecorder{saved: make(map[uuid.UUID]int)}
+	srv := Config{Log: log, DisableResourceBuilding: true, PlayerProvider: prov, ReconnectGracePeriod: time.Minute}.New()
+	expiry := &manualExpiry{}
+	srv.afterFunc = expiry.afterFunc
+	closeWorlds(t, srv)
+
+	// A player reconnecting within the grace period resumes with its held
+	// data.
+	conn := newLoginConn(uuid.New())
+	acceptConn(srv, conn, srv.World())
+	if !srv.SetPlayerGameMode(nil, conn.id, world.GameModeCreative) {
+		t.Fatalf("expected game mode to be set")
+	}
+	disconnect(t, srv, conn)
+	if n := prov.count(conn.id); n != 0 {
+		t.Fatalf("expected held player data not to be saved yet, got %v saves", n)
+	}
+	if _, ok := srv.resumeSession(conn.id, "other"); ok {
+		t.Fatalf("expected session not to be resumed with a different XUID")
+	}
+	h, ok := srv.resumeSession(conn.id, "")
+	if !ok {
+		t.Fatalf("expected session to be resumed within the grace period")
+	}
+	if h.conf.GameMode != world.GameModeCreative || h.w != srv.World() {
+		t.Fatalf("expected held data to be resumed, got game mode %v", h.conf.GameMode)
+	}
+	if _, ok := srv.resumeSession(conn.id, ""); ok {
+		t.Fatalf("expected session to be resumed only once")
+	}
+	// A player failing to spawn after resuming has its data held again, and
+	// saved once the grace period expires.
+	srv.restoreSession(conn.id, h)
+	if h, ok = srv.resumeSession(conn.id, ""); !ok || h.conf.GameMode != world.GameModeCreative {
+		t.Fatalf("expected restored session to be resumed")
+	}
+	srv.restoreSession(conn.id, h)
+	expiry.expire()
+	if n := prov.count(conn.id); n != 1 {
+		t.Fatalf("expected restored player data to be saved once after the grace period, got %v saves", n)
+	}
+
+	// A player reconnecting after the grace period has its data saved and
+	// loaded from the provider instead.
+	conn = newLoginConn(uuid.New())
+	acceptConn(srv, conn, srv.World())
+	disconnect(t, srv, conn)
+	expiry.expire()
+	if _, ok := srv.resumeSession(conn.id, ""); ok {
+		t.Fatalf("expected session not to be resumed after the grace period")
+	}
+	if n := prov.count(conn.id); n != 1 {
+		t.Fatalf("expected player data to be saved once after the grace period, got %v saves", n)
+	}
+}
+
+// blockingSaver is a player.Provider whose Save calls block until release is
+// closed.
+type blockingSaver struct {
+	player.NopProvider
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingSaver) Save(uuid.UUID, player.Config, *world.World) error {
+	close(b.started)
+	<-b.release
+	return nil
+}
+
+func TestHeldSessionExpiringConcurrently(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	prov := &blockingSaver{started: make(chan struct{}), release: make(chan struct{})}
+	srv := Config{Log: log, DisableResourceBuilding: true, PlayerProvider: prov, ReconnectGracePeriod: time.Minute}.New()
+	expiry := &manualExpiry{}
+	srv.afterFunc = expiry.afterFunc
+	closeWorlds(t, srv)
+
+	id := uuid.New()
+	srv.holdSession(id, &onlinePlayer{}, player.Config{}, srv.World())
+	go expiry.expire()
+	<-prov.started
+
+	// While the expired session is being saved, neither resuming it nor
+	// releasing the held sessions may return before the save finished.
+	resumed, released := make(chan bool), make(chan struct{})
+	go func() {
+		_, ok := srv.resumeSession(id, "")
+		resumed <- ok
+	}()
+	go func() {
+		srv.releaseHeldSessions()
+		close(released)
+	}()
+	select {
+	case <-resumed:
+		t.Fatalf("expected resumeSession to wait for the expiring session to be saved")
+	case <-released:
+		t.Fatalf("expected releaseHeldSessions to wait for the expiring session to be saved")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(prov.release)
+	if <-resumed {
+		t.Fatalf("expected expired session not to be resumed")
+	}
+	<-released
+}
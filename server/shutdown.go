@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// shutdownHook is a named function registered using Server.OnPreShutdown or
+// Server.OnPostShutdown.
+type shutdownHook struct {
+	name string
+	f    func(ctx context.Context) error
+}
+
+// OnPreShutdown registers a function that is run when the Server is closed,
+// before players are disconnected and worlds are saved and closed. Hooks
+// registered using OnPreShutdown run in the order they were registered in.
+// The context passed to f expires after Config.ShutdownHookTimeout. If f does
+// not return in time, the timeout is logged and shutdown continues without
+// waiting for f any longer. Errors returned by f are logged.
+func (srv *Server) OnPreShutdown(name string, f func(ctx context.Context) error) {
+	srv.hmu.Lock()
+	defer srv.hmu.Unlock()
+	srv.preShutdown = append(srv.preShutdown, shutdownHook{name: name, f: f})
+}
+
+// OnPostShutdown registers a function that is run when the Server is closed,
+// after all worlds have been saved and closed. Hooks registered using
+// OnPostShutdown run in the reverse order of registration, so that resources
+// set up first are released last. Timeouts and errors are handled the same as
+// for OnPreShutdown.
+func (srv *Server) OnPostShutdown(name string, f func(ctx context.Context) error) {
+	srv.hmu.Lock()
+	defer srv.hmu.Unlock()
+	srv.postShutdown = append(srv.postShutdown, shutdownHook{name: name, f: f})
+}
+
+// runPreShutdownHooks runs all hooks registered using OnPreShutdown.
+func (srv *Server) runPreShutdownHooks() {
+	srv.hmu.Lock()
+	hooks := slices.Clone(srv.preShutdown)
+	srv.hmu.Unlock()
+
+	for _, h := range hooks {
+		srv.runShutdownHook(h)
+	}
+}
+
+// runPostShutdownHooks runs all hooks registered using OnPostShutdown in
+// reverse order of registration.
+func (srv *Server) runPostShutdownHooks() {
+	srv.hmu.Lock()
+	hooks := slices.Clone(srv.postShutdown)
+	srv.hmu.Unlock()
+
+	slices.Reverse(hooks)
+	for _, h := range hooks {
+		srv.runShutdownHook(h)
+	}
+}
+
+// runShutdownHook runs a single shutdown hook, waiting at most
+// Config.ShutdownHookTimeout for it to return.
+func (srv *Server) runShutdownHook(h shutdownHook) {
+	ctx, cancel := context.WithTimeout(context.Background(), srv.conf.ShutdownHookTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- h.f(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			srv.conf.Log.Error("Shutdown hook: "+err.Error(), "hook", h.name)
+		}
+	case <-ctx.Done():
+		srv.conf.Log.Warn("Shutdown hook timed out, continuing shutdown.", "hook", h.name, "timeout", srv.conf.ShutdownHookTimeout)
+	}
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sync"
+
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/df-mc/goleveldb/leveldb/opt"
+)
+
+// errStoreClosed is returned when writing to a Store that was closed.
+var errStoreClosed = errors.New("store closed")
+
+// namespacePattern matches valid namespaces of a Store.
+var namespacePattern = regexp.MustCompile(`^[a-z0-9_\-]+$`)
+
+// Store is a persistent key-value store, backed by a LevelDB database. A Store
+// is obtained using Server.Store and is safe for concurrent use. The database
+// is only opened once the Store is first used.
+type Store struct {
+	path string
+
+	mu     sync.Mutex
+	db     *leveldb.DB
+	closed bool
+}
+
+// open opens the database of the Store if it is not yet open.
+func (s *Store) open() (*leveldb.DB, error) {
+	if s.closed {
+		return nil, errStoreClosed
+	}
+	if s.db == nil {
+		if err := os.MkdirAll(s.path, 0777); err != nil {
+			return nil, fmt.Errorf("open store: %w", err)
+		}
+		db, err := leveldb.OpenFile(s.path, &opt.Options{Compression: opt.SnappyCompression})
+		if err != nil {
+			return nil, fmt.Errorf("open store: %w", err)
+		}
+		s.db = db
+	}
+	return s.db, nil
+}
+
+// Get returns the value stored under key. False is returned if no value was
+// stored under the key or if the Store could not be read.
+func (s *Store) Get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	db, err := s.open()
+	if err != nil {
+		return nil, false
+	}
+	v, err := db.Get([]byte(key), nil)
+	if err != nil {
+		return nil, false
+	}
+	return v, true
+}
+
+// Set stores value under key, replacing any value previously stored under it.
+func (s *Store) Set(key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	db, err := s.open()
+	if err != nil {
+		return err
+	}
+	return db.Put([]byte(key), value, nil)
+}
+
+// Delete removes the value stored under key. Deleting a key that has no value
+// is not an error.
+func (s *Store) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	db, err := s.open()
+	if err != nil {
+		return err
+	}
+	return db.Delete([]byte(key), nil)
+}
+
+// All returns an iterator over all keys and their values in the Store, in
+// lexicographical order of the keys. All iterates over the contents of the
+// Store at the time iteration starts, so the Store may be modified while
+// iterating.
+func (s *Store) All() iter.Seq2[string, []byte] {
+	return func(yield func(string, []byte) bool) {
+		s.mu.Lock()
+		db, err := s.open()
+		var snap *leveldb.Snapshot
+		if err == nil {
+			snap, err = db.GetSnapshot()
+		}
+		s.mu.Unlock()
+		if err != nil {
+			return
+		}
+		defer snap.Release()
+
+		it := snap.NewIterator(nil, nil)
+		defer it.Release()
+		for it.Next() {
+			if !yield(string(it.Key()), slices.Clone(it.Value())) {
+				return
+			}
+		}
+	}
+}
+
+// close closes the database of the Store if it was opened. Any further use of
+// the Store fails.
+func (s *Store) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	if s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+// Store returns the persistent key-value Store with the namespace passed, such
+// as the name of a plugin. The data of the Store is kept in a folder named
+// after the namespace in Config.StoreFolder. The Store remains open until it
+// is closed using CloseStore or the Server is closed. Store returns false if
+// the namespace is empty or contains characters other than lowercase letters,
+// digits, '_' and '-'.
+func (srv *Server) Store(namespace string) (*Store, bool) {
+	if !namespacePattern.MatchString(namespace) {
+		return nil, false
+	}
+	srv.smu.Lock()
+	defer srv.smu.Unlock()
+	if s, ok := srv.stores[namespace]; ok {
+		return s, true
+	}
+	s := &Store{path: filepath.Join(srv.conf.StoreFolder, namespace)}
+	srv.stores[namespace] = s
+	return s, true
+}
+
+// CloseStore closes the Store with the namespace passed, for example when the
+// plugin using it is disabled. The Store returned by an earlier call to Store
+// can no longer be used afterwards, but Store may be called again to reopen
+// it.
+func (srv *Server) CloseStore(namespace string) error {
+	srv.smu.Lock()
+	s, ok := srv.stores[namespace]
+	delete(srv.stores, namespace)
+	srv.smu.Unlock()
+	if !ok {
+		return nil
+	}
+	return s.close()
+}
+
+// closeStores closes all Stores opened using Store.
+func (srv *Server) closeStores() {
+	srv.smu.Lock()
+	defer srv.smu.Unlock()
+	for namespace, s := range srv.stores {
+		if err := s.close(); err != nil {
+			srv.conf.Log.Error("Close store: "+err.Error(), "namespace", namespace)
+		}
+	}
+	clear(srv.stores)
+}
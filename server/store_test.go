@@ -0,0 +1,58 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"maps"
+	"testing"
+)
+
+func TestStore(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	srv := Config{Log: log, DisableResourceBuilding: true, StoreFolder: t.TempDir()}.New()
+	closeWorlds(t, srv)
+
+	if _, ok := srv.Store("My Plugin"); ok {
+		t.Fatalf("expected invalid namespace to be refused")
+	}
+	s, ok := srv.Store("homes")
+	if !ok {
+		t.Fatalf("expected store to be returned")
+	}
+	if _, ok := s.Get("steve"); ok {
+		t.Fatalf("expected no value for an unset key")
+	}
+	if err := s.Set("steve", []byte("0,64,0")); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := s.Set("alex", []byte("16,70,-3")); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if v, ok := s.Get("steve"); !ok || string(v) != "0,64,0" {
+		t.Fatalf("expected value 0,64,0, got %q (%v)", v, ok)
+	}
+	if err := s.Delete("alex"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, ok := s.Get("alex"); ok {
+		t.Fatalf("expected deleted key to have no value")
+	}
+	if all := maps.Collect(s.All()); len(all) != 1 || string(all["steve"]) != "0,64,0" {
+		t.Fatalf("expected only steve to be stored, got %v", all)
+	}
+
+	if err := srv.CloseStore("homes"); err != nil {
+		t.Fatalf("close store: %v", err)
+	}
+	if err := s.Set("alex", nil); err == nil {
+		t.Fatalf("expected writing to a closed store to fail")
+	}
+	reopened, _ := srv.Store("homes")
+	if v, ok := reopened.Get("steve"); !ok || string(v) != "0,64,0" {
+		t.Fatalf("expected value to persist after reopening, got %q (%v)", v, ok)
+	}
+	srv.closeStores()
+	if _, ok := reopened.Get("steve"); ok {
+		t.Fatalf("expected store to be closed with the server")
+	}
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+)
+
+// TransferPlayer transfers the online player with the UUID passed to another
+// server, such as a lobby in a network of servers. The address must be of the
+// form host:port. As with player.Player.Transfer, the player.Handler of the
+// player may cancel the transfer in HandleTransfer. False is returned if the
+// player is not online, and an error is returned if the address is invalid.
+// tx should be the transaction that the caller is running in, or nil if it is
+// not running in one.
+func (srv *Server) TransferPlayer(tx *world.Tx, id uuid.UUID, address string) (bool, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return false, fmt.Errorf("transfer player: invalid address %q: %w", address, err)
+	}
+	if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 || host == "" {
+		return false, fmt.Errorf("transfer player: invalid address %q: expected host:port", address)
+	}
+	ok := srv.withPlayer(tx, id, func(p *player.Player) {
+		err = p.Transfer(address)
+	})
+	if err != nil {
+		return false, fmt.Errorf("transfer player: %w", err)
+	}
+	return ok, nil
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// transferVeto is a player.Handler that cancels transfers to a port.
+type transferVeto struct {
+	player.NopHandler
+	port int
+}
+
+func (h transferVeto) HandleTransfer(ctx *player.Context, addr *net.UDPAddr) {
+	if addr.Port == h.port {
+		ctx.Cancel()
+	}
+}
+
+func TestTransferPlayer(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	srv := Config{Log: log, DisableResourceBuilding: true}.New()
+	closeWorlds(t, srv)
+
+	var (
+		mu    sync.Mutex
+		ports []uint16
+	)
+	conn := newLoginConn(uuid.New())
+	conn.written = func(pk packet.Packet) {
+		if tr, ok := pk.(*packet.Transfer); ok {
+			mu.Lock()
+			ports = append(ports, tr.Port)
+			mu.Unlock()
+		}
+	}
+	awaitPort := func(port uint16) []uint16 {
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			mu.Lock()
+			seen := slices.Clone(ports)
+			mu.Unlock()
+			if slices.Contains(seen, port) {
+				return seen
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected transfer to port %v to be sent to the player", port)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	acceptConn(srv, conn, srv.World())
+
+	for _, addr := range []string{"127.0.0.1", "127.0.0.1:0", "127.0.0.1:70000", ":19132"} {
+		if _, err := srv.TransferPlayer(nil, conn.id, addr); err == nil {
+			t.Fatalf("expected address %q to be rejected", addr)
+		}
+	}
+	if ok, err := srv.TransferPlayer(nil, uuid.New(), "127.0.0.1:19133"); ok || err != nil {
+		t.Fatalf("expected transfer of offline player to fail without error, got %v, %v", ok, err)
+	}
+	if ok, err := srv.TransferPlayer(nil, conn.id, "127.0.0.1:19133"); !ok || err != nil {
+		t.Fatalf("expected transfer to succeed, got %v, %v", ok, err)
+	}
+	awaitPort(19133)
+
+	handle, _ := srv.Player(conn.id)
+	handle.ExecWorld(func(tx *world.Tx, e world.Entity) {
+		e.(*player.Player).Handle(transferVeto{port: 19134})
+	})
+	if _, err := srv.TransferPlayer(nil, conn.id, "127.0.0.1:19134"); err != nil {
+		t.Fatalf("transfer: %v", err)
+	}
+	// Transferring from within the transaction of the player's world must not
+	// block on that same world.
+	var err error
+	handle.ExecWorld(func(tx *world.Tx, e world.Entity) {
+		_, err = srv.TransferPlayer(tx, conn.id, "127.0.0.1:19135")
+	})
+	if err != nil {
+		t.Fatalf("transfer: %v", err)
+	}
+	// Packets are written in order, so the vetoed transfer would have been
+	// written before the one that followed it.
+	if seen := awaitPort(19135); slices.Contains(seen, 19134) {
+		t.Fatalf("expected transfer cancelled by the handler not to be sent, got transfers to %v", seen)
+	}
+}
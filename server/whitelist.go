@@ -154,6 +154,23 @@ func (w *Whitelist) Players() []string {
 	return names
 }
 
+// Reload re-reads the whitelist file and replaces the players in the whitelist
+// with the players found in it. If the file is missing or cannot be decoded, an
+// error is returned and the whitelist is left unchanged.
+func (w *Whitelist) Reload() error {
+	if w == nil {
+		return ErrWhitelistUnavailable
+	}
+	players, err := readWhitelist(w.filePath)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	w.players = players
+	w.mu.Unlock()
+	return nil
+}
+
 func (w *Whitelist) reloadFromDisk() error {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -161,29 +178,40 @@ func (w *Whitelist) reloadFromDisk() error {
 }
 
 func (w *Whitelist) reloadLocked() error {
-	data := whitelistFile{}
-	contents, err := os.ReadFile(w.filePath)
+	players, err := readWhitelist(w.filePath)
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
 			w.players = make(map[string]string)
 			return w.writeLocked()
 		}
-		return fmt.Errorf("read whitelist: %w", err)
+		return err
+	}
+	w.players = players
+	return nil
+}
+
+// readWhitelist reads and decodes the whitelist file at the path passed,
+// returning the players in it keyed by their normalised name.
+func readWhitelist(path string) (map[string]string, error) {
+	data := whitelistFile{}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read whitelist: %w", err)
 	}
 	if len(contents) != 0 {
 		if err := toml.Unmarshal(contents, &data); err != nil {
-			return fmt.Errorf("decode whitelist: %w", err)
+			return nil, fmt.Errorf("decode whitelist: %w", err)
 		}
 	}
-	w.players = make(map[string]string, len(data.Players))
+	players := make(map[string]string, len(data.Players))
 	for _, name := range data.Players {
 		trimmed := strings.TrimSpace(name)
 		if trimmed == "" {
 			continue
 		}
-		w.players[normalizeName(trimmed)] = trimmed
+		players[normalizeName(trimmed)] = trimmed
 	}
-	return nil
+	return players, nil
 }
 
 func (w *Whitelist) writeLocked() error {
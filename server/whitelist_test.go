@@ -0,0 +1,55 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+)
+
+func TestWhitelistReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "whitelist.toml")
+	if err := os.WriteFile(path, []byte(`players = ["Alex"]`), 0644); err != nil {
+		t.Fatalf("write whitelist: %v", err)
+	}
+	wl, err := LoadWhitelist(path)
+	if err != nil {
+		t.Fatalf("load whitelist: %v", err)
+	}
+	wl.SetEnabled(true)
+
+	if err := os.WriteFile(path, []byte(`players = ["Steve", "Notch"]`), 0644); err != nil {
+		t.Fatalf("write whitelist: %v", err)
+	}
+	if err := wl.Reload(); err != nil {
+		t.Fatalf("reload whitelist: %v", err)
+	}
+	if want := []string{"Notch", "Steve"}; !slices.Equal(wl.Players(), want) {
+		t.Fatalf("expected players %v after reload, got %v", want, wl.Players())
+	}
+	if _, ok := wl.Allow(nil, login.IdentityData{DisplayName: "steve"}, login.ClientData{}); !ok {
+		t.Fatalf("expected steve to be allowed after reload")
+	}
+	if _, ok := wl.Allow(nil, login.IdentityData{DisplayName: "Alex"}, login.ClientData{}); ok {
+		t.Fatalf("expected Alex not to be allowed after reload")
+	}
+
+	// A malformed file must leave the previous players intact.
+	if err := os.WriteFile(path, []byte(`players = [`), 0644); err != nil {
+		t.Fatalf("write whitelist: %v", err)
+	}
+	if err := wl.Reload(); err == nil {
+		t.Fatalf("expected reloading a malformed whitelist to fail")
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("remove whitelist: %v", err)
+	}
+	if err := wl.Reload(); err == nil {
+		t.Fatalf("expected reloading a missing whitelist to fail")
+	}
+	if want := []string{"Notch", "Steve"}; !slices.Equal(wl.Players(), want) {
+		t.Fatalf("expected players %v after failed reloads, got %v", want, wl.Players())
+	}
+}
@@ -0,0 +1,121 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// spawnRecorder is a Handler that records the entities spawned in a World.
+type spawnRecorder struct {
+	NopHandler
+	spawned []Entity
+}
+
+func (h *spawnRecorder) HandleEntitySpawn(_ *Tx, e Entity) { h.spawned = append(h.spawned, e) }
+
+// batchViewer is an EntityBatchViewer that records the calls to
+// ViewEntities.
+type batchViewer struct {
+	entityViewer
+	batches [][]Entity
+}
+
+func (v *batchViewer) ViewEntities(entities []Entity) {
+	v.batches = append(v.batches, entities)
+	for _, e := range entities {
+		v.ViewEntity(e)
+	}
+}
+
+func TestAddEntitiesShowsAllToViewers(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	h := &spawnRecorder{}
+	w.Handle(h)
+
+	batched, single := &entityViewer{shown: map[*EntityHandle]bool{}}, &entityViewer{shown: map[*EntityHandle]bool{}}
+	loader := NewLoader(1, w, batched)
+	<-w.Exec(func(tx *Tx) { loader.Move(tx, mgl64.Vec3{}) })
+	waitChunkLoaded(t, w, loader, ChunkPos{})
+
+	var (
+		handles  []*EntityHandle
+		entities []Entity
+		inChunk  int
+	)
+	for i := range 5 {
+		handles = append(handles, EntitySpawnOpts{Position: mgl64.Vec3{float64(i) + 0.5, 64, 8}}.New(testEntityType{}, testEntityConfig{}))
+	}
+	<-w.Exec(func(tx *Tx) {
+		entities = tx.AddEntities(handles)
+		inChunk = len(tx.World().chunks[ChunkPos{}].Entities)
+		// Show the entities individually to another viewer, as AddEntity
+		// does, to compare the result against.
+		for _, e := range entities {
+			tx.World().showEntity(e, single)
+		}
+	})
+
+	if len(entities) != len(handles) {
+		t.Fatalf("expected %v entities, got %v", len(handles), len(entities))
+	}
+	if inChunk != len(handles) {
+		t.Fatalf("expected %v entities in chunk, got %v", len(handles), inChunk)
+	}
+	for i, e := range entities {
+		if e.H() != handles[i] {
+			t.Fatalf("expected entity %v to be created from handle %v", i, i)
+		}
+		if !batched.shown[e.H()] || !single.shown[e.H()] {
+			t.Fatalf("expected entity %v to be shown to both viewers", i)
+		}
+		if h.spawned[i] != e {
+			t.Fatalf("expected HandleEntitySpawn to be called for entity %v in order", i)
+		}
+	}
+	if len(batched.shown) != len(single.shown) || len(h.spawned) != len(handles) {
+		t.Fatalf("expected batched and individual paths to show the same entities")
+	}
+}
+
+func TestAddEntitiesBatchesViewers(t *testing.T) {
+	w := newTestWorld(t, Config{})
+
+	batched := &batchViewer{entityViewer: entityViewer{shown: map[*EntityHandle]bool{}}}
+	loader := NewLoader(2, w, batched)
+	<-w.Exec(func(tx *Tx) { loader.Move(tx, mgl64.Vec3{}) })
+	waitChunkLoaded(t, w, loader, ChunkPos{})
+	waitChunkLoaded(t, w, loader, ChunkPos{1, 0})
+
+	var handles []*EntityHandle
+	for i := range 3 {
+		handles = append(handles, EntitySpawnOpts{Position: mgl64.Vec3{float64(i) + 0.5, 64, 8}}.New(testEntityType{}, testEntityConfig{}))
+	}
+	handles = append(handles, EntitySpawnOpts{Position: mgl64.Vec3{24, 64, 8}}.New(testEntityType{}, testEntityConfig{}))
+	hidden := EntitySpawnOpts{Position: mgl64.Vec3{4.5, 64, 8}}.New(testEntityType{}, testEntityConfig{})
+
+	<-w.Exec(func(tx *Tx) {
+		// HideEntityFrom requires the entity to be in the world already, so
+		// the entity is marked as hidden directly.
+		w.hiddenEntities = map[*EntityHandle]map[Viewer]struct{}{hidden: {batched: {}}}
+		tx.AddEntities(append(handles, hidden))
+	})
+
+	if len(batched.batches) != 2 {
+		t.Fatalf("expected one ViewEntities call per chunk, got %v", len(batched.batches))
+	}
+	if n := len(batched.batches[0]); n != 3 {
+		t.Fatalf("expected 3 visible entities in the first chunk's batch, got %v", n)
+	}
+	for i, e := range batched.batches[0] {
+		if e.H() != handles[i] {
+			t.Fatalf("expected entity %v of the batch to be created from handle %v", i, i)
+		}
+	}
+	if n := len(batched.batches[1]); n != 1 || batched.batches[1][0].H() != handles[3] {
+		t.Fatalf("expected the second chunk's batch to hold the last entity only")
+	}
+	if batched.shown[hidden] {
+		t.Fatalf("expected hidden entity not to be passed to ViewEntities")
+	}
+}
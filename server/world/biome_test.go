@@ -0,0 +1,49 @@
+package world
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// testBiome is a Biome with a fixed ID used in tests.
+type testBiome int
+
+func (testBiome) Temperature() float64    { return 0.5 }
+func (testBiome) Rainfall() float64       { return 0.5 }
+func (testBiome) Depth() float64          { return 0 }
+func (testBiome) Scale() float64          { return 0 }
+func (testBiome) WaterColour() color.RGBA { return color.RGBA{} }
+func (testBiome) Tags() []string          { return nil }
+func (b testBiome) String() string        { return "test" }
+func (b testBiome) EncodeBiome() int      { return int(b) }
+
+func TestSetBiomesCheckerboard(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	checker := func(x, z int) testBiome {
+		if (x+z)%2 == 0 {
+			return testBiome(1)
+		}
+		return testBiome(2)
+	}
+
+	<-w.Exec(func(tx *Tx) {
+		tx.SetBiomes(cube.Pos{16, 60, 0}, [3]int{16, 8, 16}, func(x, y, z int) Biome {
+			return checker(x, z)
+		})
+		c := w.chunks[ChunkPos{1, 0}]
+		for x := 0; x < 16; x++ {
+			for z := 0; z < 16; z++ {
+				for y := 60; y < 68; y++ {
+					if got, want := c.Biome(uint8(x), int16(y), uint8(z)), uint32(checker(x, z)); got != want {
+						t.Fatalf("expected biome %v at %v, got %v", want, cube.Pos{16 + x, y, z}, got)
+					}
+				}
+			}
+		}
+		if got := c.Biome(0, 68, 0); got == uint32(checker(0, 0)) {
+			t.Fatalf("expected biome above the stamped region to be unchanged")
+		}
+	})
+}
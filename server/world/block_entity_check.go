@@ -0,0 +1,91 @@
+package world
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// validateBlockEntities checks all block entities in the loaded chunks of the
+// World and returns an error for each block entity that would not survive
+// being saved to and loaded from a Provider unchanged.
+func (w *World) validateBlockEntities() []error {
+	var errs []error
+	for _, c := range w.chunks {
+		if !c.Ready() {
+			continue
+		}
+		errs = append(errs, validateColumnBlockEntities(c)...)
+	}
+	return errs
+}
+
+// validateColumnBlockEntities checks all block entities in the Column passed
+// using validateBlockEntity.
+func validateColumnBlockEntities(c *Column) []error {
+	var errs []error
+	for pos, b := range c.BlockEntities {
+		if err := validateBlockEntity(pos, b); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// validateBlockEntity performs the same NBT round-trip that columnTo and
+// columnFrom perform for a block entity: The block is encoded, written to
+// NBT, read back and decoded using the block registered for its runtime ID.
+// An error is returned if any step fails or if the decoded block does not
+// encode to the same data as the original block.
+func validateBlockEntity(pos cube.Pos, b Block) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("block entity %T at %v: panic during round-trip: %v", b, pos, r)
+		}
+	}()
+	nb, ok := b.(NBTer)
+	if !ok {
+		return fmt.Errorf("block entity %T at %v: does not implement NBTer", b, pos)
+	}
+	data, err := nbtRoundTrip(nb.EncodeNBT())
+	if err != nil {
+		return fmt.Errorf("block entity %T at %v: encode: %w", b, pos, err)
+	}
+	rid := BlockRuntimeID(b)
+	registered, ok := BlockByRuntimeID(rid)
+	if !ok {
+		return fmt.Errorf("block entity %T at %v: no block with runtime ID %v", b, pos, rid)
+	}
+	registeredNBT, ok := registered.(NBTer)
+	if !ok {
+		return fmt.Errorf("block entity %T at %v: registered block %T does not implement NBTer", b, pos, registered)
+	}
+	decoded, ok := registeredNBT.DecodeNBT(data).(NBTer)
+	if !ok {
+		return fmt.Errorf("block entity %T at %v: DecodeNBT did not return a block implementing NBTer", b, pos)
+	}
+	reencoded, err := nbtRoundTrip(decoded.EncodeNBT())
+	if err != nil {
+		return fmt.Errorf("block entity %T at %v: encode decoded block: %w", b, pos, err)
+	}
+	if !reflect.DeepEqual(data, reencoded) {
+		return fmt.Errorf("block entity %T at %v: data changed after round-trip: %v != %v", b, pos, data, reencoded)
+	}
+	return nil
+}
+
+// nbtRoundTrip encodes the data passed to NBT and decodes it again, so that
+// the map returned holds the same types as a map read from a Provider.
+func nbtRoundTrip(data map[string]any) (map[string]any, error) {
+	b, err := nbt.MarshalEncoding(data, nbt.LittleEndian)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := nbt.UnmarshalEncoding(b, &m, nbt.LittleEndian); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
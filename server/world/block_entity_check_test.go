@@ -0,0 +1,39 @@
+package world
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// brokenBlockEntity is a block entity that encodes data that cannot be
+// written to NBT.
+type brokenBlockEntity struct{}
+
+func (brokenBlockEntity) EncodeBlock() (string, map[string]any) { return "test:broken", nil }
+func (brokenBlockEntity) Hash() (uint64, uint64)                { return 0, 0 }
+func (brokenBlockEntity) Model() BlockModel                     { return unknownModel{} }
+func (brokenBlockEntity) DecodeNBT(map[string]any) any          { return brokenBlockEntity{} }
+func (brokenBlockEntity) EncodeNBT() map[string]any {
+	return map[string]any{"callback": func() {}}
+}
+
+func TestValidateBlockEntity(t *testing.T) {
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("stone block state not registered")
+	}
+	b, _ := BlockByRuntimeID(rid)
+	valid := b.(NBTer).DecodeNBT(map[string]any{"id": "Test", "Value": int32(5)}).(Block)
+	if err := validateBlockEntity(cube.Pos{}, valid); err != nil {
+		t.Fatalf("expected valid block entity to pass validation, got %v", err)
+	}
+
+	if err := validateBlockEntity(cube.Pos{1, 2, 3}, brokenBlockEntity{}); err == nil {
+		t.Fatalf("expected broken block entity to fail validation")
+	} else if !strings.Contains(err.Error(), "encode") {
+		t.Fatalf("expected encoding error for broken block entity, got %v", err)
+	}
+}
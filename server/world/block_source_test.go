@@ -0,0 +1,49 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// supportedModel is a BlockModel that only has a solid top face if the block
+// below it has the runtime ID rid.
+type supportedModel struct{ rid uint32 }
+
+func (supportedModel) BBox(cube.Pos, BlockSource) []cube.BBox { return nil }
+func (m supportedModel) FaceSolid(pos cube.Pos, face cube.Face, s BlockSource) bool {
+	return face == cube.FaceUp && BlockRuntimeID(s.Block(pos.Side(cube.FaceDown))) == m.rid
+}
+
+func TestTxSource(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	stone, _ := BlockByRuntimeID(rid)
+
+	pos := cube.Pos{0, 10, 0}
+	var unsupported, supported bool
+	var src BlockSource
+	<-w.Exec(func(tx *Tx) {
+		src = tx.Source()
+		unsupported = supportedModel{rid: rid}.FaceSolid(pos, cube.FaceUp, src)
+		tx.SetBlock(pos.Side(cube.FaceDown), stone, nil)
+		supported = supportedModel{rid: rid}.FaceSolid(pos, cube.FaceUp, src)
+	})
+	if unsupported {
+		t.Fatalf("expected top face to be non-solid without a block below")
+	}
+	if !supported {
+		t.Fatalf("expected top face to be solid with stone below")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected use of source after transaction to panic")
+		}
+	}()
+	src.Block(pos)
+}
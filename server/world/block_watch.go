@@ -0,0 +1,83 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// blockWatcher is a region registered through World.WatchBlockChanges.
+type blockWatcher struct {
+	box cube.BBox
+	fn  func(pos cube.Pos, old, new Block)
+}
+
+// watches checks if the block at the position passed lies within the region of
+// the blockWatcher. A block is considered inside if its centre is.
+func (bw *blockWatcher) watches(pos cube.Pos) bool {
+	return bw.box.Vec3Within(pos.Vec3Centre())
+}
+
+// WatchBlockChanges registers fn to be called for every block changed within
+// box through Tx.SetBlock or Tx.BuildStructure. A block is considered within box
+// if its centre is. fn is passed the block present before the change and the
+// block that replaced it. The function returned removes the watcher again and
+// may be called more than once.
+//
+// fn is called on the tick goroutine of the World while a transaction is
+// running, in the middle of the block change. It must therefore be cheap and
+// must not block or wait for another transaction on this World.
+func (w *World) WatchBlockChanges(box cube.BBox, fn func(pos cube.Pos, old, new Block)) func() {
+	if w == nil {
+		return func() {}
+	}
+	bw := &blockWatcher{box: box, fn: fn}
+
+	w.blockWatcherMu.Lock()
+	defer w.blockWatcherMu.Unlock()
+	watchers := append(w.currentBlockWatchers(), bw)
+	w.blockWatchers.Store(&watchers)
+
+	return func() {
+		w.blockWatcherMu.Lock()
+		defer w.blockWatcherMu.Unlock()
+		current := w.currentBlockWatchers()
+		watchers := make([]*blockWatcher, 0, len(current))
+		for _, other := range current {
+			if other != bw {
+				watchers = append(watchers, other)
+			}
+		}
+		w.blockWatchers.Store(&watchers)
+	}
+}
+
+// currentBlockWatchers returns the blockWatchers currently registered. The
+// slice returned must not be modified.
+func (w *World) currentBlockWatchers() []*blockWatcher {
+	if watchers := w.blockWatchers.Load(); watchers != nil {
+		return *watchers
+	}
+	return nil
+}
+
+// blockWatchersAt returns all blockWatchers that watch the position passed.
+func (w *World) blockWatchersAt(pos cube.Pos) []*blockWatcher {
+	var watching []*blockWatcher
+	for _, bw := range w.currentBlockWatchers() {
+		if bw.watches(pos) {
+			watching = append(watching, bw)
+		}
+	}
+	return watching
+}
+
+// blockWatchersIntersecting returns all blockWatchers with a region that
+// intersects the BBox passed.
+func (w *World) blockWatchersIntersecting(box cube.BBox) []*blockWatcher {
+	var watching []*blockWatcher
+	for _, bw := range w.currentBlockWatchers() {
+		if bw.box.IntersectsWith(box) {
+			watching = append(watching, bw)
+		}
+	}
+	return watching
+}
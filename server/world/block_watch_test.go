@@ -0,0 +1,51 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// blockChange is a change of a block recorded by a watcher registered using
+// World.WatchBlockChanges.
+type blockChange struct {
+	pos      cube.Pos
+	old, new Block
+}
+
+func TestWatchBlockChanges(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	stone, _ := BlockByRuntimeID(rid)
+
+	var changes []blockChange
+	cancel := w.WatchBlockChanges(cube.Box(0, 0, 0, 4, 20, 4), func(pos cube.Pos, old, new Block) {
+		changes = append(changes, blockChange{pos: pos, old: old, new: new})
+	})
+
+	inside, outside := cube.Pos{1, 10, 1}, cube.Pos{8, 10, 8}
+	<-w.Exec(func(tx *Tx) {
+		tx.SetBlock(inside, stone, nil)
+		tx.SetBlock(outside, stone, nil)
+	})
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 block change inside the box to be watched, got %v", len(changes))
+	}
+	if c := changes[0]; c.pos != inside || BlockRuntimeID(c.old) != airRID || BlockRuntimeID(c.new) != rid {
+		t.Fatalf("expected air to be replaced by stone at %v, got %#v", inside, c)
+	}
+
+	cancel()
+	<-w.Exec(func(tx *Tx) {
+		tx.SetBlock(inside, nil, nil)
+	})
+	if len(changes) != 1 {
+		t.Fatalf("expected no block changes to be watched after cancelling, got %v", len(changes)-1)
+	}
+	// Cancelling again must not panic.
+	cancel()
+}
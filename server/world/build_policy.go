@@ -0,0 +1,72 @@
+package world
+
+import "github.com/df-mc/dragonfly/server/block/cube"
+
+// BuildAction is an action that changes a block in a World, as checked by a
+// BuildPolicy.
+type BuildAction uint8
+
+const (
+	// BuildActionBreak is the action of an entity breaking a block.
+	BuildActionBreak BuildAction = iota
+	// BuildActionPlace is the action of an entity placing a block.
+	BuildActionPlace
+)
+
+// BuildPolicy decides if an Entity, typically a player, may perform a
+// BuildAction at a position in a World. Returning false denies the action.
+type BuildPolicy func(e Entity, pos cube.Pos, action BuildAction) bool
+
+// SetBuildPolicy sets the BuildPolicy that is consulted before players break
+// or place blocks in the World. Actions denied by the policy are cancelled
+// server-side, before the Handler of the player is called, regardless of the
+// game mode of the player. Passing nil removes the policy, allowing all
+// actions.
+func (w *World) SetBuildPolicy(p BuildPolicy) {
+	if p == nil {
+		w.buildPolicy.Store(nil)
+		return
+	}
+	w.buildPolicy.Store(&p)
+}
+
+// SetSpawnProtectionRadius sets the radius around the spawn position of the
+// World in which entities may not break or place blocks, unless
+// Config.SpawnProtectionBypass returns true for them. A radius of 0 or lower
+// disables spawn protection.
+func (w *World) SetSpawnProtectionRadius(r int) {
+	w.spawnProtectionRadius.Store(int64(max(r, 0)))
+}
+
+// SpawnProtectionRadius returns the radius around the spawn position of the
+// World in which blocks are protected, as set using SetSpawnProtectionRadius.
+func (w *World) SpawnProtectionRadius() int {
+	return int(w.spawnProtectionRadius.Load())
+}
+
+// spawnProtected checks if an Entity is denied changing the block at a
+// position because it is within the spawn protection radius of the World.
+func (w *World) spawnProtected(e Entity, pos cube.Pos) bool {
+	r := int(w.spawnProtectionRadius.Load())
+	if r <= 0 {
+		return false
+	}
+	spawn := w.Spawn()
+	dx, dz := pos[0]-spawn[0], pos[2]-spawn[2]
+	if dx < -r || dx > r || dz < -r || dz > r {
+		return false
+	}
+	return w.conf.SpawnProtectionBypass == nil || !w.conf.SpawnProtectionBypass(e)
+}
+
+// mayBuild checks if the spawn protection and BuildPolicy of the World allow
+// an Entity to perform a BuildAction at a position.
+func (w *World) mayBuild(e Entity, pos cube.Pos, action BuildAction) bool {
+	if w.spawnProtected(e, pos) {
+		return false
+	}
+	if p := w.buildPolicy.Load(); p != nil {
+		return (*p)(e, pos, action)
+	}
+	return true
+}
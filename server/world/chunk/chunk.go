@@ -119,6 +119,16 @@ func (chunk *Chunk) Light(x uint8, y int16, z uint8) uint8 {
 	return sky
 }
 
+// FillFullLight sets the skylight of all sub chunks in the chunk to the maximum
+// level and removes all block light, without calculating the actual light of
+// the blocks in the chunk.
+func (chunk *Chunk) FillFullLight() {
+	for _, sub := range chunk.sub {
+		sub.skyLight = fullLight
+		sub.blockLight = noLight
+	}
+}
+
 // SkyLight returns the skylight level at a specific position in the chunk.
 func (chunk *Chunk) SkyLight(x uint8, y int16, z uint8) uint8 {
 	return chunk.SubChunk(y).SkyLight(x&15, uint8(y&15), z&15)
@@ -10,6 +10,9 @@ type Column struct {
 	BlockEntities   []BlockEntity
 	Tick            int64
 	ScheduledBlocks []ScheduledBlockUpdate
+	// InhabitedTime is the number of ticks that the column was simulated
+	// because a viewer was near it.
+	InhabitedTime int64
 }
 
 type BlockEntity struct {
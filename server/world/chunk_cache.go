@@ -0,0 +1,59 @@
+package world
+
+import (
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// chunkCache is a least recently used cache of columns of recently closed
+// chunks, in the form they were saved in. It allows chunks that are unloaded
+// and loaded again shortly after, for example by players moving back and forth
+// at the edge of their view distance, to be loaded without reading them from
+// the Provider or generating them again. A chunkCache may only be used within
+// a transaction.
+type chunkCache struct {
+	size  int
+	order []ChunkPos
+	cols  map[ChunkPos]*chunk.Column
+}
+
+// newChunkCache creates a chunkCache holding at most size columns. Nil is
+// returned if size is 0 or lower.
+func newChunkCache(size int) *chunkCache {
+	if size <= 0 {
+		return nil
+	}
+	return &chunkCache{size: size, order: make([]ChunkPos, 0, size), cols: make(map[ChunkPos]*chunk.Column, size)}
+}
+
+// put adds the column at a position to the cache, evicting the least recently
+// closed column if the cache is full.
+func (c *chunkCache) put(pos ChunkPos, col *chunk.Column) {
+	if c == nil {
+		return
+	}
+	if _, ok := c.cols[pos]; ok {
+		c.order = slices.DeleteFunc(c.order, func(p ChunkPos) bool { return p == pos })
+	} else if len(c.order) >= c.size {
+		delete(c.cols, c.order[0])
+		c.order = slices.Delete(c.order, 0, 1)
+	}
+	c.order = append(c.order, pos)
+	c.cols[pos] = col
+}
+
+// take removes the column at a position from the cache and returns it. False
+// is returned if no column at the position was cached.
+func (c *chunkCache) take(pos ChunkPos) (*chunk.Column, bool) {
+	if c == nil {
+		return nil, false
+	}
+	col, ok := c.cols[pos]
+	if !ok {
+		return nil, false
+	}
+	delete(c.cols, pos)
+	c.order = slices.DeleteFunc(c.order, func(p ChunkPos) bool { return p == pos })
+	return col, true
+}
@@ -0,0 +1,75 @@
+package world
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb"
+)
+
+// loadRecorder is a Provider that counts the number of times each column was
+// loaded.
+type loadRecorder struct {
+	NopProvider
+	mu     sync.Mutex
+	loaded map[ChunkPos]int
+}
+
+func (l *loadRecorder) LoadColumn(pos ChunkPos, _ Dimension) (*chunk.Column, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.loaded[pos]++
+	return nil, leveldb.ErrNotFound
+}
+
+func TestChunkCacheServesRecentlyClosedChunk(t *testing.T) {
+	prov := &loadRecorder{loaded: make(map[ChunkPos]int)}
+	w := newTestWorld(t, Config{Provider: prov, ChunkCacheSize: 4})
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	stone, _ := BlockByRuntimeID(rid)
+
+	pos := cube.Pos{20, 10, 4}
+	var (
+		collected int
+		after     Block
+	)
+	<-w.Exec(func(tx *Tx) {
+		tx.SetBlock(pos, stone, nil)
+		collected, _, _ = w.CollectGarbage(tx)
+		after = tx.Block(pos)
+	})
+	if collected == 0 {
+		t.Fatalf("expected at least one chunk to be collected")
+	}
+	if BlockRuntimeID(after) != rid {
+		t.Fatalf("expected stone to be kept in the cached chunk, got %#v", after)
+	}
+	prov.mu.Lock()
+	defer prov.mu.Unlock()
+	if n := prov.loaded[ChunkPos{1, 0}]; n != 1 {
+		t.Fatalf("expected chunk to be loaded from the provider once, got %v times", n)
+	}
+}
+
+func TestChunkCacheEvictsLeastRecent(t *testing.T) {
+	c := newChunkCache(2)
+	c.put(ChunkPos{0, 0}, &chunk.Column{})
+	c.put(ChunkPos{1, 0}, &chunk.Column{})
+	c.put(ChunkPos{0, 0}, &chunk.Column{})
+	c.put(ChunkPos{2, 0}, &chunk.Column{})
+
+	if _, ok := c.take(ChunkPos{1, 0}); ok {
+		t.Fatalf("expected least recently closed column to be evicted")
+	}
+	if _, ok := c.take(ChunkPos{0, 0}); !ok {
+		t.Fatalf("expected column to be cached")
+	}
+	if _, ok := c.take(ChunkPos{0, 0}); ok {
+		t.Fatalf("expected column to be removed from the cache after taking it")
+	}
+}
@@ -0,0 +1,78 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb"
+)
+
+// ChunkLoadErrorPolicy specifies how a World handles errors returned by its
+// Provider when loading a chunk, such as I/O errors or corrupted data.
+type ChunkLoadErrorPolicy uint8
+
+const (
+	// ChunkLoadErrorSubstitute logs the error and uses an empty chunk in place
+	// of the chunk that could not be loaded. The empty chunk is never saved.
+	ChunkLoadErrorSubstitute ChunkLoadErrorPolicy = iota
+	// ChunkLoadErrorRetry retries loading the chunk, waiting
+	// Config.ChunkLoadRetryDelay before the first retry and doubling the delay
+	// for every following retry. The World is blocked while waiting. If all
+	// Config.ChunkLoadRetries retries fail, ChunkLoadErrorSubstitute is
+	// applied, and the chunk is not retried again for a minute: Loading it
+	// in that time fails immediately if the first attempt fails.
+	ChunkLoadErrorRetry
+	// ChunkLoadErrorFail panics with the error, stopping the server before
+	// anything could be changed in a chunk that could not be loaded.
+	ChunkLoadErrorFail
+)
+
+// chunkLoadRetryCooldown is the time for which a chunk that could not be
+// loaded after all retries of ChunkLoadErrorRetry is not retried again.
+const chunkLoadRetryCooldown = time.Minute
+
+// loadColumn loads the column at a position from the Provider of the World,
+// applying the ChunkLoadErrorPolicy of the World if loading it fails.
+func (w *World) loadColumn(pos ChunkPos) (*chunk.Column, error) {
+	column, err := w.conf.Provider.LoadColumn(pos, w.conf.Dim)
+	if err == nil || errors.Is(err, leveldb.ErrNotFound) {
+		delete(w.loadFailures, pos)
+		return column, err
+	}
+	switch w.conf.ChunkLoadErrorPolicy {
+	case ChunkLoadErrorRetry:
+		now := time.Now()
+		if failed, ok := w.loadFailures[pos]; ok && now.Sub(failed) < chunkLoadRetryCooldown {
+			// Retrying blocks the World, so a chunk that could not be loaded
+			// is not retried on every access.
+			return nil, err
+		}
+		delay := w.conf.ChunkLoadRetryDelay
+		for i := 0; i < w.conf.ChunkLoadRetries; i++ {
+			w.conf.Log.Warn("load chunk: retrying: "+err.Error(), "X", pos[0], "Z", pos[1], "retry", i+1, "delay", delay)
+			time.Sleep(delay)
+			delay *= 2
+
+			column, err = w.conf.Provider.LoadColumn(pos, w.conf.Dim)
+			if err == nil || errors.Is(err, leveldb.ErrNotFound) {
+				delete(w.loadFailures, pos)
+				return column, err
+			}
+		}
+		if w.loadFailures == nil {
+			w.loadFailures = make(map[ChunkPos]time.Time)
+		}
+		// Forget chunks of which the cooldown passed, so that the map does not
+		// grow indefinitely.
+		maps.DeleteFunc(w.loadFailures, func(_ ChunkPos, failed time.Time) bool {
+			return time.Since(failed) >= chunkLoadRetryCooldown
+		})
+		w.loadFailures[pos] = time.Now()
+	case ChunkLoadErrorFail:
+		panic(fmt.Errorf("load chunk %v: %w", pos, err))
+	}
+	return nil, err
+}
@@ -0,0 +1,120 @@
+package world
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// failingProvider is a Provider that fails loading columns the first fails
+// times LoadColumn is called.
+type failingProvider struct {
+	NopProvider
+	mu    sync.Mutex
+	fails int
+	calls int
+}
+
+func (f *failingProvider) LoadColumn(pos ChunkPos, dim Dimension) (*chunk.Column, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.calls <= f.fails {
+		return nil, errors.New("i/o error")
+	}
+	return f.NopProvider.LoadColumn(pos, dim)
+}
+
+func (f *failingProvider) loadCalls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func newFailingWorld(t *testing.T, prov *failingProvider, policy ChunkLoadErrorPolicy) *World {
+	w := newTestWorld(t, Config{
+		Log:                  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Provider:             prov,
+		ChunkLoadErrorPolicy: policy,
+		ChunkLoadRetryDelay:  time.Millisecond,
+	})
+	return w
+}
+
+func TestChunkLoadErrorSubstitute(t *testing.T) {
+	prov := &failingProvider{fails: 1}
+	w := newFailingWorld(t, prov, ChunkLoadErrorSubstitute)
+
+	var stored bool
+	<-w.Exec(func(tx *Tx) {
+		tx.Block(cube.Pos{0, 0, 0})
+		_, stored = tx.World().chunks[ChunkPos{}]
+	})
+	if stored {
+		t.Fatalf("expected substituted chunk not to be stored in the world")
+	}
+	if calls := prov.loadCalls(); calls != 1 {
+		t.Fatalf("expected 1 load, got %v", calls)
+	}
+}
+
+func TestChunkLoadErrorRetry(t *testing.T) {
+	prov := &failingProvider{fails: 2}
+	w := newFailingWorld(t, prov, ChunkLoadErrorRetry)
+
+	var stored bool
+	<-w.Exec(func(tx *Tx) {
+		tx.Block(cube.Pos{0, 0, 0})
+		_, stored = tx.World().chunks[ChunkPos{}]
+	})
+	if !stored {
+		t.Fatalf("expected chunk to be loaded after retrying")
+	}
+	if calls := prov.loadCalls(); calls != 3 {
+		t.Fatalf("expected 3 loads, got %v", calls)
+	}
+}
+
+func TestChunkLoadErrorRetryExhausted(t *testing.T) {
+	prov := &failingProvider{fails: 10}
+	w := newFailingWorld(t, prov, ChunkLoadErrorRetry)
+
+	if _, err := w.loadColumn(ChunkPos{}); err == nil {
+		t.Fatalf("expected error after exhausting retries")
+	}
+	if calls := prov.loadCalls(); calls != 4 {
+		t.Fatalf("expected 4 loads, got %v", calls)
+	}
+	// A chunk that could not be loaded is not retried again right away.
+	if _, err := w.loadColumn(ChunkPos{}); err == nil {
+		t.Fatalf("expected error while the chunk is cooling down")
+	}
+	if calls := prov.loadCalls(); calls != 5 {
+		t.Fatalf("expected 1 load without retries during the cooldown, got %v", calls-4)
+	}
+	w.loadFailures[ChunkPos{}] = time.Now().Add(-chunkLoadRetryCooldown)
+	if _, err := w.loadColumn(ChunkPos{}); err == nil {
+		t.Fatalf("expected error after exhausting retries")
+	}
+	if calls := prov.loadCalls(); calls != 9 {
+		t.Fatalf("expected chunk to be retried after the cooldown, got %v loads", calls-5)
+	}
+}
+
+func TestChunkLoadErrorFail(t *testing.T) {
+	prov := &failingProvider{fails: 1}
+	w := newFailingWorld(t, prov, ChunkLoadErrorFail)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected loading chunk to panic")
+		}
+	}()
+	_, _ = w.loadColumn(ChunkPos{})
+}
@@ -0,0 +1,36 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+func TestMarkChunkModified(t *testing.T) {
+	prov := &storeRecorder{stored: make(map[ChunkPos]int)}
+	w := newTestWorld(t, Config{Provider: prov, SaveInterval: -1})
+
+	marked, unmarked := ChunkPos{0, 0}, ChunkPos{1, 0}
+	<-w.Exec(func(tx *Tx) {
+		tx.Block(cube.Pos{0, 0, 0})
+		tx.Block(cube.Pos{16, 0, 0})
+		w.chunks[marked].modified = false
+		w.chunks[unmarked].modified = false
+
+		tx.MarkChunkModified(marked)
+		tx.MarkChunkModified(ChunkPos{100, 100})
+		if _, ok := w.chunks[ChunkPos{100, 100}]; ok {
+			t.Fatalf("expected MarkChunkModified not to load the chunk")
+		}
+
+		if !w.saveChunk(tx, marked, w.chunks[marked]) {
+			t.Fatalf("expected chunk marked as modified to be saved")
+		}
+		if w.saveChunk(tx, unmarked, w.chunks[unmarked]) {
+			t.Fatalf("expected unmodified chunk not to be saved")
+		}
+	})
+	if prov.stored[marked] != 1 || prov.stored[unmarked] != 0 {
+		t.Fatalf("expected only %v to be stored, got %v", marked, prov.stored)
+	}
+}
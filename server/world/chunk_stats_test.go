@@ -0,0 +1,45 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestChunkStats(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	stone, _ := BlockByRuntimeID(rid)
+
+	<-w.Exec(func(tx *Tx) {
+		if _, _, _, ok := tx.ChunkStats(ChunkPos{3, 3}); ok {
+			t.Fatalf("expected stats of an unloaded chunk not to be reported")
+		}
+
+		c := w.chunk(ChunkPos{})
+		for i := range 3 {
+			tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{float64(i), 64, 8}}.New(testEntityType{}, testEntityConfig{}))
+		}
+		c.BlockEntities[cube.Pos{1, 10, 1}] = stone
+		c.BlockEntities[cube.Pos{2, 10, 2}] = stone
+		for i := range 4 {
+			tx.ScheduleBlockUpdate(cube.Pos{i, 20, 0}, stone, time.Second)
+		}
+		// A scheduled update in another chunk must not be counted.
+		tx.ScheduleBlockUpdate(cube.Pos{32, 20, 0}, stone, time.Second)
+
+		entities, blockEntities, scheduled, ok := tx.ChunkStats(ChunkPos{})
+		if !ok {
+			t.Fatalf("expected stats of a loaded chunk to be reported")
+		}
+		if entities != 3 || blockEntities != 2 || scheduled != 4 {
+			t.Fatalf("expected 3 entities, 2 block entities and 4 scheduled updates, got %v, %v and %v", entities, blockEntities, scheduled)
+		}
+	})
+}
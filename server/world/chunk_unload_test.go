@@ -0,0 +1,32 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+func TestOnChunkUnloadFiresOnCollectGarbage(t *testing.T) {
+	var unloaded []ChunkPos
+	w := newTestWorld(t, Config{
+		OnChunkUnload: func(pos ChunkPos) {
+			unloaded = append(unloaded, pos)
+		},
+	})
+
+	<-w.Exec(func(tx *Tx) {
+		// Reading a block loads the chunk without adding a viewer to it.
+		tx.Block(cube.Pos{20, 0, 4})
+		unloaded = unloaded[:0]
+
+		if n, _, _ := w.CollectGarbage(tx); n == 0 {
+			t.Fatalf("expected at least one chunk to be collected")
+		}
+		for _, pos := range unloaded {
+			if pos == (ChunkPos{1, 0}) {
+				return
+			}
+		}
+		t.Fatalf("expected OnChunkUnload to be called for %v, got %v", ChunkPos{1, 0}, unloaded)
+	})
+}
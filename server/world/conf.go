@@ -50,8 +50,15 @@ type Config struct {
 	// sustained heavy load you may want to raise the queue size together with
 	// GeneratorWorkers to avoid backpressure warnings.
 	GeneratorQueueSize int
+	// ResumeGeneration specifies if the generation of chunks that was
+	// requested but not completed when the World was last closed should be
+	// resumed when the World is created. This requires the Provider to
+	// implement GenerationQueueProvider. Chunks are resumed in the background
+	// and unloaded again once generated if no Loader uses them.
+	ResumeGeneration bool
 	// ReadOnly specifies if the World should be read-only, meaning no new data
-	// will be written to the Provider.
+	// will be written to the Provider. It may be changed later using
+	// World.SetReadOnly.
 	ReadOnly bool
 	// SaveInterval specifies how often a World should be automatically saved to
 	// disk. This includes chunks, entities and level.dat data. If ReadOnly is
@@ -59,12 +66,33 @@ type Config struct {
 	// By default, SaveInterval is set to 10 minutes. Setting SaveInterval to
 	// a negative number disables automatic saving entirely.
 	SaveInterval time.Duration
+	// MaxChunkSavesPerTick limits how many chunks are written to the Provider
+	// per tick during automatic saves. If set to a value above 0, automatic
+	// saves queue all modified chunks and flush them incrementally over the
+	// following ticks instead of writing them all at once, smoothing out disk
+	// I/O. Explicit calls to World.Save still write all chunks immediately.
+	// By default, MaxChunkSavesPerTick is 0 and automatic saves are not
+	// throttled.
+	MaxChunkSavesPerTick int
+	// MaxNeighbourUpdatesPerTick limits how many neighbour updates, which are
+	// caused by blocks changing next to a block, are performed per tick. If
+	// set to a value above 0, updates beyond the limit are postponed to the
+	// next tick, preventing runaway update chains from stalling a tick. By
+	// default, MaxNeighbourUpdatesPerTick is 0 and all neighbour updates
+	// queued are performed every tick.
+	MaxNeighbourUpdatesPerTick int
 	// RandomTickSpeed specifies the rate at which blocks should be ticked in
 	// the World. By default, each sub chunk has 3 blocks randomly ticked per
 	// sub chunk, so the default value is 3. Setting this value to -1 or lower
 	// will stop random ticking altogether, while setting it higher results in
 	// faster ticking.
 	RandomTickSpeed int
+	// LiquidTickMultiplier scales the delay of block updates scheduled by
+	// liquids, which drive the speed at which liquids flow. A value of 2
+	// makes liquids flow half as fast, while a value of 0.5 makes them flow
+	// twice as fast. Delays are never shorter than a single tick. Values of 0
+	// or lower default to 1, leaving the flow speed unchanged.
+	LiquidTickMultiplier float64
 	// RandSource is the rand.Source used for generation of random numbers in a
 	// World, such as when selecting blocks to tick or when deciding where to
 	// strike lightning. If set to nil, RandSource defaults to a `rand.PCG`
@@ -76,8 +104,165 @@ type Config struct {
 	// Entities is an EntityRegistry with all Entity types registered that may
 	// be added to the World.
 	Entities EntityRegistry
+	// ItemPickupDelay is the default time that must pass before an item entity
+	// dropped in the World can be picked up, used for items that were not
+	// created with a specific pickup delay. If set to 0, ItemPickupDelay
+	// defaults to half a second. A negative value allows items to be picked up
+	// immediately.
+	ItemPickupDelay time.Duration
+	// ValidateBlockEntities specifies if block entities should be checked for
+	// a clean NBT round-trip every time a chunk is saved. Block entities that
+	// would be dropped or changed when the chunk is loaded again are logged as
+	// errors. This is a debugging aid for custom blocks and costs an extra
+	// encode and decode per block entity, so it is disabled by default.
+	ValidateBlockEntities bool
+	// ChunkCacheSize is the number of recently unloaded chunks kept in memory,
+	// so that they are not read from the Provider or generated again if they
+	// are loaded again shortly after being unloaded. Only chunks of which all
+	// changes were saved are kept. ChunkCacheSize is 0 by default, which
+	// disables the cache.
+	ChunkCacheSize int
+	// ChunkLoadErrorPolicy specifies what happens if the Provider returns an
+	// error other than leveldb.ErrNotFound when loading a chunk. By default,
+	// ChunkLoadErrorSubstitute is used and an empty chunk is used in its
+	// place.
+	ChunkLoadErrorPolicy ChunkLoadErrorPolicy
+	// ChunkLoadRetries is the number of times loading a chunk is retried if
+	// ChunkLoadErrorPolicy is ChunkLoadErrorRetry. If 0, 3 retries are made.
+	ChunkLoadRetries int
+	// ChunkLoadRetryDelay is the delay before the first retry of loading a
+	// chunk if ChunkLoadErrorPolicy is ChunkLoadErrorRetry. The delay doubles
+	// with every following retry. If 0, a delay of 50ms is used.
+	ChunkLoadRetryDelay time.Duration
+	// OnChunkUnload is called with the position of a chunk right before it is
+	// unloaded from the World, after it has been saved. It is called on the
+	// tick goroutine of the World, both when unused chunks are collected and
+	// when the World is closed, and must therefore not block. If nil, nothing
+	// is called.
+	OnChunkUnload func(pos ChunkPos)
+	// ChunkEntityLimit is the number of entities a single chunk may hold
+	// before it is considered overcrowded. A warning is logged at most once a
+	// minute for overcrowded chunks, and OnChunkEntityLimit is called. By
+	// default, ChunkEntityLimit is 1000. A negative value disables the check.
+	ChunkEntityLimit int
+	// OnChunkEntityLimit, if non-nil, is called every tick for every chunk
+	// holding more entities than ChunkEntityLimit, with the handles of the
+	// entities in the chunk. It may be used to merge or remove entities, such
+	// as the oldest items, to keep the World from lagging.
+	OnChunkEntityLimit func(tx *Tx, pos ChunkPos, entities []*EntityHandle)
+	// OnGenerationFailure, if non-nil, is called when the Generator panics
+	// while generating a chunk, with the position of the chunk, the number of
+	// times generating it failed so far and the error recovered. The chunk is
+	// left empty. OnGenerationFailure is called on a generator goroutine,
+	// outside of any transaction, and must therefore not block.
+	OnGenerationFailure func(pos ChunkPos, failures int, err error)
+	// VoidHandler, if non-nil, is called every tick for every ticking entity
+	// that has fallen into the void, with the block Y the entity is at. It may
+	// be used to teleport, damage or kill the entity. If set, entities no
+	// longer apply their default void behaviour, such as taking void damage.
+	VoidHandler func(tx *Tx, e Entity, y int)
+	// VoidMargin is the number of blocks below the minimum Y of the Range of
+	// the dimension that an entity must fall before it is considered to be
+	// in the void. VoidMargin is 0 by default.
+	VoidMargin int
+	// BlockActivationShape specifies the shape of the area around loaders in
+	// which blocks are randomly ticked and block entities are ticked, as also
+	// reported by Tx.IsSimulated. It does not affect entities, which are
+	// ticked in all chunks that have a viewer. By default, ActivationCylinder
+	// is used, which ignores the height of the loader.
+	BlockActivationShape ActivationShape
+	// MaxChunkRadius is the maximum chunk radius of the Loaders in the World.
+	// Loaders with a larger radius only load and simulate chunks within
+	// MaxChunkRadius. If set to 0 or lower, the radius of Loaders is not
+	// limited.
+	MaxChunkRadius int
+	// SkipLighting specifies if light calculation should be skipped for the
+	// chunks of the World. If set to true, all chunks are given full skylight
+	// and no block light, which speeds up chunk loading for flat or void
+	// worlds where light does not need to be accurate. SkipLighting is false
+	// by default.
+	SkipLighting bool
+	// OnTPSWarning, if non-nil, is called with the measured TPS when the TPS
+	// of the World drops below 95% of the target TPS, at the same time a
+	// warning is logged. OnTPSRecover is called with the measured TPS once it
+	// rises above that threshold again. Both functions are called from the
+	// tick loop of the World, so they must return quickly or hand off their
+	// work to another goroutine.
+	OnTPSWarning, OnTPSRecover func(tps float64)
+	// DifficultyScaler, if non-nil, is called for every ScalableEntity added
+	// to the World with the current Difficulty and the base attributes of the
+	// entity. The EntityAttributes returned are applied to the entity. If
+	// nil, the base attributes of entities are left unchanged.
+	DifficultyScaler func(d Difficulty, base EntityAttributes) EntityAttributes
+	// EntityRemap, if non-nil, is called with the identifier of an entity
+	// read from the Provider if no EntityType is registered for it in
+	// Entities. If it returns true, the entity is loaded using the EntityType
+	// registered for the identifier returned instead. Entities that cannot be
+	// remapped are dropped. EntityRemap may be used to keep the data of
+	// entities of which the type was renamed.
+	EntityRemap func(identifier string) (string, bool)
+	// SpawnProtectionRadius is the radius around the spawn position of the
+	// World in which entities may not break or place blocks, unless
+	// SpawnProtectionBypass returns true for them. Like in vanilla, the area
+	// protected is a square extending SpawnProtectionRadius blocks from the
+	// spawn in both horizontal directions. The radius may be changed later
+	// using World.SetSpawnProtectionRadius. SpawnProtectionRadius is 0 by
+	// default, which disables spawn protection.
+	SpawnProtectionRadius int
+	// SpawnProtectionBypass is called to check if an entity, typically an
+	// operator, may break and place blocks in the spawn protection area. If
+	// nil, no entity may do so.
+	SpawnProtectionBypass func(e Entity) bool
+	// OnSave is called with the SaveResult every time the World was saved,
+	// both by automatic saves and calls to World.Save and
+	// World.SaveWithResult, for example to back up the files of the Provider
+	// while they are consistent. OnSave is called on the goroutine that
+	// performed the save: The goroutine calling Save, or the goroutine of
+	// the World running automatic saves. For incremental saves, as done if
+	// MaxChunkSavesPerTick is set, OnSave is called on a new goroutine once
+	// the last chunk was written. OnSave is not called when the World is
+	// closed.
+	OnSave func(res SaveResult)
+	// IdleTickMode specifies how the World is ticked while nobody is viewing
+	// it. By default, IdleTickFull is used.
+	IdleTickMode IdleTickMode
+	// Seed is the seed used by the Generator of the World, if it does not
+	// implement SeededGenerator. If non-zero, it is stored in the Settings of
+	// the World, replacing the seed loaded from the Provider, and is returned
+	// by World.Seed. The seed of a SeededGenerator always takes precedence.
+	Seed int64
 }
 
+// ActivationShape is the shape of the area around a loader in which blocks are
+// simulated, as set using Config.BlockActivationShape. The radius of the area
+// is the simulation distance of the World.
+type ActivationShape uint8
+
+const (
+	// ActivationCylinder activates all sub chunks of the chunk columns within
+	// the simulation distance of a loader, regardless of their height.
+	ActivationCylinder ActivationShape = iota
+	// ActivationSphere activates only the sub chunks within the simulation
+	// distance of a loader, taking into account the height of the loader.
+	ActivationSphere
+)
+
+// IdleTickMode specifies how a World is ticked while it is idle.
+type IdleTickMode uint8
+
+const (
+	// IdleTickFull ticks the World fully as long as any Loader is present in
+	// it, regardless of its Viewer. The World only stops ticking once all
+	// loaders are closed.
+	IdleTickFull IdleTickMode = iota
+	// IdleTickSuspend suspends ticking the World while no Loader with a
+	// Viewer other than NopViewer is present in it, such as when only loaders
+	// keeping chunks loaded are left. Entities, blocks and the time of the
+	// World are not ticked while suspended. Only the current tick advances,
+	// so that scheduled updates still run once ticking resumes.
+	IdleTickSuspend
+)
+
 // New creates a new World using the Config conf. The World returned will start
 // ticking as soon as a viewer is added to it and is otherwise ready for use.
 func (conf Config) New() *World {
@@ -99,6 +284,12 @@ func (conf Config) New() *World {
 	if conf.GeneratorWorkers <= 0 {
 		conf.GeneratorWorkers = runtime.NumCPU()
 	}
+	if conf.ChunkLoadRetries <= 0 {
+		conf.ChunkLoadRetries = 3
+	}
+	if conf.ChunkLoadRetryDelay <= 0 {
+		conf.ChunkLoadRetryDelay = time.Millisecond * 50
+	}
 	if conf.GeneratorWorkers <= 0 {
 		conf.GeneratorWorkers = 1
 	}
@@ -108,11 +299,29 @@ func (conf Config) New() *World {
 	if conf.RandomTickSpeed == 0 {
 		conf.RandomTickSpeed = 3
 	}
+	if !(conf.LiquidTickMultiplier > 0) {
+		conf.LiquidTickMultiplier = 1
+	}
+	if conf.ChunkEntityLimit == 0 {
+		conf.ChunkEntityLimit = 1000
+	}
+	if conf.ItemPickupDelay == 0 {
+		conf.ItemPickupDelay = time.Second / 2
+	}
 	if conf.RandSource == nil {
 		t := uint64(time.Now().UnixNano())
 		conf.RandSource = rand.NewPCG(t, t)
 	}
 	s := conf.Provider.Settings()
+	if g, ok := conf.Generator.(SeededGenerator); ok {
+		s.Lock()
+		s.Seed = g.Seed()
+		s.Unlock()
+	} else if conf.Seed != 0 {
+		s.Lock()
+		s.Seed = conf.Seed
+		s.Unlock()
+	}
 	w := &World{
 		scheduledUpdates:    newScheduledTickQueue(s.CurrentTick),
 		entities:            make(map[*EntityHandle]*entityState),
@@ -131,16 +340,25 @@ func (conf Config) New() *World {
 		entityColumnIndex:   make(map[ChunkPos]int),
 		scratchActiveRefs:   make(map[*EntityHandle]entityChunkRef),
 		scratchSleepingRefs: make(map[*EntityHandle]entityChunkRef),
+		tickIntervalChanged: make(chan struct{}, 1),
+		pendingGen:          make(map[ChunkPos]struct{}),
+		chunkCache:          newChunkCache(conf.ChunkCacheSize),
 	}
 	w.weather = weather{w: w}
 	var h Handler = NopHandler{}
 	w.handler.Store(&h)
+	w.generator.Store(&conf.Generator)
 	w.tps.Store(math.Float64bits(20))
+	w.tickInterval.Store(int64(time.Second / 20))
+	w.readOnly.Store(conf.ReadOnly)
+	w.spawnProtectionRadius.Store(int64(max(conf.SpawnProtectionRadius, 0)))
 
 	w.queueing.Add(1)
 	w.running.Add(conf.GeneratorWorkers + 2)
 
-	t := ticker{interval: time.Second / 20}
+	w.loadStructures()
+
+	t := ticker{}
 	go t.tickLoop(w)
 	go w.autoSave()
 	for i := 0; i < conf.GeneratorWorkers; i++ {
@@ -149,5 +367,8 @@ func (conf Config) New() *World {
 	go w.handleTransactions()
 
 	<-w.Exec(t.tick)
+	if conf.ResumeGeneration {
+		w.resumeGeneration()
+	}
 	return w
 }
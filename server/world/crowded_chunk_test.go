@@ -0,0 +1,57 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestChunkEntityLimit(t *testing.T) {
+	crowded := make(chan ChunkPos, 16)
+	w := newTestWorld(t, Config{
+		ChunkEntityLimit: 5,
+		OnChunkEntityLimit: func(tx *Tx, pos ChunkPos, entities []*EntityHandle) {
+			// Remove entities until the chunk is no longer crowded.
+			for _, handle := range entities[5:] {
+				e, _ := handle.Entity(tx)
+				tx.RemoveEntity(e)
+			}
+			crowded <- pos
+		},
+	})
+	loader := NewLoader(2, w, nopViewer{})
+	t.Cleanup(func() {
+		<-w.Exec(loader.Close)
+	})
+
+	<-w.Exec(func(tx *Tx) {
+		for i := range 5 {
+			tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{20, 64, float64(i)}}.New(testEntityType{}, testEntityConfig{}))
+		}
+		for i := range 8 {
+			tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{4, 64, float64(i)}}.New(testEntityType{}, testEntityConfig{}))
+		}
+		loader.Move(tx, mgl64.Vec3{})
+		loader.Load(tx, 25)
+	})
+
+	select {
+	case pos := <-crowded:
+		if pos != (ChunkPos{}) {
+			t.Fatalf("expected chunk %v to be crowded, got %v", ChunkPos{}, pos)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected crowded chunk to be reported")
+	}
+	<-w.Exec(func(tx *Tx) {
+		if n := len(w.chunks[ChunkPos{}].Entities); n != 5 {
+			t.Fatalf("expected 5 entities left in the chunk, got %v", n)
+		}
+	})
+	select {
+	case pos := <-crowded:
+		t.Fatalf("expected no further crowded chunks, got %v", pos)
+	default:
+	}
+}
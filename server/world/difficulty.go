@@ -53,6 +53,25 @@ func DifficultyID(diff Difficulty) (int, bool) {
 	return difficultyReg.LookupID(diff)
 }
 
+// EntityAttributes holds attributes of a mob that may be scaled depending on
+// the Difficulty of a World using Config.DifficultyScaler.
+type EntityAttributes struct {
+	// MaxHealth is the maximum health of the mob.
+	MaxHealth float64
+	// AttackDamage is the damage the mob deals to other entities.
+	AttackDamage float64
+}
+
+// ScalableEntity is an Entity of which the EntityAttributes may be scaled by
+// Config.DifficultyScaler when it is added to a World.
+type ScalableEntity interface {
+	Entity
+	// BaseAttributes returns the unscaled EntityAttributes of the entity.
+	BaseAttributes() EntityAttributes
+	// ApplyAttributes applies the EntityAttributes passed to the entity.
+	ApplyAttributes(a EntityAttributes)
+}
+
 type difficultyRegistry struct {
 	difficulties map[int]Difficulty
 	ids          map[Difficulty]int
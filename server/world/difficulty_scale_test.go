@@ -0,0 +1,52 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// scalableEntityType is an EntityType of which the entities implement
+// ScalableEntity.
+type scalableEntityType struct{ testEntityType }
+
+func (scalableEntityType) Open(_ *Tx, handle *EntityHandle, data *EntityData) Entity {
+	return &scalableEntity{testEntity: testEntity{handle: handle, data: data}}
+}
+
+type scalableEntity struct {
+	testEntity
+	attributes EntityAttributes
+}
+
+func (*scalableEntity) BaseAttributes() EntityAttributes {
+	return EntityAttributes{MaxHealth: 20, AttackDamage: 3}
+}
+func (e *scalableEntity) ApplyAttributes(a EntityAttributes) { e.attributes = a }
+
+func TestDifficultyScaler(t *testing.T) {
+	w := newTestWorld(t, Config{
+		DifficultyScaler: func(d Difficulty, base EntityAttributes) EntityAttributes {
+			id, _ := DifficultyID(d)
+			base.MaxHealth *= 1 + float64(id)/2
+			base.AttackDamage *= float64(id)
+			return base
+		},
+	})
+
+	spawn := func(d Difficulty) (a EntityAttributes) {
+		w.SetDifficulty(d)
+		<-w.Exec(func(tx *Tx) {
+			e := tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{4, 64, 4}}.New(scalableEntityType{}, testEntityConfig{}))
+			a = e.(*scalableEntity).attributes
+		})
+		return a
+	}
+	peaceful, hard := spawn(DifficultyPeaceful), spawn(DifficultyHard)
+	if peaceful != (EntityAttributes{MaxHealth: 20, AttackDamage: 0}) {
+		t.Fatalf("unexpected peaceful attributes %+v", peaceful)
+	}
+	if hard != (EntityAttributes{MaxHealth: 50, AttackDamage: 9}) {
+		t.Fatalf("unexpected hard attributes %+v", hard)
+	}
+}
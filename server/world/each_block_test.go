@@ -0,0 +1,65 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestEachBlockInChunk(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	stoneRID, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	waterRID, ok := chunk.StateToRuntimeID("minecraft:water", map[string]any{"liquid_depth": int32(0)})
+	if !ok {
+		t.Fatalf("block state minecraft:water not registered")
+	}
+	stone, _ := BlockByRuntimeID(stoneRID)
+
+	loader := NewLoader(1, w, NopViewer{})
+	<-w.Exec(func(tx *Tx) { loader.Move(tx, mgl64.Vec3{}) })
+	waitChunkLoaded(t, w, loader, ChunkPos{1, 0})
+
+	type visit struct {
+		pos cube.Pos
+		rid uint32
+	}
+	var visited []visit
+	var stopped int
+	<-w.Exec(func(tx *Tx) {
+		a, b := cube.Pos{16, 10, 0}, cube.Pos{31, 100, 15}
+		tx.SetBlock(a, stone, nil)
+		tx.SetBlock(b, stone, nil)
+		w.chunk(ChunkPos{1, 0}).SetBlock(0, 10, 0, 1, waterRID)
+
+		tx.EachBlockInChunk(ChunkPos{1, 0}, func(pos cube.Pos, rid uint32) bool {
+			visited = append(visited, visit{pos: pos, rid: rid})
+			return true
+		})
+		tx.EachBlockInChunk(ChunkPos{1, 0}, func(cube.Pos, uint32) bool {
+			stopped++
+			return false
+		})
+	})
+
+	want := map[visit]bool{
+		{pos: cube.Pos{16, 10, 0}, rid: stoneRID}:   true,
+		{pos: cube.Pos{31, 100, 15}, rid: stoneRID}: true,
+		{pos: cube.Pos{16, 10, 0}, rid: waterRID}:   true,
+	}
+	if len(visited) != len(want) {
+		t.Fatalf("expected %v non-air blocks to be visited on all layers, got %v", len(want), visited)
+	}
+	for _, v := range visited {
+		if !want[v] {
+			t.Fatalf("unexpected block visited: %v", v)
+		}
+	}
+	if stopped != 1 {
+		t.Fatalf("expected iteration to stop after fn returned false, got %v calls", stopped)
+	}
+}
@@ -136,6 +136,12 @@ func (e *EntityHandle) UUID() uuid.UUID {
 	return e.id
 }
 
+// persistentID returns the ID under which the entity is stored in chunk data.
+// It is derived from the last 8 bytes of the UUID of the EntityHandle.
+func (e *EntityHandle) persistentID() int64 {
+	return int64(binary.LittleEndian.Uint64(e.id[8:]))
+}
+
 // Close closes the EntityHandle. Any subsequent call to ExecWorld will return
 // immediately without the transaction function being called. Close always
 // returns nil.
@@ -270,10 +276,12 @@ func (e *EntityHandle) decodeNBT(m map[string]any) {
 	e.data.Age = time.Duration(readInt16(m, "Age")) * (time.Second / 20)
 	e.data.FireDuration = time.Duration(readInt16(m, "Fire")) * time.Second / 20
 	e.data.Name, _ = m["NameTag"].(string)
+	noAI, _ := m["NoAI"].(uint8)
+	e.data.NoTick = noAI == 1
 }
 
-// encodeNBT encodes the position, velocity, rotation, age, on-fire duration and
-// name tag of an entity.
+// encodeNBT encodes the position, velocity, rotation, age, on-fire duration,
+// name tag and frozen state of an entity.
 func (e *EntityHandle) encodeNBT() map[string]any {
 	return map[string]any{
 		"Pos":     []float32{float32(e.data.Pos[0]), float32(e.data.Pos[1]), float32(e.data.Pos[2])},
@@ -283,6 +291,7 @@ func (e *EntityHandle) encodeNBT() map[string]any {
 		"Fire":    int16(e.data.FireDuration.Seconds() * 20),
 		"Age":     int16(e.data.Age / (time.Second * 20)),
 		"NameTag": e.data.Name,
+		"NoAI":    boolByte(e.data.NoTick),
 	}
 }
 
@@ -293,6 +302,10 @@ type EntityData struct {
 	Name         string
 	FireDuration time.Duration
 	Age          time.Duration
+	// NoTick freezes the entity: If set to true, the Tick method of the entity
+	// is no longer called, although its age and fire duration still advance
+	// and it remains visible to viewers.
+	NoTick bool
 
 	Data any
 }
@@ -319,6 +332,41 @@ type TickerEntity interface {
 	Tick(tx *Tx, current int64)
 }
 
+// PassiveMaintenanceExempt may be implemented by an EntityType to opt its
+// entities out of passive maintenance. Entities that are not ticked because no
+// viewer is near them normally have their age and fire duration advanced
+// periodically, and so do entities that were not ticked for multiple ticks
+// once they are ticked again. The age and fire duration of entities of which
+// the type is exempt are left unchanged in these cases. They are still moved
+// between chunks as usual.
+type PassiveMaintenanceExempt interface {
+	EntityType
+	// ExemptFromPassiveMaintenance returns true if entities of the type should
+	// be exempt from passive maintenance.
+	ExemptFromPassiveMaintenance() bool
+}
+
+// VelocityEntity represents an Entity that has a velocity, which may be
+// changed using Tx.SetVelocity and Tx.ApplyImpulse.
+type VelocityEntity interface {
+	Entity
+	// Velocity returns the current velocity of the Entity in blocks/tick.
+	Velocity() mgl64.Vec3
+	// SetVelocity sets the velocity of the Entity in blocks/tick.
+	SetVelocity(v mgl64.Vec3)
+}
+
+// VelocityBroadcaster is a VelocityEntity that may show its new velocity to
+// its viewers itself when SetVelocity is called, such as a player.
+// Tx.SetVelocity only shows the velocity to viewers if BroadcastsVelocity
+// returns false.
+type VelocityBroadcaster interface {
+	VelocityEntity
+	// BroadcastsVelocity reports if SetVelocity shows the new velocity to all
+	// viewers of the Entity.
+	BroadcastsVelocity() bool
+}
+
 // EntityAction represents an action that may be performed by an Entity. Typically, these actions are sent to
 // viewers in a world so that they can see these actions.
 type EntityAction interface {
@@ -453,3 +501,11 @@ func readInt16(m map[string]any, k string) int16 {
 	v, _ := m[k].(int16)
 	return v
 }
+
+// boolByte returns 1 if the bool passed is true, or 0 if it is false.
+func boolByte(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,72 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// tickingEntityType is an EntityType of which the entities count how often
+// they are ticked.
+type tickingEntityType struct {
+	testEntityType
+	ticks map[*EntityHandle]int
+}
+
+func (t tickingEntityType) Open(_ *Tx, handle *EntityHandle, data *EntityData) Entity {
+	return &tickingEntity{testEntity: testEntity{handle: handle, data: data}, ticks: t.ticks}
+}
+
+type tickingEntity struct {
+	testEntity
+	ticks map[*EntityHandle]int
+}
+
+func (e *tickingEntity) Tick(*Tx, int64) { e.ticks[e.handle]++ }
+
+// frozenConfig is an EntityConfig that sets EntityData.NoTick.
+type frozenConfig struct{}
+
+func (frozenConfig) Apply(data *EntityData) { data.NoTick = true }
+
+func TestFrozenEntityNotTicked(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	loader := NewLoader(2, w, nopViewer{})
+	t.Cleanup(func() {
+		<-w.Exec(loader.Close)
+	})
+
+	typ := tickingEntityType{ticks: map[*EntityHandle]int{}}
+	var frozen, unfrozen *EntityHandle
+	<-w.Exec(func(tx *Tx) {
+		frozen = tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{4, 64, 4}}.New(typ, frozenConfig{})).H()
+		unfrozen = tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{8, 64, 8}}.New(typ, testEntityConfig{})).H()
+		loader.Move(tx, mgl64.Vec3{})
+		loader.Load(tx, 25)
+	})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var done bool
+		<-w.Exec(func(tx *Tx) {
+			done = typ.ticks[unfrozen] >= 5
+		})
+		if done {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("unfrozen entity was never ticked")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	<-w.Exec(func(tx *Tx) {
+		if n := typ.ticks[frozen]; n != 0 {
+			t.Fatalf("expected frozen entity not to be ticked, got %v ticks", n)
+		}
+		if frozen.data.Age == 0 {
+			t.Fatalf("expected frozen entity to keep ageing")
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// testEntityType is a minimal EntityType used to spawn entities in tests.
+type testEntityType struct{}
+
+func (testEntityType) Open(_ *Tx, handle *EntityHandle, data *EntityData) Entity {
+	return &testEntity{handle: handle, data: data}
+}
+func (testEntityType) EncodeEntity() string                  { return "minecraft:test" }
+func (testEntityType) BBox(Entity) cube.BBox                 { return cube.BBox{} }
+func (testEntityType) DecodeNBT(map[string]any, *EntityData) {}
+func (testEntityType) EncodeNBT(*EntityData) map[string]any  { return nil }
+
+// testEntityConfig is an EntityConfig that leaves the EntityData unchanged.
+type testEntityConfig struct{}
+
+func (testEntityConfig) Apply(*EntityData) {}
+
+type testEntity struct {
+	handle *EntityHandle
+	data   *EntityData
+}
+
+func (e *testEntity) Close() error            { return nil }
+func (e *testEntity) H() *EntityHandle        { return e.handle }
+func (e *testEntity) Position() mgl64.Vec3    { return e.data.Pos }
+func (e *testEntity) Rotation() cube.Rotation { return e.data.Rot }
+
+// entityViewer is a Viewer that records the entities currently shown to it.
+type entityViewer struct {
+	NopViewer
+	shown map[*EntityHandle]bool
+}
+
+func (v *entityViewer) ViewEntity(e Entity) { v.shown[e.H()] = true }
+func (v *entityViewer) HideEntity(e Entity) { delete(v.shown, e.H()) }
+
+func TestHiddenEntityNotShownOnChunkLoad(t *testing.T) {
+	w := newTestWorld(t, Config{})
+
+	hiddenFrom := &entityViewer{shown: map[*EntityHandle]bool{}}
+	other := &entityViewer{shown: map[*EntityHandle]bool{}}
+	hiddenLoader, otherLoader := NewLoader(1, w, hiddenFrom), NewLoader(1, w, other)
+
+	var e Entity
+	<-w.Exec(func(tx *Tx) {
+		e = tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{8, 64, 8}}.New(testEntityType{}, testEntityConfig{}))
+		tx.HideEntityFrom(e, hiddenFrom)
+		hiddenLoader.Move(tx, mgl64.Vec3{})
+		otherLoader.Move(tx, mgl64.Vec3{})
+	})
+
+	waitChunkLoaded(t, w, hiddenLoader, ChunkPos{})
+	waitChunkLoaded(t, w, otherLoader, ChunkPos{})
+
+	<-w.Exec(func(tx *Tx) {
+		if hiddenFrom.shown[e.H()] {
+			t.Fatalf("expected hidden entity not to be shown to suppressed viewer")
+		}
+		if !other.shown[e.H()] {
+			t.Fatalf("expected entity to be shown to other viewer")
+		}
+		tx.ShowEntityTo(e, hiddenFrom)
+		if !hiddenFrom.shown[e.H()] {
+			t.Fatalf("expected entity to be shown again after unhiding")
+		}
+	})
+}
+
+// hidingViewer is an EntityHidingViewer that records the entities marked as
+// hidden from it.
+type hidingViewer struct {
+	entityViewer
+	hidden map[*EntityHandle]bool
+}
+
+func (v *hidingViewer) SetEntityHidden(h *EntityHandle, hidden bool) {
+	if hidden {
+		v.hidden[h] = true
+		return
+	}
+	delete(v.hidden, h)
+}
+
+func TestHideEntityFromHidingViewer(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	v := &hidingViewer{entityViewer: entityViewer{shown: map[*EntityHandle]bool{}}, hidden: map[*EntityHandle]bool{}}
+
+	<-w.Exec(func(tx *Tx) {
+		e := tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{8, 64, 8}}.New(testEntityType{}, testEntityConfig{}))
+		tx.HideEntityFrom(e, v)
+		if !v.hidden[e.H()] {
+			t.Fatalf("expected viewer to be marked to ignore updates of the hidden entity")
+		}
+		tx.ShowEntityTo(e, v)
+		if v.hidden[e.H()] {
+			t.Fatalf("expected viewer to be unmarked after showing the entity again")
+		}
+
+		// The mark is also removed when the entity leaves the World.
+		tx.HideEntityFrom(e, v)
+		tx.RemoveEntity(e)
+		if v.hidden[e.H()] {
+			t.Fatalf("expected viewer to be unmarked after the entity was removed")
+		}
+	})
+}
@@ -0,0 +1,29 @@
+package world
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+)
+
+func TestEntityByID(t *testing.T) {
+	w := newTestWorld(t, Config{})
+
+	id := uuid.New()
+	<-w.Exec(func(tx *Tx) {
+		e := tx.AddEntity(EntitySpawnOpts{ID: id, Position: mgl64.Vec3{8, 64, 8}}.New(testEntityType{}, testEntityConfig{}))
+
+		found, ok := tx.EntityByID(int64(binary.LittleEndian.Uint64(id[8:])))
+		if !ok {
+			t.Fatalf("expected entity to be found by its derived ID")
+		}
+		if found.H() != e.H() {
+			t.Fatalf("expected entity %v, got %v", e.H().UUID(), found.H().UUID())
+		}
+		if _, ok := tx.EntityByID(int64(binary.LittleEndian.Uint64(id[8:])) + 1); ok {
+			t.Fatalf("expected no entity to be found for an unknown ID")
+		}
+	})
+}
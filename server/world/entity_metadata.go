@@ -0,0 +1,71 @@
+package world
+
+import "time"
+
+// EntityName returns the name tag of an Entity. An empty string is returned if
+// the Entity has no name tag or does not support name tags.
+func (tx *Tx) EntityName(e Entity) string {
+	if n, ok := e.(interface{ NameTag() string }); ok {
+		return n.NameTag()
+	}
+	return ""
+}
+
+// SetEntityName changes the name tag of an Entity, showing the change to all
+// viewers of the Entity. The name tag is removed if an empty string is passed.
+// False is returned if the Entity does not support name tags.
+func (tx *Tx) SetEntityName(e Entity, name string) bool {
+	n, ok := e.(interface{ SetNameTag(name string) })
+	if ok {
+		n.SetNameTag(name)
+	}
+	return ok
+}
+
+// EntityMetadata returns a snapshot of the generic properties of an Entity,
+// keyed by name. Only the properties supported by the Entity are present:
+//
+//   - "name" (string): The name tag of the Entity.
+//   - "score_tag" (string): The score tag shown below the name tag.
+//   - "health", "max_health" (float64): The (maximum) health of the Entity.
+//   - "scale" (float64): The scale of the Entity.
+//   - "invisible", "immobile", "sneaking", "sprinting" (bool)
+//   - "on_fire" (time.Duration): The time for which the Entity is on fire.
+//
+// Changing the map returned has no effect on the Entity. Use the methods of
+// the Entity, or SetEntityName, to change its properties, which shows the
+// changes to its viewers.
+func (tx *Tx) EntityMetadata(e Entity) map[string]any {
+	m := make(map[string]any)
+	if v, ok := e.(interface{ NameTag() string }); ok {
+		m["name"] = v.NameTag()
+	}
+	if v, ok := e.(interface{ ScoreTag() string }); ok {
+		m["score_tag"] = v.ScoreTag()
+	}
+	if v, ok := e.(interface{ Health() float64 }); ok {
+		m["health"] = v.Health()
+	}
+	if v, ok := e.(interface{ MaxHealth() float64 }); ok {
+		m["max_health"] = v.MaxHealth()
+	}
+	if v, ok := e.(interface{ Scale() float64 }); ok {
+		m["scale"] = v.Scale()
+	}
+	if v, ok := e.(interface{ Invisible() bool }); ok {
+		m["invisible"] = v.Invisible()
+	}
+	if v, ok := e.(interface{ Immobile() bool }); ok {
+		m["immobile"] = v.Immobile()
+	}
+	if v, ok := e.(interface{ Sneaking() bool }); ok {
+		m["sneaking"] = v.Sneaking()
+	}
+	if v, ok := e.(interface{ Sprinting() bool }); ok {
+		m["sprinting"] = v.Sprinting()
+	}
+	if v, ok := e.(interface{ OnFireDuration() time.Duration }); ok {
+		m["on_fire"] = v.OnFireDuration()
+	}
+	return m
+}
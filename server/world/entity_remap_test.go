@@ -0,0 +1,41 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+func TestEntityRemap(t *testing.T) {
+	w := newTestWorld(t, Config{
+		Entities: EntityRegistryConfig{}.New([]EntityType{testEntityType{}}),
+		EntityRemap: func(identifier string) (string, bool) {
+			if identifier == "minecraft:old_test" {
+				return "minecraft:test", true
+			}
+			return "", false
+		},
+	})
+
+	data := func(identifier string) map[string]any {
+		return map[string]any{"identifier": identifier, "Pos": []any{float32(1), float32(64), float32(1)}}
+	}
+	<-w.Exec(func(tx *Tx) {
+		col := w.columnFrom(&chunk.Column{
+			Chunk: chunk.New(airRID, w.Range()),
+			Entities: []chunk.Entity{
+				{ID: 1, Data: data("minecraft:test")},
+				{ID: 2, Data: data("minecraft:old_test")},
+				{ID: 3, Data: data("minecraft:removed")},
+			},
+		}, ChunkPos{})
+		if len(col.Entities) != 2 {
+			t.Fatalf("expected 2 entities to be loaded, got %v", len(col.Entities))
+		}
+		for _, e := range col.Entities {
+			if name := e.Type().EncodeEntity(); name != "minecraft:test" {
+				t.Fatalf("expected entity of type minecraft:test, got %v", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// exportBlockEntity is a minimal block entity used to check block entity data
+// ends up in an exported column.
+type exportBlockEntity struct{ v int32 }
+
+func (exportBlockEntity) EncodeBlock() (string, map[string]any) { return "test:export", nil }
+func (exportBlockEntity) Hash() (uint64, uint64)                { return 0, 0 }
+func (exportBlockEntity) Model() BlockModel                     { return unknownModel{} }
+func (exportBlockEntity) DecodeNBT(map[string]any) any          { return exportBlockEntity{} }
+func (b exportBlockEntity) EncodeNBT() map[string]any {
+	return map[string]any{"id": "Export", "Value": b.v}
+}
+
+func TestExportColumn(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	stoneRID, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	stone, _ := BlockByRuntimeID(stoneRID)
+	dirtRID, ok := chunk.StateToRuntimeID("minecraft:dirt", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:dirt not registered")
+	}
+	dirt, _ := BlockByRuntimeID(dirtRID)
+
+	var col *chunk.Column
+	<-w.Exec(func(tx *Tx) {
+		if _, ok := tx.ExportColumn(ChunkPos{3, 3}); ok {
+			t.Fatalf("expected an unloaded chunk not to be exported")
+		}
+
+		tx.SetBlock(cube.Pos{1, 10, 1}, stone, nil)
+		tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{4, 64, 4}}.New(testEntityType{}, testEntityConfig{}))
+		w.chunk(ChunkPos{}).BlockEntities[cube.Pos{2, 10, 2}] = exportBlockEntity{v: 5}
+		tx.ScheduleBlockUpdate(cube.Pos{3, 20, 3}, stone, time.Second)
+
+		if col, ok = tx.ExportColumn(ChunkPos{}); !ok {
+			t.Fatalf("expected a loaded chunk to be exported")
+		}
+		// Changes made after exporting must not affect the exported column.
+		tx.SetBlock(cube.Pos{1, 10, 1}, dirt, nil)
+	})
+
+	if len(col.Entities) != 1 || col.Entities[0].Data["identifier"] != "minecraft:test" {
+		t.Fatalf("expected 1 minecraft:test entity, got %v", col.Entities)
+	}
+	if len(col.BlockEntities) != 1 || col.BlockEntities[0].Pos != (cube.Pos{2, 10, 2}) || col.BlockEntities[0].Data["Value"] != int32(5) {
+		t.Fatalf("expected block entity at (2, 10, 2) with value 5, got %v", col.BlockEntities)
+	}
+	if len(col.ScheduledBlocks) != 1 || col.ScheduledBlocks[0].Block != stoneRID {
+		t.Fatalf("expected 1 scheduled stone update, got %v", col.ScheduledBlocks)
+	}
+	if got := col.Chunk.Block(1, 10, 1, 0); got != stoneRID {
+		t.Fatalf("expected exported block to be stone (%v), got %v", stoneRID, got)
+	}
+}
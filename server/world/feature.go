@@ -0,0 +1,81 @@
+package world
+
+import (
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// GenerationFeature adds blocks, such as ore veins or structures, to a chunk
+// directly after it is generated by the Generator of a World. pos is the
+// position of the chunk and biomeAt returns the Biome at the highest block of
+// the column at the x and z passed, which are relative to the chunk (0-15).
+// GenerationFeatures are run on the generator workers of the World and must
+// therefore not access the World itself.
+type GenerationFeature func(pos ChunkPos, c *chunk.Chunk, biomeAt func(x, z int) Biome)
+
+// featureEntry is a GenerationFeature registered using AddGenerationFeature
+// together with the biome IDs it is restricted to.
+type featureEntry struct {
+	f      GenerationFeature
+	biomes map[uint32]struct{}
+}
+
+// matches checks if the featureEntry should run for the chunk passed. It
+// returns true if the entry is not restricted to any biome or if the biome at
+// the highest block of any column of the chunk is one of its biomes.
+func (e featureEntry) matches(c *chunk.Chunk) bool {
+	if len(e.biomes) == 0 {
+		return true
+	}
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			if _, ok := e.biomes[c.Biome(x, c.HighestBlock(x, z), z)]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AddGenerationFeature registers a GenerationFeature that is run for every
+// chunk generated from now on that contains at least one of the biomes
+// passed, determined by the biome at the highest block of each column. If no
+// biomes are passed, f is run for every chunk generated. Features are run in
+// the order they were added.
+func (w *World) AddGenerationFeature(f GenerationFeature, biomes ...Biome) {
+	e := featureEntry{f: f}
+	if len(biomes) > 0 {
+		e.biomes = make(map[uint32]struct{}, len(biomes))
+		for _, b := range biomes {
+			e.biomes[uint32(b.EncodeBiome())] = struct{}{}
+		}
+	}
+	w.featureMu.Lock()
+	defer w.featureMu.Unlock()
+	// The slice is replaced rather than appended to, so that workers holding
+	// the previous slice are not affected.
+	w.features = append(slices.Clip(w.features), e)
+}
+
+// runFeatures runs all GenerationFeatures matching the chunk passed.
+func (w *World) runFeatures(pos ChunkPos, c *chunk.Chunk) {
+	w.featureMu.RLock()
+	features := w.features
+	w.featureMu.RUnlock()
+	if len(features) == 0 {
+		return
+	}
+	biomeAt := func(x, z int) Biome {
+		id := int(c.Biome(uint8(x), c.HighestBlock(uint8(x), uint8(z)), uint8(z)))
+		if b, ok := BiomeByID(id); ok {
+			return b
+		}
+		return ocean()
+	}
+	for _, e := range features {
+		if e.matches(c) {
+			e.f(pos, c, biomeAt)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// biomeLayerGenerator is a Generator that places a layer of a block at y=0
+// and sets the biome of chunks with a non-negative X to east and of all other
+// chunks to west.
+type biomeLayerGenerator struct {
+	rid        uint32
+	east, west testBiome
+}
+
+func (g biomeLayerGenerator) GenerateChunk(pos ChunkPos, c *chunk.Chunk) {
+	b := g.west
+	if pos[0] >= 0 {
+		b = g.east
+	}
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			c.SetBlock(x, 0, z, 0, g.rid)
+			for y := int16(c.Range()[0]); y <= int16(c.Range()[1]); y++ {
+				c.SetBiome(x, y, z, uint32(b))
+			}
+		}
+	}
+}
+
+func TestGenerationFeature(t *testing.T) {
+	ridByName := func(name string) uint32 {
+		rid, ok := chunk.StateToRuntimeID(name, nil)
+		if !ok {
+			t.Fatalf("block state %v not registered", name)
+		}
+		return rid
+	}
+	stone, gold := ridByName("minecraft:stone"), ridByName("minecraft:gold_block")
+
+	w := newTestWorld(t, Config{Generator: biomeLayerGenerator{rid: stone, east: 7, west: 8}})
+	w.AddGenerationFeature(func(_ ChunkPos, c *chunk.Chunk, _ func(x, z int) Biome) {
+		c.SetBlock(8, 1, 8, 0, gold)
+	}, testBiome(7))
+
+	nameAt := func(tx *Tx, pos cube.Pos) string {
+		name, _ := tx.Block(pos).EncodeBlock()
+		return name
+	}
+	<-w.Exec(func(tx *Tx) {
+		if name := nameAt(tx, cube.Pos{8, 1, 8}); name != "minecraft:gold_block" {
+			t.Fatalf("expected feature to place gold in chunk of the target biome, got %v", name)
+		}
+		if name := nameAt(tx, cube.Pos{-8, 1, 8}); name != "minecraft:air" {
+			t.Fatalf("expected feature not to run in chunk of another biome, got %v", name)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// panicGenerator is a Generator that panics for every chunk.
+type panicGenerator struct{}
+
+func (panicGenerator) GenerateChunk(ChunkPos, *chunk.Chunk) { panic("faulty generator") }
+
+func TestGenerationFailureCallback(t *testing.T) {
+	type failure struct {
+		pos      ChunkPos
+		failures int
+	}
+	failures := make(chan failure, 16)
+	w := newTestWorld(t, Config{
+		Generator: panicGenerator{},
+		OnGenerationFailure: func(pos ChunkPos, n int, err error) {
+			failures <- failure{pos: pos, failures: n}
+		},
+	})
+
+	<-w.Exec(func(tx *Tx) {
+		tx.Block(cube.Pos{40, 0, -8})
+	})
+	select {
+	case f := <-failures:
+		if want := (ChunkPos{2, -1}); f.pos != want || f.failures != 1 {
+			t.Fatalf("expected first failure of chunk %v, got failure %v of chunk %v", want, f.failures, f.pos)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected generation failure callback to be called")
+	}
+	if n := w.GenerationFailures(ChunkPos{2, -1}); n != 1 {
+		t.Fatalf("expected 1 generation failure to be recorded, got %v", n)
+	}
+}
+
+func TestGenerationFailuresClearedOnSuccess(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	pos := ChunkPos{3, 4}
+
+	w.runGenerationTask(generationTask{pos: pos, col: newColumn(chunk.New(airRID, w.Range())), gen: panicGenerator{}})
+	if n := w.GenerationFailures(pos); n != 1 {
+		t.Fatalf("expected 1 generation failure to be recorded, got %v", n)
+	}
+	w.runGenerationTask(generationTask{pos: pos, col: newColumn(chunk.New(airRID, w.Range())), gen: NopGenerator{}})
+	if n := w.GenerationFailures(pos); n != 0 {
+		t.Fatalf("expected generation failures to be cleared after generating successfully, got %v", n)
+	}
+}
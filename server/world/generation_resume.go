@@ -0,0 +1,120 @@
+package world
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+)
+
+// GenerationQueueProvider is a Provider that is able to store the positions of
+// chunks of which the generation was requested but not completed when a World
+// was closed. If Config.ResumeGeneration is set, the World generates these
+// chunks again when it is created, so that long pre-generation runs may be
+// resumed after a restart.
+type GenerationQueueProvider interface {
+	Provider
+	// StorePendingGeneration stores the positions passed for the Dimension
+	// passed, replacing any positions stored earlier. An empty slice removes
+	// all positions stored.
+	StorePendingGeneration(dim Dimension, positions []ChunkPos) error
+	// LoadPendingGeneration loads the positions last stored using
+	// StorePendingGeneration for the Dimension passed.
+	LoadPendingGeneration(dim Dimension) ([]ChunkPos, error)
+}
+
+// addPendingGeneration marks the generation of the chunk at the position
+// passed as requested.
+func (w *World) addPendingGeneration(pos ChunkPos) {
+	w.pendingGenMu.Lock()
+	defer w.pendingGenMu.Unlock()
+	w.pendingGen[pos] = struct{}{}
+}
+
+// removePendingGeneration marks the generation of the chunk at the position
+// passed as completed.
+func (w *World) removePendingGeneration(pos ChunkPos) {
+	w.pendingGenMu.Lock()
+	defer w.pendingGenMu.Unlock()
+	delete(w.pendingGen, pos)
+}
+
+// storePendingGeneration writes the positions of chunks of which the
+// generation has not completed to the Provider, if it implements
+// GenerationQueueProvider. It is called when the World is closed, after all
+// generator workers have stopped.
+func (w *World) storePendingGeneration() {
+	prov, ok := w.conf.Provider.(GenerationQueueProvider)
+	if !ok || w.readOnly.Load() {
+		return
+	}
+	w.pendingGenMu.Lock()
+	positions := slices.SortedFunc(maps.Keys(w.pendingGen), func(a, b ChunkPos) int {
+		return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+	})
+	w.pendingGenMu.Unlock()
+
+	if err := prov.StorePendingGeneration(w.conf.Dim, positions); err != nil {
+		w.conf.Log.Error("store pending generation: " + err.Error())
+	}
+}
+
+// resumeGeneration requests the generation of all chunks stored in the
+// Provider using storePendingGeneration. The chunks are generated in the
+// background by generatePending.
+func (w *World) resumeGeneration() {
+	prov, ok := w.conf.Provider.(GenerationQueueProvider)
+	if !ok {
+		w.conf.Log.Warn("resume generation: provider does not store pending generation")
+		return
+	}
+	positions, err := prov.LoadPendingGeneration(w.conf.Dim)
+	if err != nil {
+		w.conf.Log.Error("load pending generation: " + err.Error())
+		return
+	}
+	if len(positions) == 0 {
+		return
+	}
+	w.conf.Log.Debug("Resuming chunk generation...", "chunks", len(positions))
+	w.running.Add(1)
+	go w.generatePending(positions)
+}
+
+// generatePending generates the chunks at the positions passed in batches of
+// Config.GeneratorQueueSize chunks, so that the generator queue is not
+// flooded and only one batch is held in memory at a time. Generated chunks
+// are marked as modified so that they are saved, and chunks not used by any
+// Loader or Viewer are saved and closed straight away.
+func (w *World) generatePending(positions []ChunkPos) {
+	defer w.running.Done()
+
+	for batch := range slices.Chunk(positions, w.conf.GeneratorQueueSize) {
+		var cols []*Column
+		<-w.Exec(func(tx *Tx) {
+			for _, pos := range batch {
+				c, _ := w.chunkIfReady(pos)
+				cols = append(cols, c)
+			}
+		})
+		for _, c := range cols {
+			select {
+			case <-c.readyCh:
+			case <-w.closing:
+				return
+			}
+		}
+		<-w.Exec(func(tx *Tx) {
+			for i, pos := range batch {
+				c, ok := w.chunks[pos]
+				if !ok || c != cols[i] {
+					// The chunk was closed in the meantime.
+					continue
+				}
+				c.modified = true
+				if len(c.viewers) == 0 && len(c.loaders) == 0 && !w.forcedChunks()(pos) && !w.holdUnsaved(c) {
+					w.closeChunk(tx, pos, c)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package world
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// pendingGenerationProvider is a GenerationQueueProvider that keeps the
+// positions stored in memory.
+type pendingGenerationProvider struct {
+	NopProvider
+	mu      sync.Mutex
+	stored  []ChunkPos
+	columns map[ChunkPos]bool
+}
+
+func (p *pendingGenerationProvider) StoreColumn(pos ChunkPos, _ Dimension, _ *chunk.Column) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.columns == nil {
+		p.columns = make(map[ChunkPos]bool)
+	}
+	p.columns[pos] = true
+	return nil
+}
+
+func (p *pendingGenerationProvider) columnStored(pos ChunkPos) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.columns[pos]
+}
+
+func (p *pendingGenerationProvider) StorePendingGeneration(_ Dimension, positions []ChunkPos) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stored = slices.Clone(positions)
+	return nil
+}
+
+func (p *pendingGenerationProvider) LoadPendingGeneration(Dimension) ([]ChunkPos, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.stored), nil
+}
+
+// blockingGenerator is a Generator that reports every chunk it starts
+// generating and waits for release to be closed before returning.
+type blockingGenerator struct {
+	started chan ChunkPos
+	release chan struct{}
+}
+
+func (g blockingGenerator) GenerateChunk(pos ChunkPos, _ *chunk.Chunk) {
+	g.started <- pos
+	<-g.release
+}
+
+func TestResumeGeneration(t *testing.T) {
+	prov := &pendingGenerationProvider{}
+	gen := blockingGenerator{started: make(chan ChunkPos, 8), release: make(chan struct{})}
+	w := Config{Dim: Overworld, Provider: prov, Generator: gen, GeneratorWorkers: 1}.New()
+
+	first, pending := ChunkPos{0, 0}, []ChunkPos{{1, 0}, {2, 0}}
+	<-w.Exec(func(tx *Tx) {
+		w.chunkIfReady(first)
+		for _, pos := range pending {
+			w.chunkIfReady(pos)
+		}
+	})
+	if pos := <-gen.started; pos != first {
+		t.Fatalf("expected generation of %v to start first, got %v", first, pos)
+	}
+	// Close the world while the only worker is still generating the first
+	// chunk, leaving the others queued.
+	closed := make(chan struct{})
+	go func() {
+		_ = w.Close()
+		close(closed)
+	}()
+	<-w.closing
+	close(gen.release)
+	<-closed
+
+	if !slices.Equal(prov.stored, pending) {
+		t.Fatalf("expected pending generation %v to be stored, got %v", pending, prov.stored)
+	}
+
+	gen = blockingGenerator{started: make(chan ChunkPos, 8), release: make(chan struct{})}
+	close(gen.release)
+	w = Config{Dim: Overworld, Provider: prov, Generator: gen, ResumeGeneration: true}.New()
+	t.Cleanup(func() {
+		if err := w.Close(); err != nil {
+			t.Fatalf("failed closing world: %v", err)
+		}
+	})
+	var resumed []ChunkPos
+	for range pending {
+		select {
+		case pos := <-gen.started:
+			resumed = append(resumed, pos)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected generation of %v to be resumed, got %v", pending, resumed)
+		}
+	}
+	slices.SortFunc(resumed, func(a, b ChunkPos) int { return int(a[0] - b[0]) })
+	if !slices.Equal(resumed, pending) {
+		t.Fatalf("expected generation of %v to be resumed, got %v", pending, resumed)
+	}
+	// Resumed chunks are saved and closed once generated, as no Loader uses
+	// them.
+	deadline := time.Now().Add(5 * time.Second)
+	for _, pos := range pending {
+		for !prov.columnStored(pos) {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected resumed chunk %v to be stored", pos)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	var loaded int
+	<-w.Exec(func(tx *Tx) {
+		for _, pos := range pending {
+			if _, ok := w.chunks[pos]; ok {
+				loaded++
+			}
+		}
+	})
+	if loaded != 0 {
+		t.Fatalf("expected resumed chunks to be closed after saving, %v still loaded", loaded)
+	}
+}
+
+func TestGenerationPanicClearsPending(t *testing.T) {
+	prov := &pendingGenerationProvider{}
+	w := Config{Dim: Overworld, Provider: prov, Generator: panicGenerator{}}.New()
+
+	var c *Column
+	<-w.Exec(func(tx *Tx) {
+		c, _ = w.chunkIfReady(ChunkPos{4, 4})
+	})
+	c.waitReady()
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed closing world: %v", err)
+	}
+	if len(prov.stored) != 0 {
+		t.Fatalf("expected chunk failing generation not to be resumed, got %v pending", prov.stored)
+	}
+}
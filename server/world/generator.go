@@ -12,6 +12,15 @@ type Generator interface {
 	GenerateChunk(pos ChunkPos, chunk *chunk.Chunk)
 }
 
+// SeededGenerator is a Generator that generates terrain from a seed. The seed
+// of the Generator of a World is returned by World.Seed and stored in its
+// Settings.
+type SeededGenerator interface {
+	Generator
+	// Seed returns the seed that the Generator generates terrain from.
+	Seed() int64
+}
+
 // NopGenerator is the default generator a world. It places no blocks in the world which results in a void
 // world.
 type NopGenerator struct{}
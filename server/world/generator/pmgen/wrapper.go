@@ -24,6 +24,11 @@ func NewOverworld(seed int64) *Overworld {
 	return &Overworld{seed: seed, gen: New(seed)}
 }
 
+// Seed returns the seed that the Overworld generates terrain from.
+func (o *Overworld) Seed() int64 {
+	return o.seed
+}
+
 // BindWorld initialises the underlying pm-gen generator with the world handle. It is safe to
 // call BindWorld multiple times; only the first call will initialise the generator.
 func (o *Overworld) BindWorld(w *world.World) {
@@ -0,0 +1,56 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// layerGenerator is a Generator that places a single layer of a block at y=0.
+type layerGenerator struct{ rid uint32 }
+
+func (g layerGenerator) GenerateChunk(_ ChunkPos, c *chunk.Chunk) {
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			c.SetBlock(x, 0, z, 0, g.rid)
+		}
+	}
+}
+
+func TestSetGenerator(t *testing.T) {
+	ridByName := func(name string) uint32 {
+		rid, ok := chunk.StateToRuntimeID(name, nil)
+		if !ok {
+			t.Fatalf("block state %v not registered", name)
+		}
+		return rid
+	}
+	nameAt := func(tx *Tx, pos cube.Pos) string {
+		name, _ := tx.Block(pos).EncodeBlock()
+		return name
+	}
+	stone, dirt := ridByName("minecraft:stone"), ridByName("minecraft:dirt")
+
+	w := newTestWorld(t, Config{Generator: layerGenerator{rid: stone}})
+
+	<-w.Exec(func(tx *Tx) {
+		if name := nameAt(tx, cube.Pos{0, 0, 0}); name != "minecraft:stone" {
+			t.Fatalf("expected stone before swapping generators, got %v", name)
+		}
+	})
+	w.SetGenerator(layerGenerator{rid: dirt})
+	<-w.Exec(func(tx *Tx) {
+		if name := nameAt(tx, cube.Pos{160, 0, 160}); name != "minecraft:dirt" {
+			t.Fatalf("expected dirt in a chunk generated after swapping generators, got %v", name)
+		}
+		if name := nameAt(tx, cube.Pos{0, 0, 0}); name != "minecraft:stone" {
+			t.Fatalf("expected chunk generated before the swap to be unchanged, got %v", name)
+		}
+	})
+
+	w.SetGenerator(nil)
+	if _, ok := w.Generator().(NopGenerator); !ok {
+		t.Fatalf("expected nil generator to be replaced with NopGenerator, got %T", w.Generator())
+	}
+}
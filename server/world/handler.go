@@ -8,6 +8,13 @@ import (
 
 type Context = event.Context[*Tx]
 
+// BlockChange describes a block at a position being changed from Old to New,
+// as passed to Handler.HandleBulkBlockChange.
+type BlockChange struct {
+	Pos      cube.Pos
+	Old, New Block
+}
+
 // Handler handles events that are called by a world. Implementations of
 // Handler may be used to listen to specific events such as when an Entity is
 // added to the world.
@@ -52,6 +59,13 @@ type Handler interface {
 	// Leaves decaying happens when there is no wood block neighbouring it.
 	// ctx.Cancel() may be called to prevent leaves from decaying.
 	HandleLeavesDecay(ctx *Context, pos cube.Pos)
+	// HandleBulkBlockChange handles many blocks being changed at once by
+	// Tx.ReplaceBlocks. It is called once, before any of the changes are made,
+	// instead of an event for every block. ctx.Cancel() may be called to
+	// cancel the operation as a whole. The changes slice must not be
+	// modified. Other bulk operations, such as Tx.BuildStructure, do not call
+	// HandleBulkBlockChange.
+	HandleBulkBlockChange(ctx *Context, changes []BlockChange)
 	// HandleEntitySpawn handles an Entity being spawned into a World through a
 	// call to Tx.AddEntity.
 	HandleEntitySpawn(tx *Tx, e Entity)
@@ -86,6 +100,7 @@ func (NopHandler) HandleFireSpread(*Context, cube.Pos, cube.Pos)
 func (NopHandler) HandleBlockBurn(*Context, cube.Pos)                                            {}
 func (NopHandler) HandleCropTrample(*Context, cube.Pos)                                          {}
 func (NopHandler) HandleLeavesDecay(*Context, cube.Pos)                                          {}
+func (NopHandler) HandleBulkBlockChange(*Context, []BlockChange)                                 {}
 func (NopHandler) HandleEntitySpawn(*Tx, Entity)                                                 {}
 func (NopHandler) HandleEntityDespawn(*Tx, Entity)                                               {}
 func (NopHandler) HandleExplosion(*Context, mgl64.Vec3, *[]Entity, *[]cube.Pos, *float64, *bool) {}
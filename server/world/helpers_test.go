@@ -0,0 +1,43 @@
+package world
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestWorld creates a World using the Config passed and closes it once the
+// test and its subtests finish. Fields left empty in the Config get their
+// defaults, so Config{} results in an Overworld without provider or
+// generator.
+func newTestWorld(t *testing.T, conf Config) *World {
+	t.Helper()
+	w := conf.New()
+	t.Cleanup(func() {
+		if err := w.Close(); err != nil {
+			t.Fatalf("failed closing world: %v", err)
+		}
+	})
+	return w
+}
+
+// waitChunkLoaded keeps loading chunks with the Loader passed until it has
+// loaded the chunk at the ChunkPos passed, failing the test if this takes
+// longer than 5 seconds.
+func waitChunkLoaded(t *testing.T, w *World, loader *Loader, pos ChunkPos) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var loaded bool
+		<-w.Exec(func(tx *Tx) {
+			loader.Load(tx, 16)
+			_, loaded = loader.Chunk(pos)
+		})
+		if loaded {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("chunk %v was never loaded", pos)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,60 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestIdleTickMode(t *testing.T) {
+	for _, mode := range []IdleTickMode{IdleTickFull, IdleTickSuspend} {
+		w := Config{Dim: Overworld, Provider: NopProvider{}, Generator: NopGenerator{}, IdleTickMode: mode}.New()
+		// The loader keeps the chunk of the entity loaded, but does not
+		// actually view anything.
+		loader := NewLoader(2, w, NopViewer{})
+
+		typ := tickingEntityType{ticks: map[*EntityHandle]int{}}
+		var (
+			handle    *EntityHandle
+			startTick int64
+		)
+		<-w.Exec(func(tx *Tx) {
+			handle = tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{8, 64, 8}}.New(typ, testEntityConfig{})).H()
+			loader.Move(tx, mgl64.Vec3{})
+			loader.Load(tx, 25)
+			startTick = w.CurrentTick()
+		})
+
+		var ticks int
+		var currentTick int64
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			<-w.Exec(func(tx *Tx) {
+				ticks, currentTick = typ.ticks[handle], w.CurrentTick()
+			})
+			if ticks >= 5 || currentTick-startTick >= 10 {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		<-w.Exec(loader.Close)
+		if err := w.Close(); err != nil {
+			t.Fatalf("failed closing world: %v", err)
+		}
+
+		switch mode {
+		case IdleTickFull:
+			if ticks < 5 {
+				t.Fatalf("expected entity to be ticked with IdleTickFull, got %v ticks", ticks)
+			}
+		case IdleTickSuspend:
+			if ticks != 0 {
+				t.Fatalf("expected entity not to be ticked with IdleTickSuspend, got %v ticks", ticks)
+			}
+			if currentTick-startTick < 10 {
+				t.Fatalf("expected current tick to advance with IdleTickSuspend, advanced %v", currentTick-startTick)
+			}
+		}
+	}
+}
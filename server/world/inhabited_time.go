@@ -0,0 +1,40 @@
+package world
+
+// InhabitedTimeProvider is a Provider that is able to store the inhabited time
+// of a chunk without storing the rest of the chunk. The inhabited time of
+// chunks that are simulated is saved every inhabitedTimeSaveInterval ticks,
+// even if the chunk is otherwise unchanged. For Providers that do not
+// implement InhabitedTimeProvider, the full chunk is written for that, so
+// every simulated chunk is rewritten on each save.
+type InhabitedTimeProvider interface {
+	Provider
+	// StoreInhabitedTime stores the inhabited time of the chunk at the
+	// position and Dimension passed, leaving the rest of the chunk stored
+	// unchanged.
+	StoreInhabitedTime(pos ChunkPos, dim Dimension, inhabitedTime int64) error
+}
+
+// inhabitedTimeSaveInterval is the number of ticks after which the inhabited
+// time of a chunk is saved, even if the chunk is otherwise unchanged.
+const inhabitedTimeSaveInterval = 1200
+
+// saveInhabitedTime saves the inhabited time of a Column that was not
+// otherwise modified. If the Provider does not implement
+// InhabitedTimeProvider, the full Column is stored instead. saveInhabitedTime
+// returns true if the full Column was stored.
+func (w *World) saveInhabitedTime(pos ChunkPos, c *Column) bool {
+	if prov, ok := w.conf.Provider.(InhabitedTimeProvider); ok {
+		if err := prov.StoreInhabitedTime(pos, w.conf.Dim, c.inhabitedTime); err != nil {
+			w.conf.Log.Error("save inhabited time: "+err.Error(), "X", pos[0], "Z", pos[1])
+			return false
+		}
+		c.inhabitedTimeChanged = false
+		return false
+	}
+	if err := w.conf.Provider.StoreColumn(pos, w.conf.Dim, w.columnTo(c, pos)); err != nil {
+		w.conf.Log.Error("save chunk: "+err.Error(), "X", pos[0], "Z", pos[1])
+		return false
+	}
+	c.inhabitedTimeChanged = false
+	return true
+}
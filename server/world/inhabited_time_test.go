@@ -0,0 +1,52 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// inhabitedRecorder is a storeRecorder that also records the inhabited times
+// stored for chunks.
+type inhabitedRecorder struct {
+	storeRecorder
+	inhabited map[ChunkPos]int64
+}
+
+func (s *inhabitedRecorder) StoreInhabitedTime(pos ChunkPos, _ Dimension, inhabitedTime int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inhabited[pos] = inhabitedTime
+	return nil
+}
+
+func TestSaveInhabitedTime(t *testing.T) {
+	inhabited := &inhabitedRecorder{storeRecorder: storeRecorder{stored: make(map[ChunkPos]int)}, inhabited: make(map[ChunkPos]int64)}
+	plain := &storeRecorder{stored: make(map[ChunkPos]int)}
+
+	for _, prov := range []Provider{inhabited, plain} {
+		w := Config{Dim: Overworld, Provider: prov, Generator: NopGenerator{}}.New()
+		<-w.Exec(func(tx *Tx) {
+			tx.Block(cube.Pos{})
+			// Pretend the chunk was loaded unchanged and simulated long
+			// enough for its inhabited time to be saved.
+			c := w.chunks[ChunkPos{}]
+			c.modified, c.inhabitedTime, c.inhabitedTimeChanged = false, inhabitedTimeSaveInterval, true
+		})
+		w.Save()
+		<-w.Exec(func(tx *Tx) {
+			if w.chunks[ChunkPos{}].inhabitedTimeChanged {
+				t.Errorf("expected inhabited time to be marked as saved")
+			}
+		})
+		if err := w.Close(); err != nil {
+			t.Fatalf("failed closing world: %v", err)
+		}
+	}
+	if inhabited.count() != 0 || inhabited.inhabited[ChunkPos{}] != inhabitedTimeSaveInterval {
+		t.Fatalf("expected only the inhabited time to be stored, got %v columns and %v", inhabited.count(), inhabited.inhabited)
+	}
+	if plain.stored[ChunkPos{}] != 1 {
+		t.Fatalf("expected column to be stored once without InhabitedTimeProvider, got %v", plain.stored[ChunkPos{}])
+	}
+}
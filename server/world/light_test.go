@@ -0,0 +1,69 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// coveredColumn returns a Column with a layer of stone at y=100, so that
+// skylight calculation leaves the blocks below it dark.
+func coveredColumn(tb testing.TB, w *World) *Column {
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		tb.Fatalf("block state minecraft:stone not registered")
+	}
+	c := chunk.New(airRID, w.Range())
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			c.SetBlock(x, 100, z, 0, rid)
+		}
+	}
+	return newColumn(c)
+}
+
+func TestSkipLighting(t *testing.T) {
+	for _, skip := range []bool{false, true} {
+		w := Config{Dim: Overworld, Provider: NopProvider{}, Generator: NopGenerator{}, SkipLighting: skip}.New()
+		<-w.Exec(func(tx *Tx) {
+			col := coveredColumn(t, w)
+			col.ensureLight(w, ChunkPos{1000, 1000})
+			if !col.lightReady.Load() {
+				t.Fatalf("skip %v: expected light to be ready", skip)
+			}
+			// When skipping, no light is calculated, so the area below the
+			// stone layer keeps full skylight.
+			want := uint8(0)
+			if skip {
+				want = 15
+			}
+			if got := col.SkyLight(8, 50, 8); got != want {
+				t.Fatalf("skip %v: expected skylight %v below stone, got %v", skip, want, got)
+			}
+		})
+		if err := w.Close(); err != nil {
+			t.Fatalf("failed closing world: %v", err)
+		}
+	}
+}
+
+func BenchmarkEnsureLight(b *testing.B) {
+	for _, skip := range []bool{false, true} {
+		name := "Calculate"
+		if skip {
+			name = "Skip"
+		}
+		b.Run(name, func(b *testing.B) {
+			w := Config{Dim: Overworld, Provider: NopProvider{}, Generator: NopGenerator{}, SkipLighting: skip}.New()
+			defer w.Close()
+			<-w.Exec(func(tx *Tx) {
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					col := coveredColumn(b, w)
+					b.StartTimer()
+					col.ensureLight(w, ChunkPos{1000, 1000})
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// testLiquid is a minimal Liquid used to test the scheduling of liquid
+// updates.
+type testLiquid struct{ unknownBlock }
+
+func (testLiquid) LiquidDepth() int                      { return 7 }
+func (testLiquid) SpreadDecay() int                      { return 1 }
+func (l testLiquid) WithDepth(int, bool) Liquid          { return l }
+func (testLiquid) LiquidFalling() bool                   { return false }
+func (testLiquid) BlastResistance() float64              { return 100 }
+func (testLiquid) LiquidType() string                    { return "test" }
+func (testLiquid) Harden(cube.Pos, *Tx, *cube.Pos) bool  { return false }
+func (testLiquid) Hash() (uint64, uint64)                { return 1, 1 }
+func (testLiquid) EncodeBlock() (string, map[string]any) { return "test:liquid", nil }
+
+func TestLiquidTickMultiplier(t *testing.T) {
+	for _, tc := range []struct {
+		multiplier float64
+		liquid     int64
+	}{{0, 5}, {1, 5}, {2, 10}, {0.5, 2}} {
+		w := Config{Dim: Overworld, Provider: NopProvider{}, Generator: NopGenerator{}, LiquidTickMultiplier: tc.multiplier}.New()
+
+		var liquid, other int64
+		<-w.Exec(func(tx *Tx) {
+			tx.ScheduleBlockUpdate(cube.Pos{0, 10, 0}, testLiquid{}, time.Second/4)
+			tx.ScheduleBlockUpdate(cube.Pos{1, 10, 0}, unknownBlock{}, time.Second/4)
+			q := w.scheduledUpdates
+			for _, tick := range q.ticks {
+				switch tick.pos {
+				case cube.Pos{0, 10, 0}:
+					liquid = tick.t - q.currentTick
+				case cube.Pos{1, 10, 0}:
+					other = tick.t - q.currentTick
+				}
+			}
+		})
+		if err := w.Close(); err != nil {
+			t.Fatalf("failed closing world: %v", err)
+		}
+		if liquid != tc.liquid {
+			t.Fatalf("expected liquid update delay of %v ticks with multiplier %v, got %v", tc.liquid, tc.multiplier, liquid)
+		}
+		if other != 5 {
+			t.Fatalf("expected non-liquid update delay of 5 ticks with multiplier %v, got %v", tc.multiplier, other)
+		}
+	}
+}
@@ -17,6 +17,7 @@ type Loader struct {
 
 	mu        sync.RWMutex
 	pos       ChunkPos
+	subY      int32
 	loadQueue []ChunkPos
 	loaded    map[ChunkPos]*Column
 
@@ -49,11 +50,13 @@ func (l *Loader) ChangeWorld(tx *Tx, new *World) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	loaded := maps.Clone(l.loaded)
+	loaded, viewer := maps.Clone(l.loaded), l.viewer
 	l.w.Exec(func(tx *Tx) {
 		for pos := range loaded {
 			tx.World().removeViewer(tx, pos, l)
 		}
+		tx.World().forgetHiddenViewer(viewer)
+		tx.World().invalidateSimulatedAreas()
 	})
 	clear(l.loaded)
 	l.w.viewerMu.Lock()
@@ -69,20 +72,38 @@ func (l *Loader) ChangeRadius(tx *Tx, new int) {
 	defer l.mu.Unlock()
 
 	l.r = new
+	l.w.invalidateSimulatedAreas()
 	l.evictUnused(tx)
 	l.populateLoadQueue()
 }
 
+// radius returns the chunk radius of the Loader, limited to the maximum
+// chunk radius of its World.
+func (l *Loader) radius() int {
+	if l.w == nil {
+		return l.r
+	}
+	if m := l.w.MaxChunkRadius(); m > 0 && l.r > m {
+		return m
+	}
+	return l.r
+}
+
 // Move moves the loader to the position passed. The position is translated to a chunk position to load
 func (l *Loader) Move(tx *Tx, pos mgl64.Vec3) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	chunkPos := chunkPosFromVec3(pos)
+	subY, chunkPos := int32(math.Floor(pos[1]))>>4, chunkPosFromVec3(pos)
+	if subY != l.subY {
+		l.subY = subY
+		l.w.invalidateSimulatedAreas()
+	}
 	if chunkPos == l.pos {
 		return
 	}
 	l.pos = chunkPos
+	l.w.invalidateSimulatedAreas()
 	l.evictUnused(tx)
 	l.populateLoadQueue()
 }
@@ -141,6 +162,8 @@ func (l *Loader) Close(tx *Tx) {
 		tx.World().removeViewer(tx, pos, l)
 	}
 	l.loaded = map[ChunkPos]*Column{}
+	tx.World().forgetHiddenViewer(l.viewer)
+	tx.World().invalidateSimulatedAreas()
 
 	l.w.viewerMu.Lock()
 	delete(l.w.viewers, l)
@@ -161,7 +184,7 @@ func (l *Loader) world(new *World) {
 // evictUnused gets rid of chunks in the loaded map which are no longer within the chunk radius of the loader,
 // and should therefore be removed.
 func (l *Loader) evictUnused(tx *Tx) {
-	maxDistanceSquared := int64(l.r * l.r)
+	maxDistanceSquared := int64(l.radius() * l.radius())
 	for pos := range l.loaded {
 		diffX, diffZ := int64(pos[0]-l.pos[0]), int64(pos[1]-l.pos[1])
 		if diffX*diffX+diffZ*diffZ > maxDistanceSquared {
@@ -179,7 +202,7 @@ func (l *Loader) populateLoadQueue() {
 	// what precedence it should have), and put them in the loadQueue in that order.
 	queue := map[int32][]ChunkPos{}
 
-	r := int32(l.r)
+	r := int32(l.radius())
 	for x := -r; x <= r; x++ {
 		for z := -r; z <= r; z++ {
 			distance := math.Sqrt(float64(x*x) + float64(z*z))
@@ -213,14 +236,14 @@ func (l *Loader) activeArea(simRadius int32) loaderActiveArea {
 	if target < 0 {
 		target = 0
 	}
-	if lr := int32(l.r); lr >= 0 && lr < target {
+	if lr := int32(l.radius()); lr >= 0 && lr < target {
 		target = lr
 	}
 	if l.activeRadius != target {
 		l.activeRadius = target
 		l.activeRadiusSq = int64(target) * int64(target)
 	}
-	area := loaderActiveArea{pos: l.pos, radius: l.activeRadius, radiusSq: l.activeRadiusSq}
+	area := loaderActiveArea{pos: l.pos, y: l.subY, radius: l.activeRadius, radiusSq: l.activeRadiusSq}
 	l.mu.Unlock()
 	return area
 }
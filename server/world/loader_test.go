@@ -172,3 +172,26 @@ func chunksWithinRadius(r int) int {
 	}
 	return count
 }
+
+func TestLoaderClampedToWorldMaxChunkRadius(t *testing.T) {
+	w := Config{Dim: Overworld, Provider: NopProvider{}, Generator: NopGenerator{}, MaxChunkRadius: 2}.New()
+	t.Cleanup(func() {
+		if err := w.Close(); err != nil {
+			t.Fatalf("failed closing world: %v", err)
+		}
+	})
+	if r := w.MaxChunkRadius(); r != 2 {
+		t.Fatalf("expected max chunk radius 2, got %v", r)
+	}
+
+	loader := NewLoader(8, w, nopViewer{})
+	loader.mu.RLock()
+	queued := len(loader.loadQueue)
+	loader.mu.RUnlock()
+	if expected := chunksWithinRadius(2); queued != expected {
+		t.Fatalf("expected %d chunks queued for loading, got %d", expected, queued)
+	}
+	if area := loader.activeArea(8); area.radius != 2 {
+		t.Fatalf("expected active radius 2, got %v", area.radius)
+	}
+}
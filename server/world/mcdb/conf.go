@@ -18,6 +18,45 @@ type Config struct {
 	// LDBOptions holds LevelDB specific default options, such as the block size
 	// or compression used in the database.
 	LDBOptions *opt.Options
+	// Compression is the compression algorithm used for the tables that chunk
+	// data written by StoreColumn ends up in. If left as CompressionDefault,
+	// the compression set in LDBOptions is used, which defaults to
+	// CompressionFlate. See Compression for the trade-offs of each option.
+	Compression Compression
+}
+
+// Compression is a compression algorithm used to store data in a DB. The
+// algorithm only applies to data written after opening the DB: Existing data
+// remains readable regardless of the Compression used to write it.
+type Compression uint8
+
+const (
+	// CompressionDefault leaves the compression used unchanged from what is set
+	// in Config.LDBOptions.
+	CompressionDefault Compression = iota
+	// CompressionNone stores data uncompressed. This uses the least CPU time,
+	// but leads to considerably larger worlds on disk.
+	CompressionNone
+	// CompressionSnappy compresses data with snappy. It is a lot faster than
+	// CompressionFlate, but compresses chunks less well.
+	CompressionSnappy
+	// CompressionFlate compresses data with deflate. This is the format used by
+	// vanilla and results in the smallest worlds, at the highest CPU cost.
+	CompressionFlate
+)
+
+// ldb returns the LevelDB compression option matching the Compression.
+func (c Compression) ldb() opt.Compression {
+	switch c {
+	case CompressionNone:
+		return opt.NoCompression
+	case CompressionSnappy:
+		return opt.SnappyCompression
+	case CompressionFlate:
+		return opt.FlateCompression
+	default:
+		return opt.DefaultCompression
+	}
 }
 
 // Open creates a new DB reading and writing from/to files under the path
@@ -35,6 +74,9 @@ func (conf Config) Open(dir string) (*DB, error) {
 	if conf.LDBOptions.BlockSize == 0 {
 		conf.LDBOptions.BlockSize = 16 * opt.KiB
 	}
+	if conf.Compression != CompressionDefault {
+		conf.LDBOptions.Compression = conf.Compression.ldb()
+	}
 	_ = os.MkdirAll(filepath.Join(dir, "db"), 0777)
 
 	db := &DB{conf: conf, dir: dir, ldat: &leveldat.Data{}}
@@ -0,0 +1,47 @@
+package mcdb
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+func TestStoreColumnCompressionRoundTrip(t *testing.T) {
+	air, _ := chunk.StateToRuntimeID("minecraft:air", nil)
+	stone, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("stone block state not registered")
+	}
+
+	dir, conf := t.TempDir(), Config{Compression: CompressionSnappy}
+	db, err := conf.Open(dir)
+	if err != nil {
+		t.Fatalf("failed opening db: %v", err)
+	}
+	c := chunk.New(air, world.Overworld.Range())
+	c.SetBlock(3, 10, 7, 0, stone)
+	pos := world.ChunkPos{2, -5}
+	if err := db.StoreColumn(pos, world.Overworld, &chunk.Column{Chunk: c}); err != nil {
+		t.Fatalf("failed storing column: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("failed closing db: %v", err)
+	}
+
+	db, err = conf.Open(dir)
+	if err != nil {
+		t.Fatalf("failed reopening db: %v", err)
+	}
+	defer db.Close()
+	col, err := db.LoadColumn(pos, world.Overworld)
+	if err != nil {
+		t.Fatalf("failed loading column: %v", err)
+	}
+	if rid := col.Chunk.Block(3, 10, 7, 0); rid != stone {
+		t.Fatalf("expected stone (%v) after round trip, got %v", stone, rid)
+	}
+	if rid := col.Chunk.Block(4, 10, 7, 0); rid != air {
+		t.Fatalf("expected air (%v) after round trip, got %v", air, rid)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/df-mc/goleveldb/leveldb"
 	"github.com/google/uuid"
 	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"maps"
 	"math/rand/v2"
 	"os"
 	"path/filepath"
@@ -177,6 +178,10 @@ func (db *DB) column(k dbKey) (*chunk.Column, error) {
 	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
 		return nil, fmt.Errorf("read scheduled updates: %w", err)
 	}
+	col.InhabitedTime, err = db.inhabitedTime(k)
+	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+		return nil, fmt.Errorf("read inhabited time: %w", err)
+	}
 	return col, nil
 }
 
@@ -230,6 +235,17 @@ func (db *DB) subChunks(k dbKey) ([][]byte, error) {
 	return sub, nil
 }
 
+func (db *DB) inhabitedTime(k dbKey) (int64, error) {
+	p, err := db.ldb.Get(append([]byte(keyInhabitedTime), index(k.pos, k.dim)...), nil)
+	if err != nil {
+		return 0, err
+	}
+	if n := len(p); n != 8 {
+		return 0, fmt.Errorf("expected 8 inhabited time bytes, got %v", n)
+	}
+	return int64(binary.LittleEndian.Uint64(p)), nil
+}
+
 func (db *DB) entities(k dbKey) ([]chunk.Entity, error) {
 	// https://learn.microsoft.com/en-us/minecraft/creator/documents/actorstorage
 	ids, err := db.ldb.Get(append([]byte(keyEntityIdentifiers), index(k.pos, k.dim)...), nil)
@@ -334,6 +350,17 @@ func (db *DB) StoreColumn(pos world.ChunkPos, dim world.Dimension, col *chunk.Co
 	return nil
 }
 
+// StoreInhabitedTime stores the inhabited time of the chunk at a position and
+// dimension in the DB, without storing the rest of the chunk.
+func (db *DB) StoreInhabitedTime(pos world.ChunkPos, dim world.Dimension, inhabitedTime int64) error {
+	batch := leveldb.MakeBatch(1)
+	db.storeInhabitedTime(batch, dbKey{pos: pos, dim: dim}, inhabitedTime)
+	if err := db.ldb.Write(batch, nil); err != nil {
+		return fmt.Errorf("store inhabited time %v (%v): %w", pos, dim, err)
+	}
+	return nil
+}
+
 func (db *DB) storeColumn(k dbKey, col *chunk.Column) error {
 	data := chunk.Encode(col.Chunk, chunk.DiskEncoding)
 	n := 7 + len(data.SubChunks) + len(col.Entities)
@@ -346,6 +373,7 @@ func (db *DB) storeColumn(k dbKey, col *chunk.Column) error {
 	db.storeEntities(batch, k, col.Entities)
 	db.storeBlockEntities(batch, k, col.BlockEntities)
 	db.storeScheduledUpdates(batch, k, col.Tick, col.ScheduledBlocks)
+	db.storeInhabitedTime(batch, k, col.InhabitedTime)
 
 	return db.ldb.Write(batch, nil)
 }
@@ -441,6 +469,15 @@ func (db *DB) storeBlockEntities(batch *leveldb.Batch, k dbKey, blockEntities []
 	batch.Put(k.Sum(keyBlockEntities), buf.Bytes())
 }
 
+func (db *DB) storeInhabitedTime(batch *leveldb.Batch, k dbKey, inhabitedTime int64) {
+	key := append([]byte(keyInhabitedTime), index(k.pos, k.dim)...)
+	if inhabitedTime == 0 {
+		batch.Delete(key)
+		return
+	}
+	batch.Put(key, binary.LittleEndian.AppendUint64(nil, uint64(inhabitedTime)))
+}
+
 func (db *DB) storeScheduledUpdates(batch *leveldb.Batch, k dbKey, tick int64, updates []chunk.ScheduledBlockUpdate) {
 	if len(updates) == 0 {
 		batch.Delete(k.Sum(keyPendingScheduledTicks))
@@ -477,6 +514,102 @@ func (db *DB) NewColumnIterator(r *IteratorRange) *ColumnIterator {
 	return newColumnIterator(db, r)
 }
 
+// pendingGenerationKey returns the key under which the positions stored
+// using StorePendingGeneration are stored for a dimension.
+func pendingGenerationKey(dim world.Dimension) []byte {
+	id, _ := world.DimensionID(dim)
+	return fmt.Appendf(nil, "dfPendingGeneration%d", id)
+}
+
+// StorePendingGeneration stores the positions of chunks of which the
+// generation was not completed for the dimension passed.
+func (db *DB) StorePendingGeneration(dim world.Dimension, positions []world.ChunkPos) error {
+	if len(positions) == 0 {
+		return db.ldb.Delete(pendingGenerationKey(dim), nil)
+	}
+	b := make([]byte, 0, len(positions)*8)
+	for _, pos := range positions {
+		b = binary.LittleEndian.AppendUint32(b, uint32(pos[0]))
+		b = binary.LittleEndian.AppendUint32(b, uint32(pos[1]))
+	}
+	return db.ldb.Put(pendingGenerationKey(dim), b, nil)
+}
+
+// LoadPendingGeneration loads the positions stored using
+// StorePendingGeneration for the dimension passed.
+func (db *DB) LoadPendingGeneration(dim world.Dimension) ([]world.ChunkPos, error) {
+	b, err := db.ldb.Get(pendingGenerationKey(dim), nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("load pending generation: %w", err)
+	}
+	positions := make([]world.ChunkPos, 0, len(b)/8)
+	for ; len(b) >= 8; b = b[8:] {
+		positions = append(positions, world.ChunkPos{int32(binary.LittleEndian.Uint32(b)), int32(binary.LittleEndian.Uint32(b[4:]))})
+	}
+	return positions, nil
+}
+
+// structuresKey returns the key under which the structures stored using
+// StoreStructures are stored for a dimension.
+func structuresKey(dim world.Dimension) []byte {
+	id, _ := world.DimensionID(dim)
+	return fmt.Appendf(nil, "dfStructures%d", id)
+}
+
+// StoreStructures stores the locations of structures recorded in the
+// dimension passed, indexed by the name of the structure.
+func (db *DB) StoreStructures(dim world.Dimension, structures map[string][]cube.Pos) error {
+	if len(structures) == 0 {
+		return db.ldb.Delete(structuresKey(dim), nil)
+	}
+	var b []byte
+	for _, name := range slices.Sorted(maps.Keys(structures)) {
+		positions := structures[name]
+		b = binary.LittleEndian.AppendUint16(b, uint16(len(name)))
+		b = append(b, name...)
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(positions)))
+		for _, pos := range positions {
+			b = binary.LittleEndian.AppendUint32(b, uint32(pos[0]))
+			b = binary.LittleEndian.AppendUint32(b, uint32(pos[1]))
+			b = binary.LittleEndian.AppendUint32(b, uint32(pos[2]))
+		}
+	}
+	return db.ldb.Put(structuresKey(dim), b, nil)
+}
+
+// LoadStructures loads the locations of structures stored using
+// StoreStructures for the dimension passed.
+func (db *DB) LoadStructures(dim world.Dimension) (map[string][]cube.Pos, error) {
+	b, err := db.ldb.Get(structuresKey(dim), nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("load structures: %w", err)
+	}
+	structures := make(map[string][]cube.Pos)
+	for len(b) >= 2 {
+		n := int(binary.LittleEndian.Uint16(b))
+		if len(b) < 2+n+4 {
+			return nil, fmt.Errorf("load structures: unexpected end of data")
+		}
+		name := string(b[2 : 2+n])
+		count := int(binary.LittleEndian.Uint32(b[2+n:]))
+		b = b[2+n+4:]
+		if len(b) < count*12 {
+			return nil, fmt.Errorf("load structures: unexpected end of data")
+		}
+		positions := make([]cube.Pos, 0, count)
+		for range count {
+			positions = append(positions, cube.Pos{int(int32(binary.LittleEndian.Uint32(b))), int(int32(binary.LittleEndian.Uint32(b[4:]))), int(int32(binary.LittleEndian.Uint32(b[8:])))})
+			b = b[12:]
+		}
+		structures[name] = positions
+	}
+	return structures, nil
+}
+
 // Close closes the provider, saving any file that might need to be saved, such as the level.dat.
 func (db *DB) Close() error {
 	db.ldat.LastPlayed = time.Now().Unix()
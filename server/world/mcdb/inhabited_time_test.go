@@ -0,0 +1,91 @@
+package mcdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestInhabitedTimeSurvivesSave(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Open(dir)
+	if err != nil {
+		t.Fatalf("failed opening db: %v", err)
+	}
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	stone, _ := world.BlockByRuntimeID(rid)
+
+	w := world.Config{Provider: db, Generator: world.NopGenerator{}}.New()
+	loader := world.NewLoader(2, w, world.NopViewer{})
+	<-w.Exec(func(tx *world.Tx) {
+		loader.Move(tx, mgl64.Vec3{})
+		loader.Load(tx, 25)
+		// Modify the chunk so that it is saved when the world is closed.
+		tx.SetBlock(cube.Pos{0, 0, 0}, stone, nil)
+	})
+
+	var inhabited int64
+	deadline := time.Now().Add(5 * time.Second)
+	for inhabited < 10 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected inhabited time of viewed chunk to increase, got %v", inhabited)
+		}
+		time.Sleep(10 * time.Millisecond)
+		<-w.Exec(func(tx *world.Tx) {
+			inhabited = tx.InhabitedTime(world.ChunkPos{})
+		})
+	}
+	<-w.Exec(loader.Close)
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed closing world: %v", err)
+	}
+
+	db, err = Open(dir)
+	if err != nil {
+		t.Fatalf("failed reopening db: %v", err)
+	}
+	w = world.Config{Provider: db, Generator: world.NopGenerator{}}.New()
+	t.Cleanup(func() {
+		if err := w.Close(); err != nil {
+			t.Fatalf("failed closing world: %v", err)
+		}
+	})
+	var loaded int64
+	<-w.Exec(func(tx *world.Tx) {
+		tx.Block(cube.Pos{0, 0, 0})
+		loaded = tx.InhabitedTime(world.ChunkPos{})
+	})
+	if loaded < inhabited {
+		t.Fatalf("expected inhabited time of at least %v after loading, got %v", inhabited, loaded)
+	}
+}
+
+func TestStoreInhabitedTime(t *testing.T) {
+	db, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed opening db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	col := &chunk.Column{Chunk: chunk.New(0, world.Overworld.Range())}
+	if err := db.StoreColumn(world.ChunkPos{}, world.Overworld, col); err != nil {
+		t.Fatalf("failed storing column: %v", err)
+	}
+	if err := db.StoreInhabitedTime(world.ChunkPos{}, world.Overworld, 1200); err != nil {
+		t.Fatalf("failed storing inhabited time: %v", err)
+	}
+	loaded, err := db.LoadColumn(world.ChunkPos{}, world.Overworld)
+	if err != nil {
+		t.Fatalf("failed loading column: %v", err)
+	}
+	if loaded.InhabitedTime != 1200 {
+		t.Fatalf("expected inhabited time 1200, got %v", loaded.InhabitedTime)
+	}
+}
@@ -39,6 +39,10 @@ const (
 	keyEntityIdentifiers = "digp"
 
 	keyEntity = "actorprefix"
+
+	// keyInhabitedTime is not used by vanilla. It holds the number of ticks
+	// that a chunk was simulated for, as tracked by dragonfly.
+	keyInhabitedTime = "dfInhabitedTime"
 )
 
 // Keys on a per-world basis. These are found only once in a leveldb world save.
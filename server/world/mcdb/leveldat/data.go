@@ -243,6 +243,7 @@ func (d *Data) Settings() *world.Settings {
 		Difficulty:                difficulty,
 		TickRange:                 d.ServerChunkTickRange,
 		PlayersSleepingPercentage: d.PlayersSleepingPercentage,
+		Seed:                      d.RandomSeed,
 	}
 }
 
@@ -265,6 +266,7 @@ func (d *Data) PutSettings(s *world.Settings) {
 	d.CurrentTick = s.CurrentTick
 	d.ServerChunkTickRange = s.TickRange
 	d.PlayersSleepingPercentage = s.PlayersSleepingPercentage
+	d.RandomSeed = s.Seed
 	mode, _ := world.GameModeID(s.DefaultGameMode)
 	d.GameType = int32(mode)
 	difficulty, _ := world.DifficultyID(s.Difficulty)
@@ -0,0 +1,33 @@
+package mcdb
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestPendingGenerationRoundTrip(t *testing.T) {
+	db, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed opening db: %v", err)
+	}
+	defer db.Close()
+
+	positions := []world.ChunkPos{{-3, 5}, {100, -200}}
+	if err := db.StorePendingGeneration(world.Nether, positions); err != nil {
+		t.Fatalf("failed storing pending generation: %v", err)
+	}
+	if got, _ := db.LoadPendingGeneration(world.Nether); !slices.Equal(got, positions) {
+		t.Fatalf("expected %v, got %v", positions, got)
+	}
+	if got, _ := db.LoadPendingGeneration(world.Overworld); len(got) != 0 {
+		t.Fatalf("expected no pending generation in the overworld, got %v", got)
+	}
+	if err := db.StorePendingGeneration(world.Nether, nil); err != nil {
+		t.Fatalf("failed clearing pending generation: %v", err)
+	}
+	if got, _ := db.LoadPendingGeneration(world.Nether); len(got) != 0 {
+		t.Fatalf("expected pending generation to be cleared, got %v", got)
+	}
+}
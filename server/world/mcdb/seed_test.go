@@ -0,0 +1,71 @@
+package mcdb
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestWorldSeedRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Open(dir)
+	if err != nil {
+		t.Fatalf("failed opening db: %v", err)
+	}
+	w := world.Config{Provider: db, Generator: world.NopGenerator{}, Seed: 12345}.New()
+	if seed := w.Seed(); seed != 12345 {
+		t.Fatalf("expected seed 12345, got %v", seed)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed closing world: %v", err)
+	}
+
+	db, err = Open(dir)
+	if err != nil {
+		t.Fatalf("failed reopening db: %v", err)
+	}
+	w = world.Config{Provider: db, Generator: world.NopGenerator{}}.New()
+	defer w.Close()
+	if seed := w.Seed(); seed != 12345 {
+		t.Fatalf("expected seed 12345 after reload, got %v", seed)
+	}
+}
+
+// seededGenerator is a world.SeededGenerator that generates nothing.
+type seededGenerator struct {
+	world.NopGenerator
+	seed int64
+}
+
+func (g seededGenerator) Seed() int64 { return g.seed }
+
+func TestSeededGeneratorSeed(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Open(dir)
+	if err != nil {
+		t.Fatalf("failed opening db: %v", err)
+	}
+	// A seed of 0 is valid, so it must replace the seed of a new level.dat
+	// instead of being ignored.
+	w := world.Config{Provider: db, Generator: seededGenerator{seed: 0}, Seed: 12345}.New()
+	if seed := w.Seed(); seed != 0 {
+		t.Fatalf("expected seed of generator 0, got %v", seed)
+	}
+	w.SetGenerator(seededGenerator{seed: 678})
+	if seed := w.Seed(); seed != 678 {
+		t.Fatalf("expected seed of new generator 678, got %v", seed)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed closing world: %v", err)
+	}
+
+	db, err = Open(dir)
+	if err != nil {
+		t.Fatalf("failed reopening db: %v", err)
+	}
+	w = world.Config{Provider: db, Generator: world.NopGenerator{}}.New()
+	defer w.Close()
+	if seed := w.Seed(); seed != 678 {
+		t.Fatalf("expected seed 678 to be stored, got %v", seed)
+	}
+}
@@ -0,0 +1,46 @@
+package mcdb
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestStructuresRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	db, err := Open(dir)
+	if err != nil {
+		t.Fatalf("failed opening db: %v", err)
+	}
+	w := world.Config{Provider: db, Generator: world.NopGenerator{}}.New()
+	w.RecordStructure("village", cube.Pos{100, 64, -30})
+	w.RecordStructure("village", cube.Pos{-500, 70, 12})
+	w.RecordStructure("dungeon", cube.Pos{8, -20, 8})
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed closing world: %v", err)
+	}
+
+	db, err = Open(dir)
+	if err != nil {
+		t.Fatalf("failed reopening db: %v", err)
+	}
+	structures, err := db.LoadStructures(world.Overworld)
+	if err != nil {
+		t.Fatalf("failed loading structures: %v", err)
+	}
+	want := map[string][]cube.Pos{
+		"village": {{100, 64, -30}, {-500, 70, 12}},
+		"dungeon": {{8, -20, 8}},
+	}
+	if !maps.EqualFunc(structures, want, slices.Equal) {
+		t.Fatalf("expected structures %v, got %v", want, structures)
+	}
+	w = world.Config{Provider: db, Generator: world.NopGenerator{}}.New()
+	defer w.Close()
+	if pos, ok := w.NearestStructure("dungeon", cube.Pos{}); !ok || pos != (cube.Pos{8, -20, 8}) {
+		t.Fatalf("expected dungeon to be located after reload, got %v (%v)", pos, ok)
+	}
+}
@@ -0,0 +1,28 @@
+package world
+
+import (
+	"math"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+func TestNearbyBlockEntities(t *testing.T) {
+	w := newTestWorld(t, Config{})
+
+	<-w.Exec(func(tx *Tx) {
+		near, far := cube.Pos{2, 10, 2}, cube.Pos{40, 10, 2}
+		w.chunk(ChunkPos{}).BlockEntities[near] = exportBlockEntity{v: 1}
+		w.chunk(ChunkPos{2, 0}).BlockEntities[far] = exportBlockEntity{v: 2}
+
+		if found := tx.NearbyBlockEntities(cube.Pos{0, 10, 0}, 8, nil); len(found) != 1 || found[0].Pos != near {
+			t.Errorf("expected only the near block entity, got %v", found)
+		}
+		// Radii far larger than the world must neither overflow nor walk
+		// all chunk positions within them.
+		found := tx.NearbyBlockEntities(cube.Pos{0, 10, 0}, math.MaxInt, nil)
+		if len(found) != 2 || found[0].Pos != near || found[1].Pos != far {
+			t.Errorf("expected both block entities sorted by distance, got %v", found)
+		}
+	})
+}
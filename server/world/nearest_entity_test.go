@@ -0,0 +1,37 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestNearestEntity(t *testing.T) {
+	w := newTestWorld(t, Config{})
+
+	<-w.Exec(func(tx *Tx) {
+		spawn := func(pos mgl64.Vec3) Entity {
+			return tx.AddEntity(EntitySpawnOpts{Position: pos}.New(testEntityType{}, testEntityConfig{}))
+		}
+		far := spawn(mgl64.Vec3{40, 64, 0})
+		near := spawn(mgl64.Vec3{3, 64, 4})
+		excluded := spawn(mgl64.Vec3{1, 64, 0})
+		spawn(mgl64.Vec3{-20, 64, -20})
+
+		filter := func(e Entity) bool { return e != excluded }
+		e, dist, ok := tx.NearestEntity(mgl64.Vec3{0, 64, 0}, 64, filter)
+		if !ok || e != near {
+			t.Fatalf("expected nearest matching entity at %v, got %v (found: %v)", near.Position(), e, ok)
+		}
+		if dist != 5 {
+			t.Fatalf("expected distance 5, got %v", dist)
+		}
+
+		if e, _, ok := tx.NearestEntity(mgl64.Vec3{36, 64, 0}, 8, filter); !ok || e != far {
+			t.Fatalf("expected entity at %v to be found, got %v (found: %v)", far.Position(), e, ok)
+		}
+		if _, _, ok := tx.NearestEntity(mgl64.Vec3{100, 64, 100}, 10, nil); ok {
+			t.Fatalf("expected no entity to be found within range")
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// chainTicker is a NeighbourUpdateTicker that queues further neighbour
+// updates around itself every time it is updated, like a redstone or liquid
+// chain would.
+type chainTicker struct{ unknownBlock }
+
+func (chainTicker) NeighbourUpdateTick(pos, _ cube.Pos, tx *Tx) {
+	tx.World().doBlockUpdatesAround(pos)
+}
+
+func TestPendingNeighbourUpdates(t *testing.T) {
+	w := newTestWorld(t, Config{MaxNeighbourUpdatesPerTick: 4})
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	stone, _ := BlockByRuntimeID(rid)
+
+	<-w.Exec(func(tx *Tx) {
+		// Updates may be left over from the ticks performed so far.
+		tx.World().neighbourUpdates = tx.World().neighbourUpdates[:0]
+
+		pos := cube.Pos{0, 10, 0}
+		tx.SetBlock(pos, stone, nil)
+		updates := tx.PendingNeighbourUpdates()
+		if len(updates) != 7 {
+			t.Fatalf("expected 7 pending neighbour updates, got %v", len(updates))
+		}
+		for _, update := range updates {
+			if update.Neighbour != pos {
+				t.Fatalf("expected neighbour %v, got %v", pos, update.Neighbour)
+			}
+		}
+		updates[0].Pos = cube.Pos{100, 100, 100}
+		if tx.PendingNeighbourUpdates()[0].Pos == updates[0].Pos {
+			t.Fatalf("expected pending neighbour updates to be copied")
+		}
+
+		hits := w.NeighbourUpdateCapHits()
+		ticker{}.performNeighbourUpdates(tx)
+		if n := len(tx.PendingNeighbourUpdates()); n != 3 {
+			t.Fatalf("expected 3 neighbour updates to be postponed, got %v", n)
+		}
+		if n := w.NeighbourUpdateCapHits(); n != hits+1 {
+			t.Fatalf("expected %v neighbour update cap hits, got %v", hits+1, n)
+		}
+		ticker{}.performNeighbourUpdates(tx)
+		if n := len(tx.PendingNeighbourUpdates()); n != 0 {
+			t.Fatalf("expected postponed neighbour updates to be performed, got %v left", n)
+		}
+		if n := w.NeighbourUpdateCapHits(); n != hits+1 {
+			t.Fatalf("expected cap hits to stay at %v below the cap, got %v", hits+1, n)
+		}
+	})
+}
+
+func TestNeighbourUpdateChainWithoutCap(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	// No blocks with block entities are registered in this package, so stone
+	// is treated as one and the ticker is stored in its place.
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	stone, _ := BlockByRuntimeID(rid)
+	prev := nbtBlocks[rid]
+	nbtBlocks[rid] = true
+	t.Cleanup(func() { nbtBlocks[rid] = prev })
+
+	var pending int
+	var hits uint64
+	<-w.Exec(func(tx *Tx) {
+		pos := cube.Pos{0, 10, 0}
+		tx.SetBlock(pos, stone, nil)
+		w.chunk(chunkPosFromBlockPos(pos)).BlockEntities[pos] = chainTicker{}
+		w.neighbourUpdates = append(w.neighbourUpdates[:0], neighbourUpdate{pos: pos, neighbour: pos})
+
+		before := w.NeighbourUpdateCapHits()
+		ticker{}.performNeighbourUpdates(tx)
+		pending = len(tx.PendingNeighbourUpdates())
+		hits = w.NeighbourUpdateCapHits() - before
+
+		// Stop the chain so that it does not keep running in later ticks.
+		tx.SetBlock(pos, nil, nil)
+	})
+	if pending != 7 {
+		t.Fatalf("expected 7 neighbour updates queued by the ticker, got %v", pending)
+	}
+	if hits != 0 {
+		t.Fatalf("expected no neighbour update cap hits without a cap, got %v", hits)
+	}
+}
@@ -0,0 +1,68 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+func TestOnSave(t *testing.T) {
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	stone, _ := BlockByRuntimeID(rid)
+
+	tests := []struct {
+		name string
+		conf Config
+		save bool
+		auto bool
+	}{
+		{name: "explicit", conf: Config{SaveInterval: -1}, save: true},
+		{name: "automatic", conf: Config{SaveInterval: 20 * time.Millisecond}, auto: true},
+		{name: "incremental", conf: Config{SaveInterval: 20 * time.Millisecond, MaxChunkSavesPerTick: 1}, auto: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := make(chan SaveResult, 64)
+			conf := tt.conf
+			conf.Dim, conf.Generator = Overworld, NopGenerator{}
+			conf.Provider = &storeRecorder{stored: make(map[ChunkPos]int)}
+			conf.OnSave = func(res SaveResult) { results <- res }
+			w := conf.New()
+			t.Cleanup(func() {
+				if err := w.Close(); err != nil {
+					t.Fatalf("failed closing world: %v", err)
+				}
+			})
+			<-w.Exec(func(tx *Tx) {
+				tx.SetBlock(cube.Pos{0, 10, 0}, stone, nil)
+				tx.SetBlock(cube.Pos{16, 10, 0}, stone, nil)
+			})
+			if tt.save {
+				w.Save()
+			}
+
+			timeout := time.After(5 * time.Second)
+			for {
+				select {
+				case res := <-results:
+					if res.Auto != tt.auto {
+						t.Fatalf("expected save result with Auto %v, got %+v", tt.auto, res)
+					}
+					if res.Chunks == 2 {
+						return
+					}
+					if tt.save {
+						t.Fatalf("expected explicit save to write 2 chunks, got %+v", res)
+					}
+				case <-timeout:
+					t.Fatalf("expected OnSave to be called with a save of 2 chunks")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// exemptEntityType is an EntityType of which the entities are exempt from
+// passive maintenance.
+type exemptEntityType struct{ testEntityType }
+
+func (exemptEntityType) ExemptFromPassiveMaintenance() bool { return true }
+
+func TestPassiveMaintenanceExempt(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	// Speed up the world so that passive maintenance happens quickly.
+	w.SetTargetTPS(400)
+	loader := NewLoader(1, w, nopViewer{})
+	t.Cleanup(func() {
+		<-w.Exec(loader.Close)
+	})
+
+	var normal, exempt *EntityHandle
+	<-w.Exec(func(tx *Tx) {
+		// Both entities are far away from the loader, so they are only
+		// maintained passively.
+		normal = tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{320, 64, 320}}.New(testEntityType{}, testEntityConfig{})).H()
+		exempt = tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{328, 64, 328}}.New(exemptEntityType{}, testEntityConfig{})).H()
+		loader.Move(tx, mgl64.Vec3{})
+		loader.Load(tx, 9)
+	})
+
+	var normalAge, exemptAge time.Duration
+	deadline := time.Now().Add(10 * time.Second)
+	for normalAge == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected age of sleeping entity to advance during passive maintenance")
+		}
+		time.Sleep(10 * time.Millisecond)
+		<-w.Exec(func(tx *Tx) {
+			normalAge, exemptAge = normal.data.Age, exempt.data.Age
+		})
+	}
+	if exemptAge != 0 {
+		t.Fatalf("expected age of exempt entity not to change, got %v", exemptAge)
+	}
+}
@@ -0,0 +1,38 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// playerEntityType is an EntityType that is identified as a player.
+type playerEntityType struct{ testEntityType }
+
+func (playerEntityType) EncodeEntity() string { return "minecraft:player" }
+
+func TestPlayersWithin(t *testing.T) {
+	w := newTestWorld(t, Config{})
+
+	<-w.Exec(func(tx *Tx) {
+		spawn := func(typ EntityType, pos mgl64.Vec3) *EntityHandle {
+			return tx.AddEntity(EntitySpawnOpts{Position: pos}.New(typ, testEntityConfig{})).H()
+		}
+		centre := mgl64.Vec3{8, 64, 8}
+		near := spawn(playerEntityType{}, mgl64.Vec3{12, 64, 8})
+		nearOtherChunk := spawn(playerEntityType{}, mgl64.Vec3{8, 64, 17})
+		// Within the box around the radius, but not within the radius itself.
+		spawn(playerEntityType{}, mgl64.Vec3{17, 64, 17})
+		spawn(playerEntityType{}, mgl64.Vec3{40, 64, 8})
+		// Not a player.
+		spawn(testEntityType{}, mgl64.Vec3{9, 64, 8})
+
+		found := map[*EntityHandle]bool{}
+		for e := range tx.PlayersWithin(centre, 10) {
+			found[e.H()] = true
+		}
+		if len(found) != 2 || !found[near] || !found[nearOtherChunk] {
+			t.Fatalf("expected only the 2 near players to be yielded, got %v", len(found))
+		}
+	})
+}
@@ -15,10 +15,60 @@ type Nether struct {
 	framed    bool
 	axis      cube.Axis
 	tx        *world.Tx
+	style     NetherStyle
 	spawnPos  cube.Pos
 	positions []cube.Pos
 }
 
+// NetherStyle holds the blocks that a Nether portal is made of. The zero value
+// of NetherStyle describes a vanilla portal: An obsidian frame filled with
+// nether portal blocks. Servers may use a different NetherStyle to build and
+// detect themed portals, such as portals framed with crying obsidian.
+type NetherStyle struct {
+	// Frame is the block that the frame of the portal is made of. If nil,
+	// obsidian is used.
+	Frame world.Block
+	// Interior returns the block used to fill the frame of a portal on the
+	// axis passed. If nil, nether portal blocks are used.
+	Interior func(axis cube.Axis) world.Block
+}
+
+// frame returns the frame block of the NetherStyle.
+func (s NetherStyle) frame() world.Block {
+	if s.Frame == nil {
+		return obsidian()
+	}
+	return s.Frame
+}
+
+// interior returns the interior block of the NetherStyle for an axis.
+func (s NetherStyle) interior(axis cube.Axis) world.Block {
+	if s.Interior == nil {
+		return portal(axis)
+	}
+	return s.Interior(axis)
+}
+
+// isFrame checks if the block passed may support the interior of a portal
+// with this NetherStyle from below.
+func (s NetherStyle) isFrame(b world.Block) bool {
+	if s.Frame != nil {
+		return sameBlock(b, s.Frame)
+	}
+	f, ok := b.(frameBlock)
+	return ok && f.Frame(world.Nether)
+}
+
+// isInterior checks if the block passed is an interior block of a portal with
+// this NetherStyle.
+func (s NetherStyle) isInterior(b world.Block) bool {
+	if s.Interior != nil {
+		return sameBlock(b, s.Interior(cube.X)) || sameBlock(b, s.Interior(cube.Z))
+	}
+	p, ok := b.(portalBlock)
+	return ok && p.Portal() == world.Nether
+}
+
 const (
 	// minimumNetherPortalWidth, maximumNetherPortalWidth controls the minimum and maximum width of a portal.
 	minimumNetherPortalWidth, maximumNetherPortalWidth = 2, 21
@@ -30,17 +80,25 @@ const (
 
 // NetherPortalFromPos returns Nether portal information from a given position in the frame.
 func NetherPortalFromPos(tx *world.Tx, pos cube.Pos) (Nether, bool) {
+	return NetherStyle{}.PortalFromPos(tx, pos)
+}
+
+// PortalFromPos returns information of a Nether portal with this NetherStyle from a given position in the
+// frame.
+func (s NetherStyle) PortalFromPos(tx *world.Tx, pos cube.Pos) (Nether, bool) {
 	if tx.World().Dimension() == world.End {
 		// Don't waste our time; we can't make a portal in the end.
 		return Nether{}, false
 	}
 
+	frame := s.frame()
 	axis, positions, width, height, completed, ok := multiAxisScan(pos, tx, []string{
 		"minecraft:air",
 		"minecraft:fire",
-	})
+	}, frame)
 	if !ok {
-		axis, positions, width, height, completed, ok = multiAxisScan(pos, tx, []string{"minecraft:portal"})
+		name, _ := s.interior(cube.X).EncodeBlock()
+		axis, positions, width, height, completed, ok = multiAxisScan(pos, tx, []string{name}, frame)
 	}
 	return Nether{
 		w: width, h: height,
@@ -49,25 +107,37 @@ func NetherPortalFromPos(tx *world.Tx, pos cube.Pos) (Nether, bool) {
 		framed:    completed,
 		axis:      axis,
 		tx:        tx,
+		style:     s,
 	}, ok
 }
 
 // FindOrCreateNetherPortal finds or creates a Nether portal at the given position.
 func FindOrCreateNetherPortal(tx *world.Tx, pos cube.Pos, radius int) (Nether, bool) {
-	n, ok := FindNetherPortal(tx, pos, radius)
+	return NetherStyle{}.FindOrCreate(tx, pos, radius)
+}
+
+// FindOrCreate finds or creates a Nether portal with this NetherStyle at the given position.
+func (s NetherStyle) FindOrCreate(tx *world.Tx, pos cube.Pos, radius int) (Nether, bool) {
+	n, ok := s.Find(tx, pos, radius)
 	if ok {
 		return n, true
 	}
-	return CreateNetherPortal(tx, pos)
+	return s.Create(tx, pos)
 }
 
 // TryActivateNetherPortal attempts to activate a Nether portal using the block at the provided position as the
 // starting point. It returns true if a portal was successfully activated.
 func TryActivateNetherPortal(tx *world.Tx, pos cube.Pos) bool {
+	return NetherStyle{}.TryActivate(tx, pos)
+}
+
+// TryActivate attempts to activate a Nether portal with this NetherStyle using the block at the provided
+// position as the starting point. It returns true if a portal was successfully activated.
+func (s NetherStyle) TryActivate(tx *world.Tx, pos cube.Pos) bool {
+	frame := s.frame()
 	for _, face := range cube.Faces() {
-		name, _ := tx.Block(pos.Side(face)).EncodeBlock()
-		if name == "minecraft:obsidian" {
-			if portal, ok := NetherPortalFromPos(tx, pos); ok && portal.Framed() {
+		if sameBlock(tx.Block(pos.Side(face)), frame) {
+			if portal, ok := s.PortalFromPos(tx, pos); ok && portal.Framed() {
 				if !portal.Activated() {
 					portal.Activate()
 				}
@@ -92,6 +162,11 @@ type frameBlock interface {
 
 // FindNetherPortal searches a provided radius for a Nether portal.
 func FindNetherPortal(tx *world.Tx, pos cube.Pos, radius int) (Nether, bool) {
+	return NetherStyle{}.Find(tx, pos, radius)
+}
+
+// Find searches a provided radius for a Nether portal with this NetherStyle.
+func (s NetherStyle) Find(tx *world.Tx, pos cube.Pos, radius int) (Nether, bool) {
 	if tx.World().Dimension() == world.End {
 		// Don't waste our time - we can't make a portal in the end.
 		return Nether{}, false
@@ -103,9 +178,8 @@ func FindNetherPortal(tx *world.Tx, pos cube.Pos, radius int) (Nether, bool) {
 			r := tx.World().Dimension().Range()
 			for y := r.Max(); y >= r.Min(); y-- {
 				selectedPos := cube.Pos{x, y, z}
-				if p, ok := tx.Block(selectedPos).(portalBlock); ok && p.Portal() == world.Nether {
-					belowPos := selectedPos.Side(cube.FaceDown)
-					if f, ok := tx.Block(belowPos).(frameBlock); ok && f.Frame(world.Nether) {
+				if s.isInterior(tx.Block(selectedPos)) {
+					if s.isFrame(tx.Block(selectedPos.Side(cube.FaceDown))) {
 						dist := selectedPos.Vec3().Sub(pos.Vec3()).Len()
 						if dist < closestDist {
 							closestDist, closestPos, found = dist, selectedPos, true
@@ -119,17 +193,23 @@ func FindNetherPortal(tx *world.Tx, pos cube.Pos, radius int) (Nether, bool) {
 		// Don't waste our time if the search didn't work out.
 		return Nether{}, false
 	}
-	return NetherPortalFromPos(tx, closestPos)
+	return s.PortalFromPos(tx, closestPos)
 }
 
 // CreateNetherPortal creates a Nether portal at the given position.
 func CreateNetherPortal(tx *world.Tx, pos cube.Pos) (Nether, bool) {
+	return NetherStyle{}.Create(tx, pos)
+}
+
+// Create creates a Nether portal with this NetherStyle at the given position.
+func (s NetherStyle) Create(tx *world.Tx, pos cube.Pos) (Nether, bool) {
 	if tx.World().Dimension() == world.End {
 		// You can't create a nether portal in the end.
 		return Nether{}, false
 	}
 
 	resultPos, random, distance, a, r := pos, rand.Intn(4), -1.0, 0, tx.Range()
+	frame := s.frame()
 	searchValidArea := func(directions int, valid func(pos cube.Pos, riv int, coEff1, coEff2 int) bool) {
 		for tempX := pos.X() - 16; tempX <= pos.X()+16; tempX++ {
 			offsetX := float64(tempX-pos.X()) + 0.5
@@ -239,7 +319,7 @@ func CreateNetherPortal(tx *world.Tx, pos cube.Pos) (Nether, bool) {
 
 					tx.SetBlock(entryPos, nil, nil)
 					if height < 0 {
-						tx.SetBlock(entryPos, obsidian(), nil)
+						tx.SetBlock(entryPos, frame, nil)
 					}
 				}
 			}
@@ -257,11 +337,11 @@ func CreateNetherPortal(tx *world.Tx, pos cube.Pos) (Nether, bool) {
 			}
 
 			if width == -1 || width == 2 || height == -1 || height == 3 {
-				tx.SetBlock(entryPos, obsidian(), nil)
+				tx.SetBlock(entryPos, frame, nil)
 				continue
 			}
 			positions = append(positions, entryPos)
-			tx.SetBlock(entryPos, portal(axis), nil)
+			tx.SetBlock(entryPos, s.interior(axis), nil)
 		}
 	}
 
@@ -273,6 +353,7 @@ func CreateNetherPortal(tx *world.Tx, pos cube.Pos) (Nether, bool) {
 		positions: positions,
 		axis:      axis,
 		tx:        tx,
+		style:     s,
 	}, true
 }
 
@@ -284,7 +365,7 @@ func (n Nether) Bounds() (int, int) {
 // Activate ...
 func (n Nether) Activate() {
 	for _, pos := range n.Positions() {
-		n.tx.SetBlock(pos, portal(n.axis), nil)
+		n.tx.SetBlock(pos, n.style.interior(n.axis), nil)
 	}
 }
 
@@ -303,7 +384,7 @@ func (n Nether) Framed() bool {
 // Activated ...
 func (n Nether) Activated() bool {
 	for _, pos := range n.Positions() {
-		if n.tx.Block(pos) != portal(n.axis) {
+		if !sameBlock(n.tx.Block(pos), n.style.interior(n.axis)) {
 			return false
 		}
 	}
@@ -0,0 +1,63 @@
+package portal_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/portal"
+	_ "unsafe"
+)
+
+func init() {
+	worldFinaliseBlockRegistry()
+}
+
+//go:linkname worldFinaliseBlockRegistry github.com/df-mc/dragonfly/server/world.finaliseBlockRegistry
+func worldFinaliseBlockRegistry()
+
+func TestNetherStyleTryActivate(t *testing.T) {
+	for _, frame := range []world.Block{
+		block.Obsidian{Crying: true},
+		// Banners hold a slice and can therefore not be compared using ==.
+		block.Banner{Patterns: []block.BannerPatternLayer{}},
+	} {
+		w := world.Config{Generator: world.NopGenerator{}, Provider: world.NopProvider{}}.New()
+		style := portal.NetherStyle{Frame: frame}
+
+		var activated, vanilla bool
+		var interior []world.Block
+		<-w.Exec(func(tx *world.Tx) {
+			// Build a 4x5 frame on the X axis, leaving a 2x3 interior.
+			base := cube.Pos{0, 64, 0}
+			for x := -1; x <= 2; x++ {
+				for y := -1; y <= 3; y++ {
+					if x == -1 || x == 2 || y == -1 || y == 3 {
+						tx.SetBlock(base.Add(cube.Pos{x, y, 0}), frame, nil)
+					}
+				}
+			}
+			vanilla = portal.TryActivateNetherPortal(tx, base)
+			activated = style.TryActivate(tx, base)
+			for x := 0; x <= 1; x++ {
+				for y := 0; y <= 2; y++ {
+					interior = append(interior, tx.Block(base.Add(cube.Pos{x, y, 0})))
+				}
+			}
+		})
+		_ = w.Close()
+
+		if vanilla {
+			t.Fatalf("expected portal framed with %T not to be activated as a vanilla portal", frame)
+		}
+		if !activated {
+			t.Fatalf("expected portal framed with %T to be activated", frame)
+		}
+		for _, b := range interior {
+			if p, ok := b.(block.Portal); !ok || p.Axis != cube.X {
+				t.Fatalf("expected interior of portal framed with %T to be filled with portal blocks, got %#v", frame, b)
+			}
+		}
+	}
+}
@@ -15,10 +15,10 @@ type scanIteration struct {
 }
 
 // multiAxisScan performs a scan on the Z and X axis, returning the result that had the most positions, although
-// favouring the Z axis.
-func multiAxisScan(framePos cube.Pos, tx *world.Tx, matchers []string) (cube.Axis, []cube.Pos, int, int, bool, bool) {
-	positions, width, height, completed := scan(cube.Z, framePos, tx, matchers)
-	positionsTwo, widthTwo, heightTwo, completedTwo := scan(cube.X, framePos, tx, matchers)
+// favouring the Z axis. The frame block passed is the block that the frame of the portal is expected to be made of.
+func multiAxisScan(framePos cube.Pos, tx *world.Tx, matchers []string, frame world.Block) (cube.Axis, []cube.Pos, int, int, bool, bool) {
+	positions, width, height, completed := scan(cube.Z, framePos, tx, matchers, frame)
+	positionsTwo, widthTwo, heightTwo, completedTwo := scan(cube.X, framePos, tx, matchers, frame)
 	if len(positions) < minimumArea && len(positionsTwo) >= minimumArea {
 		return cube.X, positionsTwo, widthTwo, heightTwo, completedTwo, len(positionsTwo) > 0
 	}
@@ -26,7 +26,7 @@ func multiAxisScan(framePos cube.Pos, tx *world.Tx, matchers []string) (cube.Axi
 }
 
 // scan performs a scan on the given axis for any of the provided matchers using a position and a world.
-func scan(axis cube.Axis, framePos cube.Pos, tx *world.Tx, matchers []string) ([]cube.Pos, int, int, bool) {
+func scan(axis cube.Axis, framePos cube.Pos, tx *world.Tx, matchers []string, frame world.Block) ([]cube.Pos, int, int, bool) {
 	var width, height int
 	positionsMap := make(map[cube.Pos]bool)
 
@@ -74,7 +74,7 @@ func scan(axis cube.Axis, framePos cube.Pos, tx *world.Tx, matchers []string) ([
 			}
 			queue.PushBack(scanIteration{lastPos: pos, face: cube.FaceUp})
 			queue.PushBack(scanIteration{lastPos: pos, face: cube.FaceDown})
-		} else if _, ok = positionsMap[pos]; !(ok || b == obsidian()) {
+		} else if _, ok = positionsMap[pos]; !(ok || sameBlock(b, frame)) {
 			completed = false
 		}
 	}
@@ -102,6 +102,14 @@ func satisfiesMatchers(b world.Block, matchers []string) bool {
 	return false
 }
 
+// sameBlock checks if the blocks passed are the same, including their block
+// states. Blocks are compared by their hash rather than using ==, which panics
+// for blocks of the same type that are not comparable, such as custom blocks
+// with slice or map fields.
+func sameBlock(a, b world.Block) bool {
+	return world.BlockHash(a) == world.BlockHash(b)
+}
+
 // air returns an air block.
 func air() world.Block {
 	a, ok := world.BlockByName("minecraft:air", nil)
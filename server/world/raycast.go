@@ -0,0 +1,184 @@
+package world
+
+import (
+	"math"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// raycastEntityMargin is the distance by which the box bounding a ray is
+// grown to find candidate entities in raycastEntity. Entities are looked up
+// by their position, so the box must be large enough to include entities of
+// which only the BBox, and not the position, is close to the ray.
+const raycastEntityMargin = 3
+
+// raycastEntity finds the Entity nearest to origin of which the BBox is hit
+// by the ray starting at origin in the direction dir, at most maxDist blocks
+// away. The point at which the ray enters the BBox of the Entity is returned
+// too. A nil filter matches all entities.
+func (w *World) raycastEntity(tx *Tx, origin, dir mgl64.Vec3, maxDist float64, filter func(Entity) bool) (Entity, mgl64.Vec3, bool) {
+	if maxDist <= 0 || dir.LenSqr() == 0 {
+		return nil, mgl64.Vec3{}, false
+	}
+	dir = dir.Normalize()
+	end := origin.Add(dir.Mul(maxDist))
+	box := cube.Box(origin[0], origin[1], origin[2], end[0], end[1], end[2]).Grow(raycastEntityMargin)
+
+	var (
+		nearest     Entity
+		nearestDist = maxDist
+	)
+	for e := range w.entitiesWithin(tx, box) {
+		if filter != nil && !filter(e) {
+			continue
+		}
+		bb := e.H().Type().BBox(e).Translate(e.Position())
+		if dist, ok := rayIntercept(bb, origin, dir); ok && dist <= nearestDist {
+			nearest, nearestDist = e, dist
+		}
+	}
+	if nearest == nil {
+		return nil, mgl64.Vec3{}, false
+	}
+	return nearest, origin.Add(dir.Mul(nearestDist)), true
+}
+
+// raycastBlock finds the first block with a model BBox hit by the ray
+// starting at origin in the direction dir, at most maxDist blocks away, and
+// returns its position and the face of the block hit. Only blocks in chunks
+// that are loaded are considered: Unloaded chunks are treated as empty.
+func (w *World) raycastBlock(origin, dir mgl64.Vec3, maxDist float64) (cube.Pos, cube.Face, bool) {
+	if maxDist <= 0 || dir.LenSqr() == 0 {
+		return cube.Pos{}, 0, false
+	}
+	dir = dir.Normalize()
+
+	// Blocks are traversed using the algorithm described in 'A Fast Voxel
+	// Traversal Algorithm for Ray Tracing' by Amanatides and Woo.
+	pos := cube.PosFromVec3(origin)
+	var step [3]int
+	var tMax, tDelta [3]float64
+	for i := range 3 {
+		switch {
+		case dir[i] > 0:
+			step[i], tMax[i], tDelta[i] = 1, (math.Floor(origin[i])+1-origin[i])/dir[i], 1/dir[i]
+		case dir[i] < 0:
+			step[i], tMax[i], tDelta[i] = -1, (origin[i]-math.Floor(origin[i]))/-dir[i], -1/dir[i]
+		default:
+			tMax[i], tDelta[i] = math.Inf(1), math.Inf(1)
+		}
+	}
+	for {
+		if b, ok := w.loadedBlock(pos); ok {
+			var (
+				nearestDist = math.Inf(1)
+				nearestFace cube.Face
+			)
+			for _, bb := range b.Model().BBox(pos, worldSource{w: w}) {
+				dist, face, ok := rayInterceptFace(bb.Translate(pos.Vec3()), origin, dir)
+				if ok && dist <= maxDist && dist < nearestDist {
+					nearestDist, nearestFace = dist, face
+				}
+			}
+			if !math.IsInf(nearestDist, 1) {
+				return pos, nearestFace, true
+			}
+		}
+		axis := 0
+		if tMax[1] < tMax[axis] {
+			axis = 1
+		}
+		if tMax[2] < tMax[axis] {
+			axis = 2
+		}
+		if tMax[axis] > maxDist {
+			return cube.Pos{}, 0, false
+		}
+		pos[axis] += step[axis]
+		tMax[axis] += tDelta[axis]
+	}
+}
+
+// loadedBlock returns the block at the position passed if the chunk it is in
+// is loaded and the block is not air. Unlike block, loadedBlock never loads a
+// chunk.
+func (w *World) loadedBlock(pos cube.Pos) (Block, bool) {
+	if pos.OutOfBounds(w.ra) {
+		return nil, false
+	}
+	c, ok := w.chunks[chunkPosFromBlockPos(pos)]
+	if !ok || !c.Ready() || c.Block(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0) == airRID {
+		return nil, false
+	}
+	return w.blockInChunk(c, pos), true
+}
+
+// hasLineOfSight checks if no block with a model BBox is between the two
+// positions passed.
+func (w *World) hasLineOfSight(from, to mgl64.Vec3) bool {
+	diff := to.Sub(from)
+	if diff.LenSqr() == 0 {
+		return true
+	}
+	_, _, hit := w.raycastBlock(from, diff, diff.Len())
+	return !hit
+}
+
+// rayIntercept returns the distance along the ray starting at origin in the
+// normalised direction dir at which the ray enters the cube.BBox passed. If
+// origin is inside the box, the distance is 0. False is returned if the ray
+// does not hit the box.
+func rayIntercept(bb cube.BBox, origin, dir mgl64.Vec3) (float64, bool) {
+	dist, _, ok := rayInterceptFace(bb, origin, dir)
+	return dist, ok
+}
+
+// rayInterceptFace works like rayIntercept, but additionally returns the face
+// of the box through which the ray enters it. If origin is inside the box, the
+// face facing against the ray is returned.
+func rayInterceptFace(bb cube.BBox, origin, dir mgl64.Vec3) (float64, cube.Face, bool) {
+	tMin, tMax := 0.0, math.Inf(1)
+	minVec, maxVec := bb.Min(), bb.Max()
+	axis := -1
+	for i := range 3 {
+		if dir[i] == 0 {
+			if origin[i] < minVec[i] || origin[i] > maxVec[i] {
+				return 0, 0, false
+			}
+			continue
+		}
+		t1, t2 := (minVec[i]-origin[i])/dir[i], (maxVec[i]-origin[i])/dir[i]
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		if t1 > tMin {
+			tMin, axis = t1, i
+		}
+		tMax = min(tMax, t2)
+		if tMin > tMax {
+			return 0, 0, false
+		}
+	}
+	if axis == -1 {
+		// The origin is inside the box, so we use the axis along which the
+		// ray moves the most.
+		axis = 0
+		for i := range 3 {
+			if math.Abs(dir[i]) > math.Abs(dir[axis]) {
+				axis = i
+			}
+		}
+	}
+	return tMin, entryFace(axis, dir[axis]), true
+}
+
+// entryFace returns the face through which a ray moving in the direction d
+// along the axis passed (0 for x, 1 for y and 2 for z) enters a box.
+func entryFace(axis int, d float64) cube.Face {
+	faces := [3][2]cube.Face{{cube.FaceWest, cube.FaceEast}, {cube.FaceDown, cube.FaceUp}, {cube.FaceNorth, cube.FaceSouth}}
+	if d > 0 {
+		return faces[axis][0]
+	}
+	return faces[axis][1]
+}
@@ -0,0 +1,100 @@
+package world
+
+import (
+	"math"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// boxEntityType is an EntityType of which the entities have a BBox the size
+// of a player.
+type boxEntityType struct{ testEntityType }
+
+func (boxEntityType) BBox(Entity) cube.BBox { return cube.Box(-0.3, 0, -0.3, 0.3, 1.8, 0.3) }
+
+func TestRaycastEntity(t *testing.T) {
+	w := newTestWorld(t, Config{})
+
+	<-w.Exec(func(tx *Tx) {
+		spawn := func(pos mgl64.Vec3) Entity {
+			return tx.AddEntity(EntitySpawnOpts{Position: pos}.New(boxEntityType{}, testEntityConfig{}))
+		}
+		near, far := spawn(mgl64.Vec3{5, 64, 0.5}), spawn(mgl64.Vec3{20, 64, 0.5})
+		// An entity next to the ray must not be hit.
+		spawn(mgl64.Vec3{3, 64, 3})
+
+		origin, dir := mgl64.Vec3{0, 65, 0.5}, mgl64.Vec3{1, 0, 0}
+		e, hit, ok := tx.RaycastEntity(origin, dir, 30, nil)
+		if !ok || e != near {
+			t.Fatalf("expected ray to hit the nearest entity")
+		}
+		if want := (mgl64.Vec3{4.7, 65, 0.5}); hit.Sub(want).Len() > 1e-9 {
+			t.Fatalf("expected impact point %v, got %v", want, hit)
+		}
+
+		e, _, ok = tx.RaycastEntity(origin, dir, 30, func(e Entity) bool { return e != near })
+		if !ok || e != far {
+			t.Fatalf("expected filtered ray to hit the far entity")
+		}
+		if _, _, ok = tx.RaycastEntity(origin, dir, 4, nil); ok {
+			t.Fatalf("expected ray shorter than the distance to the entity not to hit")
+		}
+		if _, _, ok = tx.RaycastEntity(origin, mgl64.Vec3{-1, 0, 0}, 30, nil); ok {
+			t.Fatalf("expected ray in the opposite direction not to hit")
+		}
+		// A diagonal ray towards the far entity passes it at its centre.
+		diag := mgl64.Vec3{20, 65, 0.5}.Sub(mgl64.Vec3{10, 65, 10.5})
+		if e, _, ok = tx.RaycastEntity(mgl64.Vec3{10, 65, 10.5}, diag, 10*math.Sqrt2+1, nil); !ok || e != far {
+			t.Fatalf("expected diagonal ray to hit the far entity")
+		}
+	})
+}
+
+func TestHasLineOfSight(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	stone, _ := BlockByRuntimeID(rid)
+
+	from, to := mgl64.Vec3{0.5, 65.5, 0.5}, mgl64.Vec3{10.5, 65.5, 0.5}
+	var (
+		clearBefore, blocked, clearAfter bool
+		hitPos                           cube.Pos
+		hitFace                          cube.Face
+		hit                              bool
+	)
+	<-w.Exec(func(tx *Tx) {
+		// Setting the blocks loads the chunk the positions are in.
+		for y := 64; y <= 66; y++ {
+			tx.SetBlock(cube.Pos{5, y, 0}, stone, nil)
+			tx.SetBlock(cube.Pos{5, y, 0}, nil, nil)
+		}
+		clearBefore = tx.HasLineOfSight(from, to)
+		for y := 64; y <= 66; y++ {
+			tx.SetBlock(cube.Pos{5, y, 0}, stone, nil)
+		}
+		blocked = !tx.HasLineOfSight(from, to)
+		hitPos, hitFace, hit = tx.RaycastBlock(from, to.Sub(from), 20)
+		for y := 64; y <= 66; y++ {
+			tx.SetBlock(cube.Pos{5, y, 0}, nil, nil)
+		}
+		clearAfter = tx.HasLineOfSight(from, to)
+	})
+	if !clearBefore {
+		t.Fatalf("expected line of sight without a wall")
+	}
+	if !blocked {
+		t.Fatalf("expected wall to block line of sight")
+	}
+	if !hit || hitPos != (cube.Pos{5, 65, 0}) || hitFace != cube.FaceWest {
+		t.Fatalf("expected ray to hit west face of %v, got %v (face %v, hit %v)", cube.Pos{5, 65, 0}, hitPos, hitFace, hit)
+	}
+	if !clearAfter {
+		t.Fatalf("expected line of sight after removing the wall")
+	}
+}
@@ -0,0 +1,98 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+func TestSetReadOnly(t *testing.T) {
+	prov := &storeRecorder{stored: make(map[ChunkPos]int)}
+	w := newTestWorld(t, Config{Provider: prov, SaveInterval: -1})
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	stone, _ := BlockByRuntimeID(rid)
+	<-w.Exec(func(tx *Tx) {
+		tx.SetBlock(cube.Pos{0, 10, 0}, stone, nil)
+		tx.SetBlock(cube.Pos{16, 10, 0}, stone, nil)
+	})
+
+	w.SetReadOnly(true)
+	if !w.ReadOnly() {
+		t.Fatalf("expected world to be read-only")
+	}
+	if n := w.SaveWithResult(); n != 0 || prov.count() != 0 {
+		t.Fatalf("expected read-only world not to save chunks, saved %v", prov.count())
+	}
+
+	w.SetReadOnly(false)
+	if n := w.SaveWithResult(); n != 2 || prov.count() != 2 {
+		t.Fatalf("expected 2 chunks to be saved, got %v (%v stored)", n, prov.count())
+	}
+	// Chunks stay modified after saving, as changes such as edits to the
+	// inventory of a block entity do not mark them modified again.
+	if n := w.SaveWithResult(); n != 2 {
+		t.Fatalf("expected saved chunks to be saved again, got %v", n)
+	}
+}
+
+func TestReadOnlyHoldsModifiedChunks(t *testing.T) {
+	prov := &storeRecorder{stored: make(map[ChunkPos]int)}
+	w := Config{Dim: Overworld, Provider: prov, Generator: NopGenerator{}, SaveInterval: -1}.New()
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	stone, _ := BlockByRuntimeID(rid)
+
+	w.SetReadOnly(true)
+	var collected int
+	var loaded bool
+	<-w.Exec(func(tx *Tx) {
+		tx.SetBlock(cube.Pos{0, 10, 0}, stone, nil)
+		collected, _, _ = w.CollectGarbage(tx)
+		_, loaded = w.chunks[ChunkPos{}]
+	})
+	if collected != 0 || !loaded {
+		t.Fatalf("expected modified chunk to stay loaded while read-only, collected %v", collected)
+	}
+	if prov.count() != 0 {
+		t.Fatalf("expected read-only world not to save chunks, saved %v", prov.count())
+	}
+
+	// Closing the World while read-only must not lose its changes.
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed closing world: %v", err)
+	}
+	if prov.count() != 1 {
+		t.Fatalf("expected modified chunk to be saved on close, saved %v", prov.count())
+	}
+}
+
+func TestConfigReadOnlyNeverSaves(t *testing.T) {
+	prov := &storeRecorder{stored: make(map[ChunkPos]int)}
+	w := Config{Dim: Overworld, Provider: prov, Generator: NopGenerator{}, SaveInterval: -1, ReadOnly: true}.New()
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	stone, _ := BlockByRuntimeID(rid)
+
+	var collected int
+	<-w.Exec(func(tx *Tx) {
+		tx.SetBlock(cube.Pos{0, 10, 0}, stone, nil)
+		collected, _, _ = w.CollectGarbage(tx)
+	})
+	if collected != 1 {
+		t.Fatalf("expected chunk of read-only world to be collected, collected %v", collected)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed closing world: %v", err)
+	}
+	if prov.count() != 0 {
+		t.Fatalf("expected read-only world never to save chunks, saved %v", prov.count())
+	}
+}
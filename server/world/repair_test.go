@@ -0,0 +1,54 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+func TestRepairChunk(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	// No blocks with block entities are registered in this package, so stone
+	// is treated as one for the duration of the test.
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	stone, _ := BlockByRuntimeID(rid)
+	prev := nbtBlocks[rid]
+	nbtBlocks[rid] = true
+	t.Cleanup(func() { nbtBlocks[rid] = prev })
+
+	var first, second int
+	var orphaned, kept, modified bool
+	<-w.Exec(func(tx *Tx) {
+		stonePos, orphanPos := cube.Pos{1, 10, 1}, cube.Pos{2, 10, 2}
+		tx.SetBlock(stonePos, stone, nil)
+		c := w.chunk(ChunkPos{})
+		c.BlockEntities[stonePos] = exportBlockEntity{v: 1}
+		c.BlockEntities[orphanPos] = exportBlockEntity{v: 2}
+		// Block entities out of bounds of the chunk must be removed without
+		// reading the block at their position.
+		c.BlockEntities[cube.Pos{3, w.Range().Max() + 100, 3}] = exportBlockEntity{v: 3}
+		c.modified = false
+
+		first = tx.RepairChunk(ChunkPos{})
+		_, orphaned = c.BlockEntities[orphanPos]
+		_, kept = c.BlockEntities[stonePos]
+		modified = c.modified
+		second = tx.RepairChunk(ChunkPos{})
+	})
+	if first != 2 || orphaned {
+		t.Fatalf("expected 2 orphaned block entities to be removed, got %v", first)
+	}
+	if !kept {
+		t.Fatalf("expected block entity of stone to be kept")
+	}
+	if !modified {
+		t.Fatalf("expected repaired chunk to be marked as modified")
+	}
+	if second != 0 {
+		t.Fatalf("expected no block entities to be removed from a repaired chunk, got %v", second)
+	}
+}
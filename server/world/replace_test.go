@@ -0,0 +1,148 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+func TestReplaceBlocks(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	blockByName := func(name string) Block {
+		rid, ok := chunk.StateToRuntimeID(name, nil)
+		if !ok {
+			t.Fatalf("block state %v not registered", name)
+		}
+		b, _ := BlockByRuntimeID(rid)
+		return b
+	}
+	nameOf := func(b Block) string {
+		name, _ := b.EncodeBlock()
+		return name
+	}
+	stone, cobblestone := blockByName("minecraft:stone"), blockByName("minecraft:cobblestone")
+
+	<-w.Exec(func(tx *Tx) {
+		// Fill a 4x1x4 layer of stone that crosses a chunk border.
+		for x := 14; x < 18; x++ {
+			for z := 0; z < 4; z++ {
+				tx.SetBlock(cube.Pos{x, 10, z}, stone, nil)
+			}
+		}
+		n := tx.ReplaceBlocks(cube.Box(15, 10, 1, 17, 11, 3), func(b Block) bool {
+			return nameOf(b) == "minecraft:stone"
+		}, cobblestone)
+		if n != 4 {
+			t.Fatalf("expected 4 blocks to be replaced, got %v", n)
+		}
+		for x := 14; x < 18; x++ {
+			for z := 0; z < 4; z++ {
+				pos, want := cube.Pos{x, 10, z}, "minecraft:stone"
+				if x >= 15 && x < 17 && z >= 1 && z < 3 {
+					want = "minecraft:cobblestone"
+				}
+				if got := nameOf(tx.Block(pos)); got != want {
+					t.Fatalf("expected %v at %v, got %v", want, pos, got)
+				}
+			}
+		}
+		if got := nameOf(tx.Block(cube.Pos{15, 11, 1})); got != "minecraft:air" {
+			t.Fatalf("expected air above replaced region, got %v", got)
+		}
+	})
+}
+
+// bulkHandler records the Handler.HandleBulkBlockChange events it receives
+// and cancels them if cancel is true.
+type bulkHandler struct {
+	NopHandler
+	calls  int
+	last   []BlockChange
+	cancel bool
+}
+
+func (h *bulkHandler) HandleBulkBlockChange(ctx *Context, changes []BlockChange) {
+	h.calls++
+	h.last = changes
+	if h.cancel {
+		ctx.Cancel()
+	}
+}
+
+func TestReplaceBlocksBulkEvent(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	stone, _ := BlockByRuntimeID(rid)
+	h := &bulkHandler{}
+	w.Handle(h)
+
+	all := func(Block) bool { return true }
+	<-w.Exec(func(tx *Tx) {
+		// Fill a 20x2x3 region that crosses a chunk border.
+		if n := tx.ReplaceBlocks(cube.Box(0, 10, 0, 20, 12, 3), all, stone); n != 120 {
+			t.Fatalf("expected 120 blocks to be filled, got %v", n)
+		}
+		if h.calls != 1 {
+			t.Fatalf("expected 1 bulk block change event, got %v", h.calls)
+		}
+		if len(h.last) != 120 {
+			t.Fatalf("expected 120 changes in bulk event, got %v", len(h.last))
+		}
+		if name, _ := h.last[0].Old.EncodeBlock(); name != "minecraft:air" {
+			t.Fatalf("expected old block to be air, got %v", name)
+		}
+
+		h.cancel = true
+		if n := tx.ReplaceBlocks(cube.Box(0, 20, 0, 4, 21, 4), all, stone); n != 0 {
+			t.Fatalf("expected cancelled fill to change no blocks, got %v", n)
+		}
+		if name, _ := tx.Block(cube.Pos{0, 20, 0}).EncodeBlock(); name != "minecraft:air" {
+			t.Fatalf("expected cancelled fill to leave air, got %v", name)
+		}
+	})
+}
+
+func TestReplaceBlocksSecondLayer(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	runtimeID := func(name string) uint32 {
+		rid, ok := chunk.StateToRuntimeID(name, map[string]any{"liquid_depth": int32(0)})
+		if !ok {
+			rid, ok = chunk.StateToRuntimeID(name, nil)
+		}
+		if !ok {
+			t.Fatalf("block state %v not registered", name)
+		}
+		return rid
+	}
+	stoneRID, waterRID := runtimeID("minecraft:stone"), runtimeID("minecraft:water")
+	stone, _ := BlockByRuntimeID(stoneRID)
+	cobblestone, _ := BlockByRuntimeID(runtimeID("minecraft:cobblestone"))
+	isStone := func(b Block) bool { return BlockRuntimeID(b) == stoneRID }
+
+	var replacedLayer, removedLayer0, removedLayer1 uint32
+	<-w.Exec(func(tx *Tx) {
+		replaced, removed := cube.Pos{1, 10, 1}, cube.Pos{2, 10, 1}
+		c := w.chunk(ChunkPos{})
+		for _, pos := range []cube.Pos{replaced, removed} {
+			tx.SetBlock(pos, stone, nil)
+			c.SetBlock(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 1, waterRID)
+		}
+		// Blocks that cannot hold liquids have the liquid removed, while
+		// replacing a block with air leaves the liquid in its place.
+		tx.ReplaceBlocks(cube.Box(1, 10, 1, 2, 11, 2), isStone, cobblestone)
+		tx.ReplaceBlocks(cube.Box(2, 10, 1, 3, 11, 2), func(Block) bool { return true }, nil)
+
+		replacedLayer = c.Block(1, 10, 1, 1)
+		removedLayer0, removedLayer1 = c.Block(2, 10, 1, 0), c.Block(2, 10, 1, 1)
+	})
+	if replacedLayer != airRID {
+		t.Fatalf("expected liquid to be removed from a block that cannot hold it")
+	}
+	if removedLayer0 != waterRID || removedLayer1 != airRID {
+		t.Fatalf("expected liquid to take the place of a block replaced with air")
+	}
+}
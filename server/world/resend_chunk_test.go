@@ -0,0 +1,49 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// chunkViewer is a Viewer that counts how often each chunk was sent to it.
+type chunkViewer struct {
+	NopViewer
+	viewed map[ChunkPos]int
+}
+
+func (v *chunkViewer) ViewChunk(pos ChunkPos, _ Dimension, _ map[cube.Pos]Block, _ *chunk.Chunk) {
+	v.viewed[pos]++
+}
+
+func TestResendChunk(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	viewing, other := &chunkViewer{viewed: map[ChunkPos]int{}}, &chunkViewer{viewed: map[ChunkPos]int{}}
+	loader := NewLoader(1, w, viewing)
+	t.Cleanup(func() {
+		<-w.Exec(loader.Close)
+	})
+	<-w.Exec(func(tx *Tx) { loader.Move(tx, mgl64.Vec3{}) })
+	waitChunkLoaded(t, w, loader, ChunkPos{})
+
+	var resent, resentOther, resentUnloaded bool
+	var before, after int
+	<-w.Exec(func(tx *Tx) {
+		before = viewing.viewed[ChunkPos{}]
+		resent = tx.ResendChunk(ChunkPos{}, viewing)
+		after = viewing.viewed[ChunkPos{}]
+		resentOther = tx.ResendChunk(ChunkPos{}, other)
+		resentUnloaded = tx.ResendChunk(ChunkPos{100, 100}, viewing)
+	})
+	if !resent || after != before+1 {
+		t.Fatalf("expected chunk to be sent to the viewing viewer again")
+	}
+	if resentOther || len(other.viewed) != 0 {
+		t.Fatalf("expected chunk not to be sent to a viewer not viewing it")
+	}
+	if resentUnloaded {
+		t.Fatalf("expected unloaded chunk not to be sent")
+	}
+}
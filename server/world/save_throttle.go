@@ -0,0 +1,77 @@
+package world
+
+import "time"
+
+// PendingChunkSaves returns the number of chunks that are queued to be saved
+// incrementally but have not yet been written to the Provider. The backlog is
+// only used if Config.MaxChunkSavesPerTick is set.
+func (w *World) PendingChunkSaves() int {
+	return int(w.pendingSaveCount.Load())
+}
+
+// queueSave queues all modified chunks currently loaded to be saved over the
+// following ticks and saves the level.dat values and structures of the World. Nothing is
+// queued while chunks of a previous save are still pending, so that a save
+// interval shorter than the time needed to work through the backlog does not
+// keep it from ever completing.
+func (w *World) queueSave(*Tx) {
+	if w.readOnly.Load() || len(w.pendingSaves) > 0 {
+		return
+	}
+	if w.pendingSaveSet == nil {
+		w.pendingSaveSet = make(map[ChunkPos]struct{})
+	}
+	if !w.pendingSaveActive {
+		w.pendingSaveResult, w.pendingSaveStart, w.pendingSaveActive = SaveResult{Auto: true}, time.Now(), true
+	}
+	for pos, c := range w.chunks {
+		if !c.modified && !c.inhabitedTimeChanged {
+			continue
+		}
+		if _, ok := w.pendingSaveSet[pos]; ok {
+			continue
+		}
+		w.pendingSaveSet[pos] = struct{}{}
+		w.pendingSaves = append(w.pendingSaves, pos)
+	}
+	w.pendingSaveCount.Store(int64(len(w.pendingSaves)))
+	w.conf.Provider.SaveSettings(w.set)
+	w.storeStructures()
+}
+
+// savePending saves up to Config.MaxChunkSavesPerTick chunks queued by
+// queueSave. Chunks that were unloaded in the meantime have already been
+// saved by closeChunk and are skipped.
+func (w *World) savePending() {
+	if len(w.pendingSaves) == 0 {
+		w.finishPendingSave()
+		return
+	}
+	n := min(w.conf.MaxChunkSavesPerTick, len(w.pendingSaves))
+	for _, pos := range w.pendingSaves[:n] {
+		delete(w.pendingSaveSet, pos)
+		if c, ok := w.chunks[pos]; ok && w.saveChunk(nil, pos, c) {
+			w.pendingSaveResult.Chunks++
+		}
+	}
+	w.pendingSaves = w.pendingSaves[:copy(w.pendingSaves, w.pendingSaves[n:])]
+	w.pendingSaveCount.Store(int64(len(w.pendingSaves)))
+	if len(w.pendingSaves) == 0 {
+		w.finishPendingSave()
+	}
+}
+
+// finishPendingSave calls Config.OnSave with the result of the incremental
+// save in progress, if any. OnSave is called on a new goroutine so that it
+// runs outside of the transaction that saved the last chunk.
+func (w *World) finishPendingSave() {
+	if !w.pendingSaveActive {
+		return
+	}
+	w.pendingSaveActive = false
+	res := w.pendingSaveResult
+	res.Duration = time.Since(w.pendingSaveStart)
+	if w.conf.OnSave != nil {
+		go w.conf.OnSave(res)
+	}
+}
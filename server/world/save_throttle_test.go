@@ -0,0 +1,67 @@
+package world
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// storeRecorder is a Provider that records the positions of all columns
+// stored.
+type storeRecorder struct {
+	NopProvider
+	mu     sync.Mutex
+	stored map[ChunkPos]int
+}
+
+func (s *storeRecorder) StoreColumn(pos ChunkPos, _ Dimension, _ *chunk.Column) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stored[pos]++
+	return nil
+}
+
+func (s *storeRecorder) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.stored)
+}
+
+func TestMaxChunkSavesPerTickPersistsAllChunks(t *testing.T) {
+	const chunks = 12
+	prov := &storeRecorder{stored: make(map[ChunkPos]int)}
+	w := newTestWorld(t, Config{
+		Provider:             prov,
+		SaveInterval:         time.Millisecond * 500,
+		MaxChunkSavesPerTick: 2,
+	})
+
+	rid, ok := chunk.StateToRuntimeID("minecraft:stone", nil)
+	if !ok {
+		t.Fatalf("block state minecraft:stone not registered")
+	}
+	stone, _ := BlockByRuntimeID(rid)
+	<-w.Exec(func(tx *Tx) {
+		for i := range chunks {
+			tx.SetBlock(cube.Pos{i * 16, 10, 0}, stone, nil)
+		}
+	})
+
+	deadline := time.Now().Add(time.Second * 5)
+	for prov.count() < chunks || w.PendingChunkSaves() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %v chunks to be saved, got %v (%v pending)", chunks, prov.count(), w.PendingChunkSaves())
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	prov.mu.Lock()
+	defer prov.mu.Unlock()
+	for i := range chunks {
+		if _, ok := prov.stored[ChunkPos{int32(i), 0}]; !ok {
+			t.Fatalf("expected chunk %v to be saved", ChunkPos{int32(i), 0})
+		}
+	}
+}
@@ -45,6 +45,8 @@ type Settings struct {
 	PlayersSleepingPercentage int32
 	// RequiredSleepTicks is the number of ticks that players must sleep for in order for the time to change to day.
 	RequiredSleepTicks int64
+	// Seed is the seed used to generate the World.
+	Seed int64
 }
 
 // defaultSettings returns the default Settings for a new World.
@@ -59,3 +61,73 @@ func defaultSettings() *Settings {
 		PlayersSleepingPercentage: 100,
 	}
 }
+
+// cloneLocked returns a copy of the fields of s. s must be locked while
+// cloneLocked is called.
+func (s *Settings) cloneLocked() *Settings {
+	return &Settings{
+		Name:                      s.Name,
+		Spawn:                     s.Spawn,
+		Time:                      s.Time,
+		TimeCycle:                 s.TimeCycle,
+		RainTime:                  s.RainTime,
+		Raining:                   s.Raining,
+		ThunderTime:               s.ThunderTime,
+		Thundering:                s.Thundering,
+		WeatherCycle:              s.WeatherCycle,
+		CurrentTick:               s.CurrentTick,
+		DefaultGameMode:           s.DefaultGameMode,
+		Difficulty:                s.Difficulty,
+		TickRange:                 s.TickRange,
+		PlayersSleepingPercentage: s.PlayersSleepingPercentage,
+		RequiredSleepTicks:        s.RequiredSleepTicks,
+		Seed:                      s.Seed,
+	}
+}
+
+// Settings returns a snapshot of the Settings of the World, taken under a
+// single lock acquisition so that all fields are consistent with each other.
+// Changing the Settings returned has no effect on the World. Use
+// ApplySettings to change the Settings of the World.
+func (w *World) Settings() *Settings {
+	if w == nil {
+		return defaultSettings()
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.cloneLocked()
+}
+
+// ApplySettings calls f with the Settings of the World while they are locked,
+// so that multiple fields may be changed at once. f must not lock the Settings
+// itself. Viewers of the World are updated if the time, spawn or weather
+// changed as a result.
+func (w *World) ApplySettings(f func(s *Settings)) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	before := w.set.cloneLocked()
+	f(w.set)
+	after := w.set.cloneLocked()
+	w.set.Unlock()
+
+	timeChanged, spawnChanged := before.Time != after.Time, before.Spawn != after.Spawn
+	weatherChanged := before.Raining != after.Raining || before.Thundering != after.Thundering
+	if !timeChanged && !spawnChanged && !weatherChanged {
+		return
+	}
+	viewers, _ := w.allViewers()
+	for _, viewer := range viewers {
+		if timeChanged {
+			viewer.ViewTime(int(after.Time))
+		}
+		if spawnChanged {
+			viewer.ViewWorldSpawn(after.Spawn)
+		}
+		if weatherChanged {
+			viewer.ViewWeather(after.Raining, after.Thundering && after.Raining)
+		}
+	}
+	w.releaseViewers(viewers)
+}
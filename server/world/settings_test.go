@@ -0,0 +1,35 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+func TestSettingsSnapshotRoundTrip(t *testing.T) {
+	w := newTestWorld(t, Config{})
+
+	w.ApplySettings(func(s *Settings) {
+		s.Name = "Event"
+		s.Time = 6000
+		s.Spawn = cube.Pos{10, 70, -10}
+		s.Difficulty = DifficultyHard
+		s.PlayersSleepingPercentage = 50
+	})
+
+	snap := w.Settings()
+	if snap.Name != "Event" || snap.Time != 6000 || snap.Spawn != (cube.Pos{10, 70, -10}) ||
+		snap.Difficulty != DifficultyHard || snap.PlayersSleepingPercentage != 50 {
+		t.Fatalf("snapshot does not reflect applied settings: %+v", snap)
+	}
+	if w.Time() != 6000 || w.Spawn() != (cube.Pos{10, 70, -10}) || w.Difficulty() != DifficultyHard {
+		t.Fatalf("world getters do not reflect applied settings")
+	}
+
+	// Changing the snapshot must not affect the world.
+	snap.Time = 0
+	snap.Name = "Changed"
+	if got := w.Settings(); got.Time != 6000 || got.Name != "Event" {
+		t.Fatalf("expected snapshot changes not to affect the world, got %+v", got)
+	}
+}
@@ -0,0 +1,29 @@
+package world
+
+// SimulationStats holds statistics on the simulation of entities in a World
+// during a single tick, as returned by World.SimulationStats.
+type SimulationStats struct {
+	// ActiveColumns is the number of columns viewed by at least one Loader.
+	ActiveColumns int
+	// EntityColumns is the number of columns holding at least one entity.
+	EntityColumns int
+	// SleepingColumns is the number of columns holding entities that are not
+	// viewed by any viewer. Entities in these columns are only maintained
+	// periodically instead of being ticked every tick.
+	SleepingColumns int
+	// ActiveEntities and SleepingEntities are the number of entities in
+	// viewed and sleeping columns respectively.
+	ActiveEntities, SleepingEntities int
+}
+
+// SimulationStats returns statistics on the simulation of entities in the
+// World during the last tick, such as the number of columns and entities that
+// were actively ticked and the number that were only maintained while
+// sleeping. SimulationStats may be called at any time and does not require a
+// transaction. The zero value is returned if the World has not ticked yet.
+func (w *World) SimulationStats() SimulationStats {
+	if stats := w.simulationStats.Load(); stats != nil {
+		return *stats
+	}
+	return SimulationStats{}
+}
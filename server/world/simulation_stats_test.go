@@ -0,0 +1,42 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestSimulationStats(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	loader := NewLoader(1, w, nopViewer{})
+	t.Cleanup(func() {
+		<-w.Exec(loader.Close)
+	})
+
+	<-w.Exec(func(tx *Tx) {
+		// Two entities in the viewed chunk and one in a chunk far away from
+		// the loader.
+		tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{4, 64, 4}}.New(testEntityType{}, testEntityConfig{}))
+		tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{8, 64, 8}}.New(testEntityType{}, testEntityConfig{}))
+		tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{320, 64, 320}}.New(testEntityType{}, testEntityConfig{}))
+		loader.Move(tx, mgl64.Vec3{})
+		loader.Load(tx, 9)
+	})
+
+	want := SimulationStats{EntityColumns: 2, SleepingColumns: 1, ActiveEntities: 2, SleepingEntities: 1}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stats := w.SimulationStats()
+		if stats.ActiveColumns > 0 {
+			stats.ActiveColumns = 0
+			if stats == want {
+				return
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected simulation stats %+v with active columns, got %+v", want, w.SimulationStats())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
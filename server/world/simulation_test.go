@@ -0,0 +1,100 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestIsSimulatedWithinLoaderRadius(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	w.SetTickRange(2)
+
+	loader := NewLoader(4, w, nopViewer{})
+	t.Cleanup(func() {
+		<-w.Exec(loader.Close)
+	})
+
+	<-w.Exec(func(tx *Tx) {
+		loader.Move(tx, mgl64.Vec3{})
+
+		for _, pos := range []cube.Pos{{0, 64, 0}, {15, 0, 15}, {-17, 64, 0}, {32, 64, 0}} {
+			if !tx.IsSimulated(pos) {
+				t.Fatalf("expected %v to be simulated", pos)
+			}
+		}
+		for _, pos := range []cube.Pos{{48, 64, 0}, {0, 64, -64}, {40, 64, 40}} {
+			if tx.IsSimulated(pos) {
+				t.Fatalf("expected %v not to be simulated", pos)
+			}
+		}
+	})
+}
+
+func TestIsSimulatedActivationShape(t *testing.T) {
+	for _, shape := range []ActivationShape{ActivationCylinder, ActivationSphere} {
+		w := Config{
+			Dim:                  Overworld,
+			Provider:             NopProvider{},
+			Generator:            NopGenerator{},
+			BlockActivationShape: shape,
+		}.New()
+		w.SetTickRange(2)
+		loader := NewLoader(4, w, nopViewer{})
+
+		<-w.Exec(func(tx *Tx) {
+			loader.Move(tx, mgl64.Vec3{0, 64, 0})
+
+			// Positions at the height of the loader are simulated under both
+			// shapes.
+			for _, pos := range []cube.Pos{{0, 64, 0}, {32, 64, 0}, {16, 80, 16}} {
+				if !tx.IsSimulated(pos) {
+					t.Fatalf("shape %v: expected %v to be simulated", shape, pos)
+				}
+			}
+			// Positions far above or below the loader are only simulated for
+			// a cylinder, as are positions near the edge at a different
+			// height.
+			for _, pos := range []cube.Pos{{0, 200, 0}, {0, -48, 0}, {32, 96, 0}} {
+				if got, want := tx.IsSimulated(pos), shape == ActivationCylinder; got != want {
+					t.Fatalf("shape %v: expected simulated %v for %v, got %v", shape, want, pos, got)
+				}
+			}
+		})
+		<-w.Exec(loader.Close)
+		if err := w.Close(); err != nil {
+			t.Fatalf("failed closing world: %v", err)
+		}
+	}
+}
+
+func TestIsSimulatedCacheInvalidation(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	w.SetTickRange(2)
+	loader := NewLoader(4, w, nopViewer{})
+
+	// The active areas are cached within a tick, but must follow loaders
+	// that move or close and changes of the tick range within that tick.
+	<-w.Exec(func(tx *Tx) {
+		near, far := cube.Pos{32, 64, 0}, cube.Pos{320, 64, 0}
+		if !tx.IsSimulated(near) || tx.IsSimulated(far) {
+			t.Errorf("expected only chunks near the loader to be simulated")
+			return
+		}
+		loader.Move(tx, far.Vec3())
+		if tx.IsSimulated(near) || !tx.IsSimulated(far) {
+			t.Errorf("expected simulated area to follow the loader")
+			return
+		}
+		w.SetTickRange(1)
+		if tx.IsSimulated(far.Add(cube.Pos{32})) {
+			t.Errorf("expected smaller tick range to be applied")
+			return
+		}
+		loader.Close(tx)
+		if tx.IsSimulated(far) {
+			t.Errorf("expected nothing to be simulated after closing the loader")
+		}
+	})
+}
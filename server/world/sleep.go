@@ -21,23 +21,19 @@ type Sleeper interface {
 	Wake()
 }
 
-// tryAdvanceDay attempts to advance the day of the world, by first ensuring that all sleepers are sleeping, and then
-// updating the time of day.
+// tryAdvanceDay attempts to advance the day of the world, by first ensuring that enough sleepers are sleeping, as
+// reported by Tx.SleepSkipReady, and then updating the time of day.
 func (ticker) tryAdvanceDay(tx *Tx, timeCycle bool) {
-	sleepers := tx.Sleepers()
+	if ready, _, _ := tx.SleepSkipReady(); !ready {
+		// We can't advance the time - not enough sleepers are sleeping.
+		return
+	}
 
 	var thunderAnywhere bool
-	for s := range sleepers {
+	for s := range tx.Sleepers() {
 		if !thunderAnywhere {
 			thunderAnywhere = tx.ThunderingAt(cube.PosFromVec3(s.Position()))
 		}
-		if _, ok := s.Sleeping(); !ok {
-			// We can't advance the time - not everyone is sleeping.
-			return
-		}
-	}
-
-	for s := range sleepers {
 		s.Wake()
 	}
 
@@ -53,3 +49,39 @@ func (ticker) tryAdvanceDay(tx *Tx, timeCycle bool) {
 	}
 	tx.w.StopRaining()
 }
+
+// ForEachSleepingPlayer calls fn for every Sleeper in the world that is currently sleeping, passing its UUID and
+// the position of the bed it is sleeping in. Iteration stops once fn returns false. Unlike collecting the
+// sleepers in a map, ForEachSleepingPlayer does not allocate, making it suitable to be called every tick.
+func (tx *Tx) ForEachSleepingPlayer(fn func(id uuid.UUID, pos cube.Pos) bool) {
+	for s := range tx.Sleepers() {
+		if pos, ok := s.Sleeping(); ok && !fn(s.UUID(), pos) {
+			return
+		}
+	}
+}
+
+// SleepSkipReady reports if enough sleepers in the world are sleeping to skip the night, based on the
+// percentage returned by World.PlayersSleepingPercentage. The number of sleepers currently sleeping and the
+// number required to skip the night are returned as well. ready is always false if there are no sleepers.
+func (tx *Tx) SleepSkipReady() (ready bool, sleeping, required int) {
+	total := 0
+	for s := range tx.Sleepers() {
+		total++
+		if _, ok := s.Sleeping(); ok {
+			sleeping++
+		}
+	}
+	required = requiredSleepers(total, tx.w.PlayersSleepingPercentage())
+	return total > 0 && sleeping >= required, sleeping, required
+}
+
+// requiredSleepers returns the number of sleepers out of total that must be sleeping for the night to be
+// skipped with the percentage passed. At least one sleeper is required if total is non-zero.
+func requiredSleepers(total int, percentage int32) int {
+	if total <= 0 {
+		return 0
+	}
+	percentage = min(max(percentage, 0), 100)
+	return max((total*int(percentage)+99)/100, 1)
+}
@@ -0,0 +1,173 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+)
+
+// sleeperEntityType is an EntityType of which the entities implement Sleeper.
+// Whether the entity is sleeping is stored in a *sleeperState in
+// EntityData.Data.
+type sleeperEntityType struct{ testEntityType }
+
+func (sleeperEntityType) Open(_ *Tx, handle *EntityHandle, data *EntityData) Entity {
+	return &testSleeper{testEntity: testEntity{handle: handle, data: data}}
+}
+
+// sleeperState holds the bed position of a testSleeper and whether it is
+// sleeping in it.
+type sleeperState struct {
+	bed      cube.Pos
+	sleeping bool
+}
+
+// sleeperConfig is an EntityConfig that sets the sleeperState of a
+// testSleeper.
+type sleeperConfig struct{ state *sleeperState }
+
+func (conf sleeperConfig) Apply(data *EntityData) { data.Data = conf.state }
+
+type testSleeper struct{ testEntity }
+
+func (s *testSleeper) state() *sleeperState              { return s.data.Data.(*sleeperState) }
+func (s *testSleeper) Name() string                      { return "sleeper" }
+func (s *testSleeper) UUID() uuid.UUID                   { return s.handle.UUID() }
+func (s *testSleeper) Messaget(chat.Translation, ...any) {}
+func (s *testSleeper) SendSleepingIndicator(int, int)    {}
+func (s *testSleeper) Sleep(pos cube.Pos)                { *s.state() = sleeperState{bed: pos, sleeping: true} }
+func (s *testSleeper) Sleeping() (cube.Pos, bool)        { return s.state().bed, s.state().sleeping }
+func (s *testSleeper) Wake()                             { s.state().sleeping = false }
+
+func TestRequiredSleepers(t *testing.T) {
+	tests := []struct {
+		total      int
+		percentage int32
+		want       int
+	}{
+		{total: 0, percentage: 100, want: 0},
+		{total: 5, percentage: 100, want: 5},
+		{total: 5, percentage: 50, want: 3},
+		{total: 4, percentage: 50, want: 2},
+		{total: 5, percentage: 0, want: 1},
+		{total: 3, percentage: 150, want: 3},
+	}
+	for _, test := range tests {
+		if got := requiredSleepers(test.total, test.percentage); got != test.want {
+			t.Fatalf("requiredSleepers(%v, %v) = %v, want %v", test.total, test.percentage, got, test.want)
+		}
+	}
+}
+
+func TestSleepSkipReadyWithoutSleepers(t *testing.T) {
+	w := newTestWorld(t, Config{})
+
+	<-w.Exec(func(tx *Tx) {
+		ready, sleeping, required := tx.SleepSkipReady()
+		if ready || sleeping != 0 || required != 0 {
+			t.Fatalf("expected no sleep skip without sleepers, got ready=%v sleeping=%v required=%v", ready, sleeping, required)
+		}
+	})
+}
+
+// noWeatherDimension is the Overworld without a weather cycle. No biomes are
+// registered in tests, so checking for thunder would otherwise fail.
+type noWeatherDimension struct{ overworld }
+
+func (noWeatherDimension) WeatherCycle() bool { return false }
+
+func TestTryAdvanceDaySleepingPercentage(t *testing.T) {
+	tests := []struct {
+		percentage int32
+		sleeping   int
+		ready      bool
+	}{
+		{percentage: 100, sleeping: 3, ready: false},
+		{percentage: 100, sleeping: 4, ready: true},
+		{percentage: 50, sleeping: 1, ready: false},
+		{percentage: 50, sleeping: 2, ready: true},
+	}
+	for _, test := range tests {
+		w := newTestWorld(t, Config{Dim: noWeatherDimension{}})
+		w.set.Lock()
+		w.set.PlayersSleepingPercentage = test.percentage
+		w.set.Unlock()
+		w.SetTime(TimeNight + 1000)
+
+		states := make([]*sleeperState, 4)
+		var ready bool
+		var sleeping, required, awake int
+		<-w.Exec(func(tx *Tx) {
+			for i := range states {
+				states[i] = &sleeperState{}
+				s := tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{float64(i), 64}}.New(sleeperEntityType{}, sleeperConfig{state: states[i]}))
+				if i < test.sleeping {
+					s.(Sleeper).Sleep(cube.Pos{i, 64})
+				}
+			}
+			ready, sleeping, required = tx.SleepSkipReady()
+			ticker{}.tryAdvanceDay(tx, true)
+		})
+		for _, state := range states {
+			if !state.sleeping {
+				awake++
+			}
+		}
+		if ready != test.ready || sleeping != test.sleeping {
+			t.Fatalf("%v%%, %v/4 sleeping: expected SleepSkipReady ready=%v, got ready=%v sleeping=%v required=%v", test.percentage, test.sleeping, test.ready, ready, sleeping, required)
+		}
+		if skipped := w.Time() == TimeFull; skipped != test.ready {
+			t.Fatalf("%v%%, %v/4 sleeping: expected night skipped=%v, time is %v", test.percentage, test.sleeping, test.ready, w.Time())
+		}
+		if test.ready && awake != len(states) {
+			t.Fatalf("%v%%, %v/4 sleeping: expected all sleepers to be woken after skipping the night, %v still sleeping", test.percentage, test.sleeping, len(states)-awake)
+		}
+		if !test.ready && awake != len(states)-test.sleeping {
+			t.Fatalf("%v%%, %v/4 sleeping: expected sleepers not to be woken, got %v awake", test.percentage, test.sleeping, awake)
+		}
+	}
+}
+
+func TestForEachSleepingPlayer(t *testing.T) {
+	w := newTestWorld(t, Config{})
+
+	var (
+		sleepingIDs = map[uuid.UUID]cube.Pos{}
+		visited     = map[uuid.UUID]cube.Pos{}
+		calls       int
+	)
+	<-w.Exec(func(tx *Tx) {
+		for i := range 4 {
+			s := tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{float64(i), 64}}.New(sleeperEntityType{}, sleeperConfig{state: &sleeperState{}})).(Sleeper)
+			if i%2 == 0 {
+				s.Sleep(cube.Pos{i, 64, 3})
+				sleepingIDs[s.UUID()] = cube.Pos{i, 64, 3}
+			}
+		}
+		// Not a Sleeper.
+		tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{8, 64}}.New(testEntityType{}, testEntityConfig{}))
+
+		tx.ForEachSleepingPlayer(func(id uuid.UUID, pos cube.Pos) bool {
+			visited[id] = pos
+			return true
+		})
+		tx.ForEachSleepingPlayer(func(uuid.UUID, cube.Pos) bool {
+			calls++
+			return false
+		})
+	})
+	if len(visited) != len(sleepingIDs) {
+		t.Fatalf("expected %v sleeping players to be passed, got %v", len(sleepingIDs), len(visited))
+	}
+	for id, pos := range sleepingIDs {
+		if got, ok := visited[id]; !ok || got != pos {
+			t.Fatalf("expected sleeper %v to be passed with bed position %v, got %v (passed: %v)", id, pos, got, ok)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected iteration to stop after fn returned false, fn was called %v times", calls)
+	}
+}
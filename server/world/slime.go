@@ -0,0 +1,42 @@
+package world
+
+// IsSlimeChunk checks if slimes may spawn in the chunk at the position passed,
+// regardless of the light level, using the same algorithm as Bedrock Edition.
+// Unlike Java Edition, Bedrock Edition does not use the seed of the world to
+// determine slime chunks, so the result only depends on the chunk position
+// and is the same for every World.
+func IsSlimeChunk(pos ChunkPos) bool {
+	seed := uint32(pos[0])*0x1f1f1f1f ^ uint32(pos[1])
+	return mt19937First(seed)%10 == 0
+}
+
+// mt19937First returns the first number produced by a 32-bit Mersenne Twister
+// seeded with the seed passed. Only the three state words needed to produce
+// the first number are computed.
+func mt19937First(seed uint32) uint32 {
+	const (
+		n, m      = 624, 397
+		upperMask = 0x80000000
+		lowerMask = 0x7fffffff
+		matrixA   = 0x9908b0df
+	)
+	next := func(prev uint32, i uint32) uint32 {
+		return 1812433253*(prev^(prev>>30)) + i
+	}
+	state0, state1 := seed, next(seed, 1)
+	stateM := state1
+	for i := uint32(2); i <= m; i++ {
+		stateM = next(stateM, i)
+	}
+
+	y := state0&upperMask | state1&lowerMask
+	y = stateM ^ y>>1
+	if state1&1 != 0 {
+		y ^= matrixA
+	}
+	y ^= y >> 11
+	y ^= y << 7 & 0x9d2c5680
+	y ^= y << 15 & 0xefc60000
+	y ^= y >> 18
+	return y
+}
@@ -0,0 +1,26 @@
+package world
+
+import "testing"
+
+func TestMT19937First(t *testing.T) {
+	// 3499211612 is the first number produced by a Mersenne Twister seeded
+	// with its default seed, 5489.
+	if got := mt19937First(5489); got != 3499211612 {
+		t.Fatalf("expected 3499211612, got %v", got)
+	}
+}
+
+func TestIsSlimeChunk(t *testing.T) {
+	slime := map[ChunkPos]bool{
+		{-3, -2}: true, {-3, -1}: true, {-1, 0}: true, {0, -2}: true,
+		{1, -2}: true, {3, 0}: true, {3, 1}: true,
+	}
+	for x := int32(-3); x <= 3; x++ {
+		for z := int32(-3); z <= 3; z++ {
+			pos := ChunkPos{x, z}
+			if got := IsSlimeChunk(pos); got != slime[pos] {
+				t.Errorf("expected IsSlimeChunk(%v) to be %v, got %v", pos, slime[pos], got)
+			}
+		}
+	}
+}
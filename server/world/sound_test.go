@@ -0,0 +1,72 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+type testSound struct{}
+
+func (testSound) Play(*World, mgl64.Vec3) {}
+
+// soundViewer is a Viewer that counts the sounds played to it.
+type soundViewer struct {
+	NopViewer
+	sounds int
+}
+
+func (v *soundViewer) ViewSound(mgl64.Vec3, Sound) { v.sounds++ }
+
+// soundCanceller is a Handler that cancels every sound played.
+type soundCanceller struct{ NopHandler }
+
+func (soundCanceller) HandleSound(ctx *Context, _ Sound, _ mgl64.Vec3) { ctx.Cancel() }
+
+func TestPlaySoundRadius(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	near, neighbour, far := &soundViewer{}, &soundViewer{}, &soundViewer{}
+	nearLoader, neighbourLoader, farLoader := NewLoader(0, w, near), NewLoader(0, w, neighbour), NewLoader(0, w, far)
+	t.Cleanup(func() {
+		<-w.Exec(func(tx *Tx) {
+			nearLoader.Close(tx)
+			neighbourLoader.Close(tx)
+			farLoader.Close(tx)
+		})
+	})
+
+	<-w.Exec(func(tx *Tx) {
+		nearLoader.Move(tx, mgl64.Vec3{8, 64, 8})
+		neighbourLoader.Move(tx, mgl64.Vec3{24, 64, 8})
+		farLoader.Move(tx, mgl64.Vec3{40, 64, 8})
+	})
+	waitChunkLoaded(t, w, nearLoader, ChunkPos{0, 0})
+	waitChunkLoaded(t, w, neighbourLoader, ChunkPos{1, 0})
+	waitChunkLoaded(t, w, farLoader, ChunkPos{2, 0})
+
+	pos := mgl64.Vec3{8, 64, 8}
+	tests := []struct {
+		name                 string
+		play                 func(tx *Tx)
+		near, neighbour, far int
+	}{
+		{name: "PlaySound", play: func(tx *Tx) { tx.PlaySound(pos, testSound{}) }, near: 1},
+		{name: "PlaySoundAt", play: func(tx *Tx) { tx.PlaySoundAt(pos, testSound{}) }, near: 1},
+		{name: "radius 16", play: func(tx *Tx) { tx.PlaySoundRadius(pos, testSound{}, 16) }, near: 1, neighbour: 1},
+		{name: "radius 32", play: func(tx *Tx) { tx.PlaySoundRadius(pos, testSound{}, 32) }, near: 1, neighbour: 1, far: 1},
+	}
+	for _, test := range tests {
+		near.sounds, neighbour.sounds, far.sounds = 0, 0, 0
+		<-w.Exec(test.play)
+		if near.sounds != test.near || neighbour.sounds != test.neighbour || far.sounds != test.far {
+			t.Fatalf("%v: expected %v, %v and %v sounds for the near, neighbouring and far viewers, got %v, %v and %v", test.name, test.near, test.neighbour, test.far, near.sounds, neighbour.sounds, far.sounds)
+		}
+	}
+
+	w.Handle(soundCanceller{})
+	near.sounds, neighbour.sounds, far.sounds = 0, 0, 0
+	<-w.Exec(func(tx *Tx) { tx.PlaySoundRadius(pos, testSound{}, 32) })
+	if near.sounds != 0 || neighbour.sounds != 0 || far.sounds != 0 {
+		t.Fatalf("expected a cancelled sound not to be played, got %v, %v and %v sounds", near.sounds, neighbour.sounds, far.sounds)
+	}
+}
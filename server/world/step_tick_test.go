@@ -0,0 +1,42 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestStepTick(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	// The World isn't ticked without any viewers.
+	loader := NewLoader(1, w, nopViewer{})
+	<-w.Exec(func(tx *Tx) {
+		loader.Move(tx, mgl64.Vec3{})
+	})
+	t.Cleanup(func() { <-w.Exec(loader.Close) })
+
+	<-w.StepTick()
+	if w.Frozen() {
+		t.Fatalf("expected world not to be frozen by default")
+	}
+
+	w.SetFrozen(true)
+	// A tick may have been started just before the World was frozen.
+	time.Sleep(w.TickInterval() * 3)
+	start := w.CurrentTick()
+	time.Sleep(w.TickInterval() * 5)
+	if tick := w.CurrentTick(); tick != start {
+		t.Fatalf("expected frozen world not to tick, advanced %v ticks", tick-start)
+	}
+
+	<-w.StepTick()
+	if tick := w.CurrentTick(); tick != start+1 {
+		t.Fatalf("expected StepTick to advance exactly one tick, advanced %v ticks", tick-start)
+	}
+	<-w.StepTick()
+	<-w.StepTick()
+	if tick := w.CurrentTick(); tick != start+3 {
+		t.Fatalf("expected three steps to advance three ticks, advanced %v ticks", tick-start)
+	}
+}
@@ -0,0 +1,117 @@
+package world
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// StructureProvider is a Provider that is able to store the locations of
+// structures recorded using World.RecordStructure, so that they may still be
+// located after the World is closed and created again.
+type StructureProvider interface {
+	Provider
+	// StoreStructures stores the locations of structures passed for the
+	// Dimension passed, indexed by the name of the structure, replacing any
+	// locations stored earlier.
+	StoreStructures(dim Dimension, structures map[string][]cube.Pos) error
+	// LoadStructures loads the locations last stored using StoreStructures
+	// for the Dimension passed.
+	LoadStructures(dim Dimension) (map[string][]cube.Pos, error)
+}
+
+// RecordStructure records the location of a structure, such as a village or
+// a dungeon, with the name passed, so that it may be found using
+// NearestStructure. RecordStructure does not require a transaction and may be
+// called from a Generator or GenerationFeature. Recording the same location
+// for a name twice has no effect.
+func (w *World) RecordStructure(name string, pos cube.Pos) {
+	w.structureMu.Lock()
+	defer w.structureMu.Unlock()
+	if w.structures == nil {
+		w.structures = make(map[string][]cube.Pos)
+	}
+	if !slices.Contains(w.structures[name], pos) {
+		w.structures[name] = append(w.structures[name], pos)
+		w.structuresChanged = true
+	}
+}
+
+// NearestStructure returns the location of the structure with the name passed
+// that is horizontally closest to the position passed, as recorded using
+// RecordStructure. False is returned if no structure with the name was
+// recorded.
+func (w *World) NearestStructure(name string, from cube.Pos) (cube.Pos, bool) {
+	w.structureMu.Lock()
+	defer w.structureMu.Unlock()
+
+	var (
+		nearest cube.Pos
+		found   bool
+		minDist int64
+	)
+	for _, pos := range w.structures[name] {
+		dx, dz := int64(pos[0]-from[0]), int64(pos[2]-from[2])
+		if dist := dx*dx + dz*dz; !found || dist < minDist {
+			nearest, minDist, found = pos, dist, true
+		}
+	}
+	return nearest, found
+}
+
+// Structures returns the locations of all structures with the name passed
+// that were recorded using RecordStructure.
+func (w *World) Structures(name string) []cube.Pos {
+	w.structureMu.Lock()
+	defer w.structureMu.Unlock()
+	return slices.Clone(w.structures[name])
+}
+
+// loadStructures loads the structures stored in the Provider, if it
+// implements StructureProvider.
+func (w *World) loadStructures() {
+	prov, ok := w.conf.Provider.(StructureProvider)
+	if !ok {
+		return
+	}
+	structures, err := prov.LoadStructures(w.conf.Dim)
+	if err != nil {
+		w.conf.Log.Error("load structures: " + err.Error())
+		return
+	}
+	for name, positions := range structures {
+		for _, pos := range positions {
+			w.RecordStructure(name, pos)
+		}
+	}
+	w.structureMu.Lock()
+	w.structuresChanged = false
+	w.structureMu.Unlock()
+}
+
+// storeStructures writes the structures recorded to the Provider, if it
+// implements StructureProvider and structures were recorded since they were
+// last stored. It is called every time the World is saved, and again when
+// the World is closed, after all generator workers have stopped.
+func (w *World) storeStructures() {
+	prov, ok := w.conf.Provider.(StructureProvider)
+	if !ok || w.readOnly.Load() {
+		return
+	}
+	w.structureMu.Lock()
+	if !w.structuresChanged {
+		w.structureMu.Unlock()
+		return
+	}
+	structures := maps.Clone(w.structures)
+	w.structuresChanged = false
+	w.structureMu.Unlock()
+
+	if err := prov.StoreStructures(w.conf.Dim, structures); err != nil {
+		w.conf.Log.Error("store structures: " + err.Error())
+		w.structureMu.Lock()
+		w.structuresChanged = true
+		w.structureMu.Unlock()
+	}
+}
@@ -0,0 +1,74 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+func TestNearestStructure(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	if _, ok := w.NearestStructure("village", cube.Pos{}); ok {
+		t.Fatalf("expected no village to be found before recording one")
+	}
+	w.AddGenerationFeature(func(pos ChunkPos, _ *chunk.Chunk, _ func(x, z int) Biome) {
+		if pos == (ChunkPos{10, 0}) {
+			w.RecordStructure("village", cube.Pos{168, 64, 8})
+		}
+	})
+	w.RecordStructure("village", cube.Pos{-400, 70, 0})
+	w.RecordStructure("village", cube.Pos{-400, 70, 0})
+	w.RecordStructure("dungeon", cube.Pos{100, -30, 0})
+	<-w.Exec(func(tx *Tx) {
+		tx.Block(cube.Pos{160, 0, 0})
+	})
+
+	if n := len(w.Structures("village")); n != 2 {
+		t.Fatalf("expected 2 villages to be recorded, got %v", n)
+	}
+	if pos, ok := w.NearestStructure("village", cube.Pos{120, 0, 0}); !ok || pos != (cube.Pos{168, 64, 8}) {
+		t.Fatalf("expected nearest village at %v, got %v (%v)", cube.Pos{168, 64, 8}, pos, ok)
+	}
+	if pos, ok := w.NearestStructure("village", cube.Pos{-300, 0, 0}); !ok || pos != (cube.Pos{-400, 70, 0}) {
+		t.Fatalf("expected nearest village at %v, got %v (%v)", cube.Pos{-400, 70, 0}, pos, ok)
+	}
+}
+
+// structureRecorder is a StructureProvider that counts the number of times
+// structures were stored.
+type structureRecorder struct {
+	NopProvider
+	stores     int
+	structures map[string][]cube.Pos
+}
+
+func (s *structureRecorder) StoreStructures(_ Dimension, structures map[string][]cube.Pos) error {
+	s.stores++
+	s.structures = structures
+	return nil
+}
+
+func (s *structureRecorder) LoadStructures(Dimension) (map[string][]cube.Pos, error) {
+	return nil, nil
+}
+
+func TestStructuresStoredOnSave(t *testing.T) {
+	prov := &structureRecorder{}
+	w := newTestWorld(t, Config{Provider: prov})
+
+	w.RecordStructure("village", cube.Pos{100, 64, -30})
+	w.Save()
+	if prov.stores != 1 || len(prov.structures["village"]) != 1 {
+		t.Fatalf("expected structures to be stored on save, got %v stores of %v", prov.stores, prov.structures)
+	}
+	w.Save()
+	if prov.stores != 1 {
+		t.Fatalf("expected unchanged structures not to be stored again, got %v stores", prov.stores)
+	}
+	w.RecordStructure("village", cube.Pos{-500, 70, 12})
+	w.Save()
+	if prov.stores != 2 || len(prov.structures["village"]) != 2 {
+		t.Fatalf("expected new structure to be stored on save, got %v stores of %v", prov.stores, prov.structures)
+	}
+}
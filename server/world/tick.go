@@ -12,9 +12,7 @@ import (
 )
 
 // ticker implements World ticking methods.
-type ticker struct {
-	interval time.Duration
-}
+type ticker struct{}
 
 type entityChunkRef struct {
 	col *Column
@@ -29,15 +27,17 @@ func clearEntityRefMap(m map[*EntityHandle]entityChunkRef) {
 
 const (
 	tpsSampleSize              = 20
-	tpsWarningThreshold        = 19.0
 	passiveMaintenanceInterval = 80
+	// tpsWarningRatio is the fraction of the target TPS below which a warning
+	// is logged.
+	tpsWarningRatio = 0.95
 )
 
-// tickLoop starts ticking the World 20 times every second, updating all
-// entities, blocks and other features such as the time and weather of the
-// world, as required.
+// tickLoop starts ticking the World 20 times every second, or at the rate set
+// using World.SetTargetTPS, updating all entities, blocks and other features
+// such as the time and weather of the world, as required.
 func (t ticker) tickLoop(w *World) {
-	tc := time.NewTicker(t.interval)
+	tc := time.NewTicker(w.TickInterval())
 	defer tc.Stop()
 	lastTick := time.Now()
 	var (
@@ -49,6 +49,13 @@ func (t ticker) tickLoop(w *World) {
 		select {
 		case <-tc.C:
 			tickStart := time.Now()
+			if w.frozen.Load() {
+				// Frozen worlds are only ticked using StepTick. Keep the
+				// last tick current so that the TPS isn't skewed once the
+				// World is unfrozen.
+				lastTick = tickStart
+				continue
+			}
 			duration := tickStart.Sub(lastTick)
 			lastTick = tickStart
 			if duration > 0 {
@@ -59,13 +66,19 @@ func (t ticker) tickLoop(w *World) {
 					if avg > 0 {
 						tps := 1.0 / avg.Seconds()
 						w.tps.Store(math.Float64bits(tps))
-						if tps < tpsWarningThreshold {
+						if tps < w.TargetTPS()*tpsWarningRatio {
 							if !warned {
 								w.conf.Log.Warn("TPS dropped below threshold.", "tps", tps)
 								warned = true
+								if w.conf.OnTPSWarning != nil {
+									w.conf.OnTPSWarning(tps)
+								}
 							}
 						} else if warned {
 							warned = false
+							if w.conf.OnTPSRecover != nil {
+								w.conf.OnTPSRecover(tps)
+							}
 						}
 					} else {
 						w.tps.Store(math.Float64bits(0))
@@ -75,6 +88,9 @@ func (t ticker) tickLoop(w *World) {
 				}
 			}
 			<-w.Exec(t.tick)
+		case <-w.tickIntervalChanged:
+			tc.Reset(w.TickInterval())
+			lastTick, durationSum, ticksCount, warned = time.Now(), 0, 0, false
 		case <-w.closing:
 			// World is being closed: Stop ticking and get rid of a task.
 			w.running.Done()
@@ -90,6 +106,9 @@ func (t ticker) tick(tx *Tx) {
 	w := tx.World()
 	defer w.releaseViewers(viewers)
 
+	w.invalidateSimulatedAreas()
+	w.savePending()
+
 	w.set.Lock()
 	if s := w.set.Spawn; s[1] > tx.Range()[1] {
 		// Vanilla will set the spawn position's Y value to max to indicate that
@@ -101,6 +120,15 @@ func (t ticker) tick(tx *Tx) {
 		w.set.Unlock()
 		return
 	}
+	if w.conf.IdleTickMode == IdleTickSuspend && w.set.CurrentTick != 0 && idle(viewers) {
+		// Nobody is viewing the world, so only the current tick is
+		// advanced.
+		if w.advance {
+			w.set.CurrentTick++
+		}
+		w.set.Unlock()
+		return
+	}
 	if w.advance {
 		w.set.CurrentTick++
 		if w.set.TimeCycle {
@@ -125,6 +153,7 @@ func (t ticker) tick(tx *Tx) {
 	if tryAdvanceDay {
 		t.tryAdvanceDay(tx, timeCycle)
 	}
+	w.runTimeTriggers(tx, int64(tim))
 
 	if tick%20 == 0 {
 		for _, viewer := range viewers {
@@ -151,6 +180,12 @@ func (t ticker) performNeighbourUpdates(tx *Tx) {
 	w := tx.World()
 	updates := w.neighbourUpdates
 	limit := len(updates)
+	if m := w.conf.MaxNeighbourUpdatesPerTick; m > 0 && limit > m {
+		limit = m
+	}
+	// Updates queued while performing the ones below are always carried over
+	// to the next tick, so only updates postponed by the cap count as a hit.
+	capped := limit < len(updates)
 	for i := 0; i < limit; i++ {
 		update := updates[i]
 		pos, changedNeighbour := update.pos, update.neighbour
@@ -163,6 +198,9 @@ func (t ticker) performNeighbourUpdates(tx *Tx) {
 			}
 		}
 	}
+	if capped {
+		w.handleNeighbourUpdateCap(len(updates) - limit)
+	}
 	if len(w.neighbourUpdates) > limit {
 		remaining := w.neighbourUpdates[limit:]
 		copy(w.neighbourUpdates, remaining)
@@ -201,6 +239,7 @@ func (t ticker) tickBlocksRandomly(tx *Tx, loaders []*Loader, tick int64) {
 
 	blockEntities := w.scratchBlockEntities[:0]
 	randomBlocks := w.scratchRandom[:0]
+	sphere := w.conf.BlockActivationShape == ActivationSphere
 
 	for _, ref := range w.activeColumns {
 		if !columnWithinAreas(ref.pos, areas) {
@@ -210,7 +249,17 @@ func (t ticker) tickBlocksRandomly(tx *Tx, loaders []*Loader, tick int64) {
 		if c == nil {
 			continue
 		}
+		if c.inhabitedTime++; c.inhabitedTime%inhabitedTimeSaveInterval == 0 {
+			// Chunks are only saved if modified, so the inhabited time of
+			// chunks that are otherwise left unchanged is saved
+			// periodically, without the rest of the chunk if the Provider
+			// supports it.
+			c.inhabitedTimeChanged = true
+		}
 		for be := range c.BlockEntities {
+			if sphere && !subChunkWithinAreas(ref.pos, int32(be[1]>>4), areas) {
+				continue
+			}
 			blockEntities = append(blockEntities, be)
 		}
 
@@ -225,6 +274,9 @@ func (t ticker) tickBlocksRandomly(tx *Tx, loaders []*Loader, tick int64) {
 					// SubChunk is empty, so skip it right away.
 					continue
 				}
+				if sphere && !subChunkWithinAreas(ref.pos, int32(i+(tx.Range().Min()>>4)), areas) {
+					continue
+				}
 				// Generally we would want to make sure the block has its block entities, but provided blocks
 				// with block entities are generally ticked already, we are safe to assume that blocks
 				// implementing the RandomTicker don't rely on additional block entity data.
@@ -256,6 +308,37 @@ func (t ticker) tickBlocksRandomly(tx *Tx, loaders []*Loader, tick int64) {
 	w.scratchBlockEntities = blockEntities[:0]
 }
 
+// invalidateSimulatedAreas resets the active areas cached by isSimulated, so
+// that they are computed again the next time they are needed.
+func (w *World) invalidateSimulatedAreas() {
+	w.simulatedAreasCached = false
+}
+
+// isSimulated checks if the block at the position passed is within the active
+// area of any of the loaders in the World, meaning it is ticked. The active
+// areas are computed once and cached until the next tick, until a loader
+// moves or is closed, or until the tick range changes.
+func (w *World) isSimulated(pos cube.Pos) bool {
+	r := int32(w.tickRange())
+	if r == 0 {
+		return false
+	}
+	if !w.simulatedAreasCached || w.simulatedAreasRange != r {
+		viewers, loaders := w.allViewers()
+		w.releaseViewers(viewers)
+
+		areas := w.simulatedAreas[:0]
+		for _, loader := range loaders {
+			areas = append(areas, loader.activeArea(r))
+		}
+		w.simulatedAreas, w.simulatedAreasRange, w.simulatedAreasCached = areas, r, true
+	}
+	if w.conf.BlockActivationShape == ActivationSphere {
+		return subChunkWithinAreas(chunkPosFromBlockPos(pos), int32(pos[1]>>4), w.simulatedAreas)
+	}
+	return columnWithinAreas(chunkPosFromBlockPos(pos), w.simulatedAreas)
+}
+
 func columnWithinAreas(pos ChunkPos, areas []loaderActiveArea) bool {
 	for _, area := range areas {
 		dx := pos[0] - area.pos[0]
@@ -274,6 +357,18 @@ func columnWithinAreas(pos ChunkPos, areas []loaderActiveArea) bool {
 	return false
 }
 
+// subChunkWithinAreas checks if the sub chunk at index y in the column at pos
+// is within the spherical active area of any of the areas passed.
+func subChunkWithinAreas(pos ChunkPos, y int32, areas []loaderActiveArea) bool {
+	for _, area := range areas {
+		dx, dy, dz := int64(pos[0]-area.pos[0]), int64(y-area.y), int64(pos[1]-area.pos[1])
+		if dx*dx+dy*dy+dz*dz <= area.radiusSq {
+			return true
+		}
+	}
+	return false
+}
+
 // tickEntities ticks all entities in the world, making sure they are still located in the correct chunks and
 // updating where necessary.
 //
@@ -322,18 +417,27 @@ func (t ticker) tickEntities(tx *Tx, tick int64) {
 	// originating column so that we can update viewer lists or perform removals without having to search for the
 	// owning chunk again later in the tick.
 
+	var crowded []ChunkPos
+	stats := SimulationStats{ActiveColumns: len(w.activeColumns)}
 	for _, ref := range w.entityColumns {
 		col := ref.col
 		if col == nil || len(col.Entities) == 0 {
 			continue
 		}
+		stats.EntityColumns++
+		if limit := w.conf.ChunkEntityLimit; limit > 0 && len(col.Entities) > limit {
+			crowded = append(crowded, ref.pos)
+		}
 		if len(col.viewers) > 0 {
+			stats.ActiveEntities += len(col.Entities)
 			for _, handle := range col.Entities {
 				active = append(active, handle)
 				activeChunks[handle] = entityChunkRef{col: col, pos: ref.pos}
 			}
 			continue
 		}
+		stats.SleepingColumns++
+		stats.SleepingEntities += len(col.Entities)
 		if !lazyMaintenance {
 			continue
 		}
@@ -356,6 +460,13 @@ func (t ticker) tickEntities(tx *Tx, tick int64) {
 	w.scratchSleepingEntities = sleeping[:0]
 	clearEntityRefMap(activeChunks)
 	clearEntityRefMap(sleepingChunks)
+	w.simulationStats.Store(&stats)
+
+	// Crowded chunks are handled after all entities were ticked, so that
+	// Config.OnChunkEntityLimit may remove entities safely.
+	for _, pos := range crowded {
+		w.handleCrowdedChunk(tx, pos)
+	}
 }
 
 func (t ticker) tickEntityHandle(tx *Tx, tick int64, handle *EntityHandle, ref entityChunkRef, active bool) {
@@ -419,7 +530,7 @@ func (t ticker) tickEntityHandle(tx *Tx, tick int64, handle *EntityHandle, ref e
 			}
 			for v := range newChunk.viewers {
 				if _, ok := viewers[v]; !ok {
-					showEntity(ent, v)
+					w.showEntity(ent, v)
 				}
 			}
 		}
@@ -436,7 +547,7 @@ func (t ticker) tickEntityHandle(tx *Tx, tick int64, handle *EntityHandle, ref e
 		if tick < state.nextPassiveTick {
 			return
 		}
-		if delta := tick - state.lastTick; delta > 0 {
+		if delta := tick - state.lastTick; delta > 0 && !passiveMaintenanceExempt(handle) {
 			inc := time.Duration(delta) * (time.Second / 20)
 			handle.data.Age += inc
 			if handle.data.FireDuration > 0 {
@@ -446,8 +557,8 @@ func (t ticker) tickEntityHandle(tx *Tx, tick int64, handle *EntityHandle, ref e
 					handle.data.FireDuration -= inc
 				}
 			}
-			state.lastTick = tick
 		}
+		state.lastTick = tick
 		state.nextPassiveTick = tick + passiveMaintenanceInterval
 		if state.isItem && handle.data.Age >= 5*time.Minute {
 			if ent := loadEntity(); ent != nil {
@@ -457,7 +568,7 @@ func (t ticker) tickEntityHandle(tx *Tx, tick int64, handle *EntityHandle, ref e
 		return
 	}
 
-	if delta := tick - state.lastTick; delta > 1 {
+	if delta := tick - state.lastTick; delta > 1 && !passiveMaintenanceExempt(handle) {
 		// We collapsed multiple ticks: apply the same accounting vanilla would have done each frame so
 		// behaviours that rely on entity age or fire duration stay in sync even if an entity temporarily left
 		// the active area.
@@ -473,6 +584,15 @@ func (t ticker) tickEntityHandle(tx *Tx, tick int64, handle *EntityHandle, ref e
 	}
 	state.lastTick = tick
 	state.nextPassiveTick = tick + passiveMaintenanceInterval
+	if handle.data.NoTick {
+		// Frozen entities don't run their Tick method, which would otherwise
+		// advance their age and fire duration, so we do it here instead.
+		handle.data.Age += time.Second / 20
+		if handle.data.FireDuration > 0 {
+			handle.data.FireDuration = max(handle.data.FireDuration-time.Second/20, 0)
+		}
+		return
+	}
 	if !state.tickerChecked || state.isTicker {
 		// We must rebind the entity to the current transaction whenever it is about to tick. The bound
 		// Tx expires at the end of each frame, so behaviours that capture the Tx (fire, name tags, etc.) rely
@@ -480,6 +600,15 @@ func (t ticker) tickEntityHandle(tx *Tx, tick int64, handle *EntityHandle, ref e
 		// state.entity each tick; keep that contract so that helpers never observe a stale, closed Tx.
 		loadEntity()
 	}
+	if w.conf.VoidHandler != nil {
+		if ent := loadEntity(); ent != nil {
+			w.handleVoid(tx, ent)
+			if w.entities[handle] == nil {
+				// The handler removed the entity from the World.
+				return
+			}
+		}
+	}
 	if state.isTicker && state.ticker != nil {
 		state.ticker.Tick(tx, tick)
 	}
@@ -613,3 +742,21 @@ func (queue *scheduledTickQueue) add(ticks []scheduledTick) {
 		}
 	}
 }
+
+// idle checks if none of the viewers passed is an actual viewer, that is, a
+// Viewer other than NopViewer.
+func idle(viewers []Viewer) bool {
+	for _, v := range viewers {
+		if _, nop := v.(NopViewer); !nop {
+			return false
+		}
+	}
+	return true
+}
+
+// passiveMaintenanceExempt checks if the EntityType of an EntityHandle opted
+// out of passive maintenance by implementing PassiveMaintenanceExempt.
+func passiveMaintenanceExempt(handle *EntityHandle) bool {
+	e, ok := handle.t.(PassiveMaintenanceExempt)
+	return ok && e.ExemptFromPassiveMaintenance()
+}
@@ -0,0 +1,121 @@
+package world
+
+import (
+	"cmp"
+	"slices"
+)
+
+// MaxTicketRadius is the maximum radius in chunks of a forced chunk ticket.
+// Larger radii passed to Tx.ForceLoad are reduced to MaxTicketRadius.
+const MaxTicketRadius = 32
+
+// TicketInfo describes a forced chunk ticket, which keeps a square region of
+// chunks loaded regardless of whether any viewer or loader is near them.
+type TicketInfo struct {
+	// Owner is the owner of the ticket, such as the name of the plugin or
+	// system that added it. All tickets of an owner may be released at once
+	// using Tx.ReleaseForceOwner.
+	Owner string
+	// Name is the name of the ticket, unique per Owner.
+	Name string
+	// Center is the chunk at the centre of the region kept loaded.
+	Center ChunkPos
+	// Radius is the radius in chunks of the region kept loaded. A radius of 0
+	// keeps only the Center chunk loaded.
+	Radius int32
+}
+
+// Contains checks if the chunk at the position passed is kept loaded by the
+// ticket.
+func (t TicketInfo) Contains(pos ChunkPos) bool {
+	dx, dz := pos[0]-t.Center[0], pos[1]-t.Center[1]
+	return dx >= -t.Radius && dx <= t.Radius && dz >= -t.Radius && dz <= t.Radius
+}
+
+// ticketKey identifies a TicketInfo in a World.
+type ticketKey struct {
+	owner, name string
+}
+
+// Tickets returns all forced chunk tickets currently held in the World,
+// sorted by owner and name.
+func (w *World) Tickets() []TicketInfo {
+	w.ticketMu.Lock()
+	tickets := make([]TicketInfo, 0, len(w.tickets))
+	for _, t := range w.tickets {
+		tickets = append(tickets, t)
+	}
+	w.ticketMu.Unlock()
+
+	slices.SortFunc(tickets, func(a, b TicketInfo) int {
+		return cmp.Or(cmp.Compare(a.Owner, b.Owner), cmp.Compare(a.Name, b.Name))
+	})
+	return tickets
+}
+
+// forceLoad adds a ticket to the World and loads all chunks in its region. An
+// existing ticket with the same owner and name is replaced.
+func (w *World) forceLoad(t TicketInfo) {
+	t.Radius = min(max(t.Radius, 0), MaxTicketRadius)
+
+	w.ticketMu.Lock()
+	if w.tickets == nil {
+		w.tickets = make(map[ticketKey]TicketInfo)
+	}
+	w.tickets[ticketKey{owner: t.Owner, name: t.Name}] = t
+	w.ticketMu.Unlock()
+
+	for x := t.Center[0] - t.Radius; x <= t.Center[0]+t.Radius; x++ {
+		for z := t.Center[1] - t.Radius; z <= t.Center[1]+t.Radius; z++ {
+			w.chunk(ChunkPos{x, z})
+		}
+	}
+}
+
+// releaseForce removes the ticket with the owner and name passed from the
+// World. The chunks it kept loaded are unloaded the next time unused chunks
+// are collected.
+func (w *World) releaseForce(owner, name string) bool {
+	w.ticketMu.Lock()
+	defer w.ticketMu.Unlock()
+
+	k := ticketKey{owner: owner, name: name}
+	if _, ok := w.tickets[k]; !ok {
+		return false
+	}
+	delete(w.tickets, k)
+	return true
+}
+
+// releaseForceOwner removes all tickets of the owner passed from the World and
+// returns the number of tickets removed.
+func (w *World) releaseForceOwner(owner string) int {
+	w.ticketMu.Lock()
+	defer w.ticketMu.Unlock()
+
+	n := 0
+	for k := range w.tickets {
+		if k.owner == owner {
+			delete(w.tickets, k)
+			n++
+		}
+	}
+	return n
+}
+
+// forcedChunks returns a function that checks if a chunk is kept loaded by
+// any of the tickets in the World at the time forcedChunks is called.
+func (w *World) forcedChunks() func(pos ChunkPos) bool {
+	w.ticketMu.Lock()
+	tickets := make([]TicketInfo, 0, len(w.tickets))
+	for _, t := range w.tickets {
+		tickets = append(tickets, t)
+	}
+	w.ticketMu.Unlock()
+
+	return func(pos ChunkPos) bool {
+		return slices.ContainsFunc(tickets, func(t TicketInfo) bool {
+			return t.Contains(pos)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package world
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestForceLoadTickets(t *testing.T) {
+	w := newTestWorld(t, Config{})
+
+	<-w.Exec(func(tx *Tx) {
+		tx.ForceLoad("plugin", "spawn", ChunkPos{0, 0}, 1)
+		tx.ForceLoad("plugin", "arena", ChunkPos{10, 10}, 0)
+		tx.ForceLoad("other", "farm", ChunkPos{-10, 0}, 0)
+
+		tickets := w.Tickets()
+		if len(tickets) != 3 || tickets[0].Owner != "other" || tickets[1].Name != "arena" || tickets[2].Name != "spawn" {
+			t.Fatalf("unexpected tickets: %+v", tickets)
+		}
+
+		w.CollectGarbage(tx)
+		for _, pos := range []ChunkPos{{-1, -1}, {1, 1}, {10, 10}, {-10, 0}} {
+			if loaded, _ := tx.ChunkState(pos); !loaded {
+				t.Fatalf("expected forced chunk %v to stay loaded", pos)
+			}
+		}
+
+		// Releasing all tickets of an owner, as done when a plugin is
+		// disabled, lets its chunks be collected.
+		if n := tx.ReleaseForceOwner("plugin"); n != 2 {
+			t.Fatalf("expected 2 tickets to be released, got %v", n)
+		}
+		w.CollectGarbage(tx)
+		for _, pos := range []ChunkPos{{0, 0}, {10, 10}} {
+			if loaded, _ := tx.ChunkState(pos); loaded {
+				t.Fatalf("expected released chunk %v to be unloaded", pos)
+			}
+		}
+		if loaded, _ := tx.ChunkState(ChunkPos{-10, 0}); !loaded {
+			t.Fatalf("expected chunk of another owner to stay loaded")
+		}
+
+		// Radii that would overflow are limited to MaxTicketRadius rather
+		// than loading an unbounded area.
+		tx.ForceLoad("other", "huge", ChunkPos{}, math.MaxInt)
+		if tickets := w.Tickets(); tickets[1].Name != "huge" || tickets[1].Radius != MaxTicketRadius {
+			t.Fatalf("expected radius to be limited to %v, got %+v", MaxTicketRadius, tickets)
+		}
+		tx.ReleaseForce("other", "huge")
+
+		if !tx.ReleaseForce("other", "farm") || tx.ReleaseForce("other", "farm") {
+			t.Fatalf("expected ticket to be released exactly once")
+		}
+		if len(w.Tickets()) != 0 {
+			t.Fatalf("expected no tickets to remain, got %+v", w.Tickets())
+		}
+	})
+}
+
+func TestForceLoadTicketOutlivesLoader(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	loader := NewLoader(0, w, NopViewer{})
+	<-w.Exec(func(tx *Tx) { loader.Move(tx, mgl64.Vec3{}) })
+	waitChunkLoaded(t, w, loader, ChunkPos{})
+
+	<-w.Exec(func(tx *Tx) {
+		tx.ForceLoad("plugin", "spawn", ChunkPos{}, 0)
+		// The chunk is kept loaded by the ticket once the last loader
+		// viewing it leaves.
+		loader.Close(tx)
+		if loaded, _ := tx.ChunkState(ChunkPos{}); !loaded {
+			t.Errorf("expected forced chunk to stay loaded after its last loader left")
+			return
+		}
+		tx.ReleaseForce("plugin", "spawn")
+		w.CollectGarbage(tx)
+		if loaded, _ := tx.ChunkState(ChunkPos{}); loaded {
+			t.Errorf("expected chunk to be unloaded after releasing its ticket")
+		}
+	})
+}
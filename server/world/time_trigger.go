@@ -0,0 +1,111 @@
+package world
+
+import (
+	"slices"
+	"sync"
+)
+
+// dayLength is the length of a day in a World in ticks.
+const dayLength = 24000
+
+// timeTrigger is a function registered using World.AtTime or
+// World.RepeatAtTime that is run when the time of the World passes a specific
+// time of day.
+type timeTrigger struct {
+	time   int64
+	fn     ExecFunc
+	repeat bool
+}
+
+// timeTriggers holds the timeTriggers registered in a World.
+type timeTriggers struct {
+	mu       sync.Mutex
+	triggers []*timeTrigger
+
+	// last is the time at which the triggers were last checked. It is only
+	// accessed from the tick of the World.
+	last        int64
+	initialised bool
+}
+
+// AtTime runs fn once, in a transaction of the World, the first time the time
+// of the World passes the time of day passed, which is taken modulo 24000.
+// The function returned may be called to cancel fn if it was not run yet.
+// Time triggers are only checked while the World is ticking, so fn does not
+// run while no players are in the World. Setting the time of the World
+// backwards does not run any functions, and functions are run at most once
+// per tick, even if the time skips multiple days.
+func (w *World) AtTime(time int, fn ExecFunc) (cancel func()) {
+	return w.addTimeTrigger(time, fn, false)
+}
+
+// RepeatAtTime runs fn, in a transaction of the World, every time the time of
+// the World passes the time of day passed, which is taken modulo 24000, such
+// as at dawn or dusk every day. The function returned may be called to stop
+// running fn. RepeatAtTime otherwise behaves like AtTime.
+func (w *World) RepeatAtTime(time int, fn ExecFunc) (cancel func()) {
+	return w.addTimeTrigger(time, fn, true)
+}
+
+// addTimeTrigger registers a timeTrigger and returns a function to cancel it.
+func (w *World) addTimeTrigger(time int, fn ExecFunc, repeat bool) func() {
+	t := &timeTrigger{time: int64(((time % dayLength) + dayLength) % dayLength), fn: fn, repeat: repeat}
+	w.timeTriggers.mu.Lock()
+	w.timeTriggers.triggers = append(w.timeTriggers.triggers, t)
+	w.timeTriggers.mu.Unlock()
+
+	return func() {
+		_ = w.timeTriggers.remove(t)
+	}
+}
+
+// remove removes a timeTrigger. False is returned if the trigger was already
+// removed.
+func (t *timeTriggers) remove(trigger *timeTrigger) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if i := slices.Index(t.triggers, trigger); i != -1 {
+		t.triggers = slices.Delete(t.triggers, i, i+1)
+		return true
+	}
+	return false
+}
+
+// registered checks if a timeTrigger is still registered.
+func (t *timeTriggers) registered(trigger *timeTrigger) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return slices.Contains(t.triggers, trigger)
+}
+
+// runTimeTriggers runs the time triggers of which the time of day was passed
+// since they were last checked, with now being the current time of the World.
+func (w *World) runTimeTriggers(tx *Tx, now int64) {
+	t := &w.timeTriggers
+	prev := t.last
+	t.last = now
+	if !t.initialised {
+		t.initialised = true
+		return
+	}
+	if now <= prev {
+		return
+	}
+	t.mu.Lock()
+	triggers := slices.Clone(t.triggers)
+	t.mu.Unlock()
+
+	for _, trigger := range triggers {
+		// next is the first time after prev at which the time of day equals
+		// that of the trigger.
+		next := prev + 1 + ((trigger.time-prev-1)%dayLength+dayLength)%dayLength
+		if next > now {
+			continue
+		}
+		// Triggers may be cancelled by the functions of other triggers.
+		if trigger.repeat && !t.registered(trigger) || !trigger.repeat && !t.remove(trigger) {
+			continue
+		}
+		trigger.fn(tx)
+	}
+}
@@ -0,0 +1,38 @@
+package world
+
+import "testing"
+
+func TestTimeTriggers(t *testing.T) {
+	w := newTestWorld(t, Config{})
+
+	var dawn, once, cancelled int
+	w.RepeatAtTime(1000, func(*Tx) { dawn++ })
+	w.AtTime(13000, func(*Tx) { once++ })
+	cancel := w.RepeatAtTime(24000+6000, func(*Tx) { cancelled++ })
+	cancel()
+
+	<-w.Exec(func(tx *Tx) {
+		// Start tracking at a fixed time, regardless of earlier ticks.
+		w.timeTriggers.initialised = false
+		for tim := int64(0); tim <= 3*dayLength; tim += 100 {
+			w.runTimeTriggers(tx, tim)
+		}
+		if dawn != 3 {
+			t.Fatalf("expected repeating trigger to run 3 times, ran %v times", dawn)
+		}
+		if once != 1 {
+			t.Fatalf("expected trigger to run once, ran %v times", once)
+		}
+		if cancelled != 0 {
+			t.Fatalf("expected cancelled trigger not to run, ran %v times", cancelled)
+		}
+
+		// Setting the time backwards does not run triggers, and skipping
+		// multiple days runs them only once.
+		w.runTimeTriggers(tx, 0)
+		w.runTimeTriggers(tx, 10*dayLength)
+		if dawn != 4 {
+			t.Fatalf("expected repeating trigger to run once more after skipping days, ran %v times", dawn)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package world
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestSetTargetTPS(t *testing.T) {
+	w := newTestWorld(t, Config{})
+
+	if got := w.TickInterval(); got != time.Second/20 {
+		t.Fatalf("expected default tick interval %v, got %v", time.Second/20, got)
+	}
+	w.SetTargetTPS(40)
+	if got := w.TickInterval(); got != time.Second/40 {
+		t.Fatalf("expected tick interval %v, got %v", time.Second/40, got)
+	}
+	if got := w.TargetTPS(); got != 40 {
+		t.Fatalf("expected target TPS 40, got %v", got)
+	}
+	w.SetTargetTPS(0)
+	w.SetTargetTPS(-5)
+	if got := w.TickInterval(); got != time.Second/40 {
+		t.Fatalf("expected invalid target TPS to be ignored, got interval %v", got)
+	}
+	if tps := w.TPS(); tps != 40 {
+		t.Fatalf("expected TPS to be reset to the new target, got %v", tps)
+	}
+}
+
+// slowEntityType is an EntityType of which the entities slow down every tick
+// of the World while slow is true.
+type slowEntityType struct {
+	testEntityType
+	slow *atomic.Bool
+}
+
+func (t slowEntityType) Open(_ *Tx, handle *EntityHandle, data *EntityData) Entity {
+	return &slowEntity{testEntity: testEntity{handle: handle, data: data}, slow: t.slow}
+}
+
+type slowEntity struct {
+	testEntity
+	slow *atomic.Bool
+}
+
+func (e *slowEntity) Tick(*Tx, int64) {
+	if e.slow.Load() {
+		time.Sleep(time.Second / 10)
+	}
+}
+
+func TestTPSCallbacks(t *testing.T) {
+	warning, recovered := make(chan float64, 1), make(chan float64, 1)
+	w := newTestWorld(t, Config{
+		OnTPSWarning: func(tps float64) { warning <- tps },
+		OnTPSRecover: func(tps float64) { recovered <- tps },
+	})
+	loader := NewLoader(2, w, nopViewer{})
+	t.Cleanup(func() {
+		<-w.Exec(loader.Close)
+	})
+
+	slow := new(atomic.Bool)
+	slow.Store(true)
+	<-w.Exec(func(tx *Tx) {
+		tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{4, 64, 4}}.New(slowEntityType{slow: slow}, testEntityConfig{}))
+		loader.Move(tx, mgl64.Vec3{})
+		loader.Load(tx, 25)
+	})
+
+	select {
+	case tps := <-warning:
+		if tps >= 19 {
+			t.Fatalf("expected warning TPS below threshold, got %v", tps)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("expected TPS warning callback to be called")
+	}
+	slow.Store(false)
+
+	select {
+	case tps := <-recovered:
+		if tps < 19 {
+			t.Fatalf("expected recovered TPS above threshold, got %v", tps)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("expected TPS recover callback to be called")
+	}
+}
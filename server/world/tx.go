@@ -8,6 +8,7 @@ import (
 
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/world/chunk"
 	"github.com/go-gl/mathgl/mgl64"
 )
 
@@ -25,6 +26,14 @@ func (tx *Tx) Range() cube.Range {
 	return tx.w.ra
 }
 
+// InVoid reports if an entity at height y has fallen into the void and should
+// apply its default void behaviour, such as taking void damage. InVoid always
+// returns false if Config.VoidHandler is set, as the handler is then
+// responsible for entities in the void.
+func (tx *Tx) InVoid(y float64) bool {
+	return tx.World().inVoid(y)
+}
+
 // SetBlock writes a block to the position passed. If a chunk is not yet loaded
 // at that position, the chunk is first loaded or generated if it could not be
 // found in the world save. SetBlock panics if the block passed has not yet
@@ -43,6 +52,31 @@ func (tx *Tx) SetBlock(pos cube.Pos, b Block, opts *SetOpts) {
 	tx.World().setBlock(pos, b, opts)
 }
 
+// MoveBlock moves the block at a cube.Pos, together with its block entity
+// data such as the contents of a chest, to another cube.Pos and sets the block
+// at the original position to air. Neighbouring blocks are only updated once
+// both positions were changed. A SetOpts struct may be passed to modify the
+// behaviour of MoveBlock like with SetBlock. False is returned if the block
+// could not be moved, for example if it is air or either position is out of
+// bounds.
+func (tx *Tx) MoveBlock(from, to cube.Pos, opts *SetOpts) bool {
+	return tx.World().moveBlock(from, to, opts)
+}
+
+// BlockNBT returns the NBT data of the block at a cube.Pos, such as the text
+// of a sign or the contents of a chest. False is returned if the block at that
+// position does not carry NBT data.
+func (tx *Tx) BlockNBT(pos cube.Pos) (map[string]any, bool) {
+	return tx.World().blockNBT(pos)
+}
+
+// SetBlockNBT decodes the NBT data passed into the block at a cube.Pos and
+// updates the block for all viewers. False is returned, and nothing is
+// changed, if the block at that position does not carry NBT data.
+func (tx *Tx) SetBlockNBT(pos cube.Pos, data map[string]any) bool {
+	return tx.World().setBlockNBT(pos, data)
+}
+
 func (tx *Tx) ChunkLoaded(pos ChunkPos) bool {
 	_, ready := tx.ChunkState(pos)
 	return ready
@@ -68,6 +102,71 @@ func (tx *Tx) ChunkState(pos ChunkPos) (loaded bool, ready bool) {
 	return true, c.Ready()
 }
 
+// ExportColumn returns the loaded chunk at the position passed as a
+// chunk.Column, as it would be written to a Provider, including its entities,
+// block entities and scheduled block updates. The chunk is not saved. The
+// chunk.Column returned is a copy that remains valid after the transaction.
+// False is returned if the chunk is not loaded or not yet generated.
+func (tx *Tx) ExportColumn(pos ChunkPos) (*chunk.Column, bool) {
+	return tx.World().exportColumn(pos)
+}
+
+// RepairChunk removes orphaned block entities from the loaded chunk at the
+// position passed. These are block entities at positions of which the block
+// does not have a block entity, for example because it was replaced with air,
+// which may otherwise lead to stale NBT data being saved and loaded again.
+// The number of block entities removed is returned and logged. RepairChunk
+// does nothing if the chunk is not loaded. Chunks loaded from the Provider
+// never hold such block entities, as they are dropped when reading them.
+func (tx *Tx) RepairChunk(pos ChunkPos) int {
+	return tx.World().repairChunk(pos)
+}
+
+// MarkChunkModified marks the chunk at the position passed as modified, so
+// that it is written to the Provider when the World is next saved or the
+// chunk is unloaded. This may be used to persist changes made to a chunk
+// that the World does not track itself, such as changes to the internals of
+// a block entity. MarkChunkModified does nothing if the chunk is not loaded.
+func (tx *Tx) MarkChunkModified(pos ChunkPos) {
+	tx.World().markChunkModified(pos)
+}
+
+// ForceLoad adds a forced chunk ticket to the World, which loads all chunks
+// within the radius passed around a chunk and keeps them loaded until the
+// ticket is released, even if no viewers are near them. The owner and name
+// identify the ticket: Adding a ticket with the same owner and name replaces
+// the existing one. The radius is limited to MaxTicketRadius.
+func (tx *Tx) ForceLoad(owner, name string, center ChunkPos, radius int) {
+	radius = min(max(radius, 0), MaxTicketRadius)
+	tx.World().forceLoad(TicketInfo{Owner: owner, Name: name, Center: center, Radius: int32(radius)})
+}
+
+// ReleaseForce releases the forced chunk ticket with the owner and name
+// passed, allowing its chunks to be unloaded once no longer in use. False is
+// returned if no such ticket existed.
+func (tx *Tx) ReleaseForce(owner, name string) bool {
+	return tx.World().releaseForce(owner, name)
+}
+
+// ReleaseForceOwner releases all forced chunk tickets of the owner passed,
+// for example when the plugin that added them is disabled. The number of
+// tickets released is returned.
+func (tx *Tx) ReleaseForceOwner(owner string) int {
+	return tx.World().releaseForceOwner(owner)
+}
+
+// ChunkStats reports the number of entities, block entities and scheduled
+// block updates in the chunk at the position passed, without saving or
+// loading the chunk. It may be used to find chunks that are expensive to tick.
+// If the chunk is not loaded, ok is false.
+func (tx *Tx) ChunkStats(pos ChunkPos) (entities, blockEntities, scheduledTicks int, ok bool) {
+	c, ok := tx.w.chunks[pos]
+	if !ok {
+		return 0, 0, 0, false
+	}
+	return len(c.Entities), len(c.BlockEntities), len(tx.w.scheduledUpdates.fromChunk(pos)), true
+}
+
 // Block reads a block from the position passed. If a chunk is not yet loaded
 // at that position, the chunk is loaded, or generated if it could not be found
 // in the world save, and the block returned.
@@ -75,6 +174,66 @@ func (tx *Tx) Block(pos cube.Pos) Block {
 	return tx.World().block(pos)
 }
 
+// Source returns a BlockSource backed by the Tx, which may be passed to the
+// BBox and FaceSolid methods of a BlockModel to compute the model of any block
+// against the blocks in the World. The BlockSource is valid only for as long
+// as the Tx is: using it after the transaction finishes causes a panic.
+func (tx *Tx) Source() BlockSource {
+	return tx
+}
+
+// EachBlockInChunk calls fn with the position and runtime ID of every non-air
+// block in the loaded chunk at the ChunkPos passed, on all layers. The chunk's
+// sub chunks are read directly, so callers can filter on runtime IDs (see
+// BlockRuntimeID) before resolving them to a Block using BlockByRuntimeID.
+// Empty sub chunks are skipped. Iteration stops as soon as fn returns false.
+// EachBlockInChunk does not load chunks: Nothing happens if the chunk is not
+// loaded or has not finished generating.
+func (tx *Tx) EachBlockInChunk(pos ChunkPos, fn func(pos cube.Pos, rid uint32) bool) {
+	tx.World().eachBlockInChunk(pos, fn)
+}
+
+// IsSimulated checks if the block at the position passed is currently being
+// simulated, meaning it lies within the simulation distance of at least one
+// loader in the World, in the shape set by Config.BlockActivationShape.
+// Blocks outside of these areas are not ticked randomly and block entities
+// outside of them are not ticked. Entities are ticked in all chunks that have
+// a viewer, regardless of these areas.
+func (tx *Tx) IsSimulated(pos cube.Pos) bool {
+	return tx.World().isSimulated(pos)
+}
+
+// HideEntityFrom hides an Entity from a specific Viewer, even if the Viewer
+// has the chunk of the Entity loaded. The Entity remains hidden until
+// Tx.ShowEntityTo is called, or until the Entity or Viewer leaves the World.
+// Viewers that implement EntityHidingViewer, such as the sessions of players,
+// also stop receiving any updates of the Entity, such as its movement. Other
+// viewers only have the Entity despawned and are not shown it again.
+func (tx *Tx) HideEntityFrom(e Entity, v Viewer) {
+	tx.World().hideEntityFrom(e, v)
+}
+
+// ShowEntityTo shows an Entity previously hidden using Tx.HideEntityFrom to
+// the Viewer passed again.
+func (tx *Tx) ShowEntityTo(e Entity, v Viewer) {
+	tx.World().showEntityTo(e, v)
+}
+
+// EntityHiddenFrom checks if an Entity was hidden from the Viewer passed using
+// Tx.HideEntityFrom.
+func (tx *Tx) EntityHiddenFrom(e Entity, v Viewer) bool {
+	return tx.World().entityHiddenFrom(e, v)
+}
+
+// ValidateBlockEntities checks every block entity in the loaded chunks of the
+// World for a clean NBT round-trip, as performed when the chunk is saved and
+// loaded again. An error is returned for each block entity that fails to
+// encode or decode, or whose data changes in the process. It is intended as a
+// diagnostic tool when developing custom blocks.
+func (tx *Tx) ValidateBlockEntities() []error {
+	return tx.World().validateBlockEntities()
+}
+
 // Liquid attempts to return a Liquid block at the position passed. This
 // Liquid may be in the foreground or in any other layer. If found, the Liquid
 // is returned. If not, the bool returned is false.
@@ -82,6 +241,20 @@ func (tx *Tx) Liquid(pos cube.Pos) (Liquid, bool) {
 	return tx.World().liquid(pos)
 }
 
+// HardenLiquid attempts to harden the Liquid at the position passed based on
+// the blocks surrounding it, as happens when the liquid is updated. Lava next
+// to water, for example, hardens into obsidian if it is a source block or into
+// cobblestone otherwise. The hardening passes through
+// Handler.HandleLiquidHarden, so it may be cancelled by the Handler of the
+// World. If the liquid hardened, the resulting block and true are returned.
+func (tx *Tx) HardenLiquid(pos cube.Pos) (Block, bool) {
+	l, ok := tx.Liquid(pos)
+	if !ok || !l.Harden(pos, tx, nil) {
+		return nil, false
+	}
+	return tx.Block(pos), true
+}
+
 // SetLiquid sets a Liquid at a specific position in the World. Unlike
 // SetBlock, SetLiquid will not necessarily overwrite any existing blocks. It
 // will instead be in the same position as a block currently there, unless
@@ -103,12 +276,33 @@ func (tx *Tx) BuildStructure(pos cube.Pos, s Structure) {
 	tx.World().buildStructure(pos, s)
 }
 
+// ReplaceBlocks replaces every block within the cube.BBox passed for which
+// match returns true with the Block passed, returning the number of blocks
+// that were replaced. The box is clipped to the Range of the World. Changed
+// chunks are sent to viewers as a whole, similarly to BuildStructure, and no
+// block updates are performed. Instead of an event for every block, a single
+// Handler.HandleBulkBlockChange event is called, which may cancel the whole
+// operation.
+//
+// match must only depend on the Block passed: To keep scans of large boxes
+// fast, its result is cached per block state and reused for every block
+// without a block entity in the same state. Blocks with a block entity are
+// passed to match individually, including their block entity data. Only blocks
+// in the first layer are matched. Liquids in the second layer, such as the
+// water of waterlogged blocks, are never passed to match, but are removed if
+// the new Block cannot hold them, like SetBlock does.
+func (tx *Tx) ReplaceBlocks(box cube.BBox, match func(Block) bool, with Block) int {
+	return tx.World().replaceBlocks(tx, box, match, with)
+}
+
 // ScheduleBlockUpdate schedules a block update at the position passed for the
 // block type passed after a specific delay. If the block at that position does
 // not handle block updates, nothing will happen.
 // Block updates are both block and position specific. A block update is only
 // scheduled if no block update with the same position and block type is
 // already scheduled at a later time than the newly scheduled update.
+// The delay of updates scheduled for a Liquid is scaled by
+// Config.LiquidTickMultiplier.
 func (tx *Tx) ScheduleBlockUpdate(pos cube.Pos, b Block, delay time.Duration) {
 	tx.World().scheduleBlockUpdate(pos, b, delay)
 }
@@ -126,6 +320,38 @@ func (tx *Tx) HighestBlock(x, z int) int {
 	return tx.World().highestBlock(x, z)
 }
 
+// MayBuild checks if the BuildPolicy of the World, as set using
+// World.SetBuildPolicy, allows the Entity passed to perform a BuildAction at
+// a position. False is also returned if the position is within the spawn
+// protection radius of the World and the Entity may not bypass it. True is
+// returned if no BuildPolicy is set and the position is not protected.
+func (tx *Tx) MayBuild(e Entity, pos cube.Pos, action BuildAction) bool {
+	return tx.World().mayBuild(e, pos, action)
+}
+
+// FaceSolid checks if the face passed of the block at a position is solid,
+// according to the Model of the block. This is the same check the World uses
+// to find out if a block is supported by another block or obstructs rain.
+func (tx *Tx) FaceSolid(pos cube.Pos, face cube.Face) bool {
+	return tx.World().faceSolid(pos, face)
+}
+
+// SolidGroundBelow scans down from the position passed and returns the
+// position of the first block with a solid top face, which is a block that
+// other blocks or entities may stand on. The block at pos itself is not
+// checked. False is returned if no such block exists in the World below pos.
+func (tx *Tx) SolidGroundBelow(pos cube.Pos) (cube.Pos, bool) {
+	return tx.World().solidGroundBelow(pos)
+}
+
+// HighestBlockExcluding looks up the highest non-air block in the World at a
+// specific x and z for which exclude returns false. This may be used to find
+// the ground below leaves or water, for example. The minimum Y of the World is
+// returned if all blocks in the column are air or excluded.
+func (tx *Tx) HighestBlockExcluding(x, z int, exclude func(Block) bool) int {
+	return tx.World().highestBlockExcluding(x, z, exclude)
+}
+
 // Light returns the light level at the position passed. This is the highest of
 // the sky- and block light. The light value returned is a value in the range
 // 0-15, where 0 means there is no light present, whereas 15 means the block is
@@ -149,6 +375,16 @@ func (tx *Tx) SetBiome(pos cube.Pos, b Biome) {
 	tx.World().setBiome(pos, b)
 }
 
+// SetBiomes sets the biomes in the cuboid starting at origin and spanning dims
+// blocks on the x, y and z axes. at is called for every position in the
+// cuboid with coordinates relative to origin and returns the Biome to set
+// there, or nil to leave the biome unchanged. Positions outside the Range of
+// the World are skipped. SetBiomes is considerably faster than many separate
+// calls to SetBiome, as every affected chunk is only sent to viewers once.
+func (tx *Tx) SetBiomes(origin cube.Pos, dims [3]int, at func(x, y, z int) Biome) {
+	tx.World().setBiomes(origin, dims, at)
+}
+
 // Biome reads the Biome at the position passed. If a chunk is not yet loaded
 // at that position, the chunk is loaded, or generated if it could not be found
 // in the world save, and the Biome returned.
@@ -203,12 +439,77 @@ func (tx *Tx) PlayEntityAnimation(e Entity, a EntityAnimation) {
 	tx.World().releaseViewers(viewers)
 }
 
+// SetVelocity sets the velocity of an Entity to v, in blocks/tick, and shows
+// the new velocity to all viewers of the entity. For players, the velocity is
+// sent to the client controlling the player. False is returned if the Entity
+// does not implement VelocityEntity, in which case nothing happens.
+func (tx *Tx) SetVelocity(e Entity, v mgl64.Vec3) bool {
+	ve, ok := e.(VelocityEntity)
+	if !ok {
+		return false
+	}
+	ve.SetVelocity(v)
+	if vb, ok := ve.(VelocityBroadcaster); ok && vb.BroadcastsVelocity() {
+		return true
+	}
+	viewers := tx.World().viewersOf(e.Position())
+	for _, viewer := range viewers {
+		viewer.ViewEntityVelocity(e, v)
+	}
+	tx.World().releaseViewers(viewers)
+	return true
+}
+
+// ApplyImpulse adds delta, in blocks/tick, to the velocity of an Entity, such
+// as to knock it back or launch it into the air. Like SetVelocity, the new
+// velocity is shown to all viewers of the entity, and false is returned if
+// the Entity does not implement VelocityEntity.
+func (tx *Tx) ApplyImpulse(e Entity, delta mgl64.Vec3) bool {
+	ve, ok := e.(VelocityEntity)
+	if !ok {
+		return false
+	}
+	return tx.SetVelocity(e, ve.Velocity().Add(delta))
+}
+
+// NearbyBlockEntities returns the block entities, such as chests, at most
+// radius blocks away from centre for which match returns true. Only block
+// entities in loaded chunks are returned, sorted by their distance to centre.
+// A nil match returns all block entities within the radius.
+func (tx *Tx) NearbyBlockEntities(centre cube.Pos, radius int, match func(Block) bool) []BlockEntityInfo {
+	return tx.World().nearbyBlockEntities(centre, radius, match)
+}
+
+// PendingNeighbourUpdates returns the neighbour updates that are queued to be
+// performed at the end of the current or next tick, in the order in which
+// they will be performed. The slice returned is a copy and may be used to
+// debug update loops, for example of redstone components.
+func (tx *Tx) PendingNeighbourUpdates() []NeighbourUpdateInfo {
+	return tx.World().pendingNeighbourUpdates()
+}
+
 // PlaySound plays a sound at a specific position in the World. Viewers of that
 // position will be able to hear the sound if they are close enough.
 func (tx *Tx) PlaySound(pos mgl64.Vec3, s Sound) {
 	tx.World().playSound(tx, pos, s)
 }
 
+// PlaySoundAt plays a sound at a specific position in the World, without an
+// entity producing it, such as for ambient effects. It is equivalent to
+// PlaySound.
+func (tx *Tx) PlaySoundAt(pos mgl64.Vec3, s Sound) {
+	tx.World().playSound(tx, pos, s)
+}
+
+// PlaySoundRadius plays a sound at a specific position in the World, like
+// PlaySound. Unlike PlaySound, which plays the sound only to viewers of the
+// chunk that the position is in, PlaySoundRadius plays it to the viewers of all
+// chunks within the radius passed, so that loud sounds such as explosions may
+// be heard from further away.
+func (tx *Tx) PlaySoundRadius(pos mgl64.Vec3, s Sound, radius float64) {
+	tx.World().playSoundRadius(tx, pos, s, radius)
+}
+
 // AddEntity adds an EntityHandle to a World. The Entity will be visible to all
 // viewers of the World that have the chunk at the EntityHandle's position. If
 // the chunk that the EntityHandle is in is not yet loaded, it will first be
@@ -218,6 +519,18 @@ func (tx *Tx) AddEntity(e *EntityHandle) Entity {
 	return tx.World().addEntity(tx, e)
 }
 
+// AddEntities adds multiple EntityHandles to a World, like AddEntity does for
+// each of them. AddEntities is more efficient when adding many entities at
+// once, such as a shower of items, because the handles are grouped by chunk:
+// Every chunk is loaded and has its set of viewers walked once rather than
+// once per entity. Viewers implementing EntityBatchViewer are shown all
+// entities added to a chunk at once. The entities returned are in the same
+// order as the handles passed. AddEntities panics if any of the EntityHandles
+// is already in a world.
+func (tx *Tx) AddEntities(handles []*EntityHandle) []Entity {
+	return tx.World().addEntities(tx, handles)
+}
+
 // RemoveEntity removes an Entity from the World that is currently present in
 // it. Any viewers of the Entity will no longer be able to see it.
 // RemoveEntity returns the EntityHandle of the Entity. After removing an Entity
@@ -232,16 +545,65 @@ func (tx *Tx) EntitiesWithin(box cube.BBox) iter.Seq[Entity] {
 	return tx.World().entitiesWithin(tx, box)
 }
 
+// NearestEntity returns the Entity closest to pos that is at most maxDist
+// blocks away and for which filter returns true, together with its distance
+// to pos. If filter is nil, all entities are considered. Only entities in
+// loaded chunks are searched. The bool returned is false if no matching
+// Entity was found.
+func (tx *Tx) NearestEntity(pos mgl64.Vec3, maxDist float64, filter func(Entity) bool) (Entity, float64, bool) {
+	return tx.World().nearestEntity(tx, pos, maxDist, filter)
+}
+
+// RaycastBlock finds the first block with a collision box hit by a ray
+// starting at origin in the direction dir, at most maxDist blocks away. The
+// position of the block and the face of the block that the ray hit are
+// returned. Only chunks that are already loaded are read: Blocks in unloaded
+// chunks are treated as air.
+func (tx *Tx) RaycastBlock(origin, dir mgl64.Vec3, maxDist float64) (cube.Pos, cube.Face, bool) {
+	return tx.World().raycastBlock(origin, dir, maxDist)
+}
+
+// HasLineOfSight checks if no block with a collision box is between the two
+// positions passed, as is done by RaycastBlock.
+func (tx *Tx) HasLineOfSight(from, to mgl64.Vec3) bool {
+	return tx.World().hasLineOfSight(from, to)
+}
+
+// RaycastEntity finds the first Entity hit by a ray starting at origin in the
+// direction dir, at most maxDist blocks away, for which filter returns true.
+// If filter is nil, all entities are considered. The Entity hit is returned
+// together with the point at which the ray enters its bounding box. Only
+// entities in loaded chunks are found. The bool returned is false if no
+// Entity was hit.
+func (tx *Tx) RaycastEntity(origin, dir mgl64.Vec3, maxDist float64, filter func(Entity) bool) (Entity, mgl64.Vec3, bool) {
+	return tx.World().raycastEntity(tx, origin, dir, maxDist, filter)
+}
+
 // Entities returns an iterator that yields all entities in the World.
 func (tx *Tx) Entities() iter.Seq[Entity] {
 	return tx.World().allEntities(tx)
 }
 
+// EntityByID looks up an entity in the World by the ID it is persisted under
+// in chunk data, which is derived from the last 8 bytes of the UUID of its
+// EntityHandle. Only loaded entities are found. If no entity with the ID is
+// loaded, false is returned.
+func (tx *Tx) EntityByID(id int64) (Entity, bool) {
+	return tx.World().entityByID(tx, id)
+}
+
 // Players returns an iterator that yields all player entities in the World.
 func (tx *Tx) Players() iter.Seq[Entity] {
 	return tx.World().allPlayers(tx)
 }
 
+// PlayersWithin returns an iterator that yields all player entities in the
+// World that are at most radius blocks away from pos. Only players in loaded
+// chunks are yielded.
+func (tx *Tx) PlayersWithin(pos mgl64.Vec3, radius float64) iter.Seq[Entity] {
+	return tx.World().playersWithin(tx, pos, radius)
+}
+
 // Viewers returns all viewers viewing the position passed. The returned slice is pooled and must be released
 // by calling ReleaseViewers once it is no longer needed.
 func (tx *Tx) Viewers(pos mgl64.Vec3) []Viewer {
@@ -303,6 +665,25 @@ func (tx *Tx) BroadcastSleepingReminder(sleeper Sleeper) {
 	}
 }
 
+// InhabitedTime returns the number of ticks that the chunk at the position
+// passed was simulated for because a viewer was near it, which may be used to
+// scale the difficulty of mobs or loot like vanilla does. Zero is returned if
+// the chunk is not loaded.
+func (tx *Tx) InhabitedTime(pos ChunkPos) int64 {
+	if c, ok := tx.World().chunks[pos]; ok {
+		return c.inhabitedTime
+	}
+	return 0
+}
+
+// ResendChunk sends the current contents of the chunk at a position to a
+// Viewer again, for example to resynchronise a client after changes were made
+// to the chunk out of band. Nothing happens if the chunk is not loaded or the
+// Viewer is not viewing it, in which case false is returned.
+func (tx *Tx) ResendChunk(pos ChunkPos, v Viewer) bool {
+	return tx.World().resendChunk(pos, v)
+}
+
 // ReleaseViewers returns a slice previously obtained from Viewers back to the internal pool.
 func (tx *Tx) ReleaseViewers(viewers []Viewer) {
 	tx.World().releaseViewers(viewers)
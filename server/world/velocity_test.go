@@ -0,0 +1,83 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// velocityEntityType is an EntityType of which the entities implement
+// VelocityEntity.
+type velocityEntityType struct{ testEntityType }
+
+func (velocityEntityType) Open(_ *Tx, handle *EntityHandle, data *EntityData) Entity {
+	return &velocityEntity{testEntity{handle: handle, data: data}}
+}
+
+type velocityEntity struct{ testEntity }
+
+func (e *velocityEntity) Velocity() mgl64.Vec3     { return e.data.Vel }
+func (e *velocityEntity) SetVelocity(v mgl64.Vec3) { e.data.Vel = v }
+
+// broadcastingEntityType is an EntityType of which the entities show their
+// velocity to viewers themselves, like players do.
+type broadcastingEntityType struct{ testEntityType }
+
+func (broadcastingEntityType) Open(_ *Tx, handle *EntityHandle, data *EntityData) Entity {
+	return &broadcastingEntity{velocityEntity{testEntity{handle: handle, data: data}}}
+}
+
+type broadcastingEntity struct{ velocityEntity }
+
+func (e *broadcastingEntity) BroadcastsVelocity() bool { return true }
+
+// velocityViewer is a Viewer that records the last velocity viewed of each
+// entity and the number of times a velocity was viewed.
+type velocityViewer struct {
+	NopViewer
+	vel   map[*EntityHandle]mgl64.Vec3
+	count int
+}
+
+func (v *velocityViewer) ViewEntityVelocity(e Entity, vel mgl64.Vec3) {
+	v.vel[e.H()] = vel
+	v.count++
+}
+
+func TestApplyImpulse(t *testing.T) {
+	w := newTestWorld(t, Config{})
+	viewer := &velocityViewer{vel: map[*EntityHandle]mgl64.Vec3{}}
+	loader := NewLoader(1, w, viewer)
+	t.Cleanup(func() {
+		<-w.Exec(loader.Close)
+	})
+
+	waitChunkLoaded(t, w, loader, ChunkPos{})
+
+	<-w.Exec(func(tx *Tx) {
+		e := tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{8, 64, 8}, Velocity: mgl64.Vec3{1, 0, 0}}.New(velocityEntityType{}, testEntityConfig{}))
+		if !tx.ApplyImpulse(e, mgl64.Vec3{0, 0.5, 0}) {
+			t.Fatalf("expected impulse to be applied")
+		}
+		want := mgl64.Vec3{1, 0.5, 0}
+		if vel := e.(VelocityEntity).Velocity(); vel != want {
+			t.Fatalf("expected velocity %v, got %v", want, vel)
+		}
+		if vel, ok := viewer.vel[e.H()]; !ok || vel != want {
+			t.Fatalf("expected viewer to be shown velocity %v, got %v", want, vel)
+		}
+
+		// Entities that show their velocity themselves must not have it
+		// shown to viewers a second time.
+		count := viewer.count
+		b := tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{8, 64, 8}}.New(broadcastingEntityType{}, testEntityConfig{}))
+		if !tx.SetVelocity(b, mgl64.Vec3{0, 1, 0}) || viewer.count != count {
+			t.Fatalf("expected velocity of broadcasting entity not to be shown by SetVelocity")
+		}
+
+		other := tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{8, 64, 8}}.New(testEntityType{}, testEntityConfig{}))
+		if tx.SetVelocity(other, mgl64.Vec3{1, 0, 0}) {
+			t.Fatalf("expected entity without velocity not to be changed")
+		}
+	})
+}
@@ -74,6 +74,28 @@ type Viewer interface {
 	ViewEntityWake(e Entity)
 }
 
+// EntityHidingViewer is a Viewer that is able to ignore all views of specific
+// entities, such as their movement, velocity and state. When an Entity is
+// hidden from an EntityHidingViewer using Tx.HideEntityFrom, the Viewer is
+// marked so that it no longer receives any updates of the Entity.
+type EntityHidingViewer interface {
+	Viewer
+	// SetEntityHidden marks the Entity of the EntityHandle passed as hidden
+	// from, or visible to, the Viewer. While hidden, all views of the Entity
+	// are ignored. SetEntityHidden does not spawn or despawn the Entity.
+	SetEntityHidden(h *EntityHandle, hidden bool)
+}
+
+// EntityBatchViewer is a Viewer that is able to view multiple entities at
+// once. It is used by Tx.AddEntities to show all entities added to a chunk to
+// its viewers in one call, allowing the Viewer to send them more efficiently.
+type EntityBatchViewer interface {
+	Viewer
+	// ViewEntities views all entities passed, as if ViewEntity,
+	// ViewEntityItems and ViewEntityArmour were called for each of them.
+	ViewEntities(entities []Entity)
+}
+
 // NopViewer is a Viewer implementation that does not implement any behaviour. It may be embedded by other structs to
 // prevent having to implement all of Viewer's methods.
 type NopViewer struct{}
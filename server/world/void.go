@@ -0,0 +1,26 @@
+package world
+
+import "math"
+
+// voidY returns the Y value below which entities are considered to be in the
+// void.
+func (w *World) voidY() float64 {
+	return float64(w.ra[0] - w.conf.VoidMargin)
+}
+
+// inVoid checks if an entity at height y should apply its default void
+// behaviour.
+func (w *World) inVoid(y float64) bool {
+	return w.conf.VoidHandler == nil && y < w.voidY()
+}
+
+// handleVoid calls Config.VoidHandler for the entity passed if it has fallen
+// into the void.
+func (w *World) handleVoid(tx *Tx, e Entity) {
+	if w.conf.VoidHandler == nil {
+		return
+	}
+	if y := e.Position()[1]; y < w.voidY() {
+		w.conf.VoidHandler(tx, e, int(math.Floor(y)))
+	}
+}
@@ -0,0 +1,59 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestVoidHandler(t *testing.T) {
+	type voidEvent struct {
+		handle *EntityHandle
+		y      int
+	}
+	events := make(chan voidEvent, 16)
+	w := newTestWorld(t, Config{
+		VoidMargin: 10,
+		VoidHandler: func(tx *Tx, e Entity, y int) {
+			events <- voidEvent{handle: e.H(), y: y}
+			tx.RemoveEntity(e)
+		},
+	})
+	loader := NewLoader(2, w, nopViewer{})
+	t.Cleanup(func() {
+		<-w.Exec(loader.Close)
+	})
+
+	falling := EntitySpawnOpts{Position: mgl64.Vec3{4, -80.5, 4}}.New(testEntityType{}, testEntityConfig{})
+	<-w.Exec(func(tx *Tx) {
+		// The first entity is below the minimum Y, but within the margin.
+		tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{8, -70, 8}}.New(testEntityType{}, testEntityConfig{}))
+		tx.AddEntity(falling)
+		loader.Move(tx, mgl64.Vec3{})
+		loader.Load(tx, 25)
+	})
+
+	var inVoid bool
+	<-w.Exec(func(tx *Tx) {
+		inVoid = tx.InVoid(-100)
+	})
+	if inVoid {
+		t.Fatalf("expected InVoid to report false with a void handler set")
+	}
+
+	select {
+	case ev := <-events:
+		if ev.handle != falling || ev.y != -81 {
+			t.Fatalf("expected void handler to be called for the falling entity at y -81, got y %v", ev.y)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected void handler to be called")
+	}
+	time.Sleep(100 * time.Millisecond)
+	select {
+	case ev := <-events:
+		t.Fatalf("expected no further void handler calls, got one at y %v", ev.y)
+	default:
+	}
+}
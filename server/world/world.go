@@ -1,7 +1,7 @@
 package world
 
 import (
-	"encoding/binary"
+	"cmp"
 	"errors"
 	"fmt"
 	"iter"
@@ -33,7 +33,10 @@ type columnRef struct {
 }
 
 type loaderActiveArea struct {
-	pos      ChunkPos
+	pos ChunkPos
+	// y is the index of the sub chunk that the loader is in, used for
+	// ActivationSphere.
+	y        int32
 	radius   int32
 	radiusSq int64
 }
@@ -52,8 +55,14 @@ type World struct {
 
 	o sync.Once
 
-	set     *Settings
-	handler atomic.Pointer[Handler]
+	set       *Settings
+	handler   atomic.Pointer[Handler]
+	generator atomic.Pointer[Generator]
+
+	// featureMu guards features, the GenerationFeatures registered using
+	// AddGenerationFeature.
+	featureMu sync.RWMutex
+	features  []featureEntry
 
 	weather
 
@@ -75,6 +84,22 @@ type World struct {
 	r *rand.Rand
 
 	tps atomic.Uint64
+	// tickInterval is the time between two ticks of the World in nanoseconds.
+	// tickIntervalChanged is signalled when it is changed, so that the ticker
+	// can be reset.
+	tickInterval        atomic.Int64
+	tickIntervalChanged chan struct{}
+	// frozen specifies if the World is frozen, in which case the ticker does
+	// not tick it and ticks may only be performed using StepTick.
+	frozen atomic.Bool
+
+	// readOnly specifies if the World is currently read-only. It is
+	// initialised with Config.ReadOnly and changed using SetReadOnly.
+	readOnly atomic.Bool
+	// noFireSpread and noBlockBurn disable the spreading of fire and the
+	// burning of blocks by fire. They are changed using SetFireSpread and
+	// SetBlockBurn.
+	noFireSpread, noBlockBurn atomic.Bool
 
 	// scheduledUpdates is a map of tick time values indexed by the block
 	// position at which an update is scheduled. If the current tick exceeds the
@@ -91,6 +116,30 @@ type World struct {
 	scratchActiveRefs       map[*EntityHandle]entityChunkRef
 	scratchSleepingRefs     map[*EntityHandle]entityChunkRef
 
+	// simulatedAreas caches the active areas of all loaders used by
+	// isSimulated, computed for a tick range of simulatedAreasRange. It is
+	// reset at the start of every tick and when a loader moves or is closed.
+	simulatedAreas       []loaderActiveArea
+	simulatedAreasRange  int32
+	simulatedAreasCached bool
+
+	// pendingSaves holds the chunks queued for an incremental save when
+	// Config.MaxChunkSavesPerTick is set. pendingSaveCount mirrors its length
+	// so that it may be read outside of transactions.
+	pendingSaves     []ChunkPos
+	pendingSaveSet   map[ChunkPos]struct{}
+	pendingSaveCount atomic.Int64
+	// pendingSaveResult holds the SaveResult of the incremental save in
+	// progress, if pendingSaveActive is true.
+	pendingSaveResult SaveResult
+	pendingSaveStart  time.Time
+	pendingSaveActive bool
+
+	// ticketMu guards tickets, the forced chunk tickets added using
+	// Tx.ForceLoad.
+	ticketMu sync.Mutex
+	tickets  map[ticketKey]TicketInfo
+
 	activeColumns     []columnRef
 	activeColumnIndex map[ChunkPos]int
 	entityColumns     []columnRef
@@ -99,12 +148,74 @@ type World struct {
 	viewerMu sync.Mutex
 	viewers  map[*Loader]Viewer
 
+	// hiddenEntities holds, for every entity hidden from specific viewers using
+	// hideEntityFrom, the set of viewers that the entity is hidden from.
+	hiddenEntities map[*EntityHandle]map[Viewer]struct{}
+
+	// blockWatchers holds the watchers registered using WatchBlockChanges. The
+	// slice is replaced as a whole on every change, guarded by blockWatcherMu,
+	// so that setBlock can read it without locking.
+	blockWatcherMu sync.Mutex
+	blockWatchers  atomic.Pointer[[]*blockWatcher]
+
 	generatorQueue chan generationTask
 	// generatorQueueSaturation counts how often chunk generation tasks had to be
 	// enqueued asynchronously because the worker queue was full. We use this to
 	// rate-limit backpressure warnings so operators can tune queue/worker sizes.
 	generatorQueueSaturation atomic.Uint64
 	lastQueueSaturationLog   atomic.Uint64
+	// neighbourUpdateCapHits counts the ticks in which neighbour updates were
+	// deferred because Config.MaxNeighbourUpdatesPerTick was reached.
+	neighbourUpdateCapHits atomic.Uint64
+	// lastNeighbourUpdateCapLog holds the time in nanoseconds at which a
+	// warning was last logged for the neighbour update cap being reached.
+	lastNeighbourUpdateCapLog atomic.Int64
+	// playerCount is the number of players in the World, so that it may be
+	// read outside of transactions.
+	playerCount atomic.Int64
+	// lastCrowdedChunkLog holds the time in nanoseconds at which a warning was
+	// last logged for a chunk exceeding Config.ChunkEntityLimit.
+	lastCrowdedChunkLog atomic.Int64
+	// chunkCache holds the columns of recently closed chunks if
+	// Config.ChunkCacheSize is set, or nil otherwise.
+	chunkCache *chunkCache
+	// loadFailures holds the time at which loading a chunk last failed after
+	// all retries of ChunkLoadErrorRetry, so that the chunk is not retried
+	// again until chunkLoadRetryCooldown passed.
+	loadFailures map[ChunkPos]time.Time
+	// simulationStats holds the SimulationStats of the last tick, so that
+	// they may be read outside of transactions.
+	simulationStats atomic.Pointer[SimulationStats]
+
+	// genFailMu guards genFailures, the number of times the generation of
+	// each chunk failed. lastGenFailureLog holds the time in nanoseconds at
+	// which a warning was last logged for a failed generation.
+	genFailMu         sync.Mutex
+	genFailures       map[ChunkPos]int
+	lastGenFailureLog atomic.Int64
+
+	// structureMu guards structures, the locations of structures recorded
+	// using RecordStructure indexed by their name, and structuresChanged,
+	// which is set if structures were recorded since they were last stored.
+	structureMu       sync.Mutex
+	structures        map[string][]cube.Pos
+	structuresChanged bool
+
+	// pendingGenMu guards pendingGen, the positions of chunks of which the
+	// generation was requested but has not yet completed.
+	pendingGenMu sync.Mutex
+	pendingGen   map[ChunkPos]struct{}
+
+	// buildPolicy holds the BuildPolicy set using SetBuildPolicy, or nil if
+	// none was set.
+	buildPolicy atomic.Pointer[BuildPolicy]
+	// spawnProtectionRadius is the radius set using
+	// SetSpawnProtectionRadius.
+	spawnProtectionRadius atomic.Int64
+
+	// timeTriggers holds the functions registered using AtTime and
+	// RepeatAtTime.
+	timeTriggers timeTriggers
 }
 
 const (
@@ -161,6 +272,8 @@ func (s *entityState) entity(tx *Tx, handle *EntityHandle) Entity {
 type generationTask struct {
 	pos ChunkPos
 	col *Column
+	// gen is the Generator of the World at the time the task was created.
+	gen Generator
 }
 
 // transaction is a type that may be added to the transaction queue of a World.
@@ -218,12 +331,74 @@ func (w *World) TPS() float64 {
 	return math.Float64frombits(w.tps.Load())
 }
 
+// TargetTPS returns the number of ticks per second that the World attempts to
+// run at. By default, this is 20.
+func (w *World) TargetTPS() float64 {
+	return float64(time.Second) / float64(w.tickInterval.Load())
+}
+
+// SetTargetTPS changes the number of ticks per second that the World attempts
+// to run at, for example to slow down or speed up a World. The warning logged
+// when the TPS drops is relative to this target. Values of 0 or lower are
+// ignored.
+func (w *World) SetTargetTPS(tps float64) {
+	if !(tps > 0) {
+		return
+	}
+	w.tickInterval.Store(max(int64(float64(time.Second)/tps), 1))
+	w.tps.Store(math.Float64bits(tps))
+	select {
+	case w.tickIntervalChanged <- struct{}{}:
+	default:
+		// A change is already pending and will pick up the new interval.
+	}
+}
+
+// TickInterval returns the time between two ticks of the World, as derived
+// from the target TPS set using SetTargetTPS.
+func (w *World) TickInterval() time.Duration {
+	return time.Duration(w.tickInterval.Load())
+}
+
+// SetFrozen freezes or unfreezes the World. While frozen, the World is not
+// ticked by its ticker, so that time, weather, blocks and entities do not
+// change unless StepTick is called. Transactions may still be executed.
+func (w *World) SetFrozen(frozen bool) {
+	w.frozen.Store(frozen)
+}
+
+// Frozen checks if the World is frozen using SetFrozen.
+func (w *World) Frozen() bool {
+	return w.frozen.Load()
+}
+
+// StepTick performs exactly one tick on a World frozen using SetFrozen. The
+// channel returned is closed once the tick is done. StepTick is intended for
+// tests and debugging tools that need deterministic, tick-by-tick control
+// over the World. If the World is not frozen, StepTick does nothing and the
+// channel returned is closed immediately.
+func (w *World) StepTick() <-chan struct{} {
+	if !w.frozen.Load() {
+		c := make(chan struct{})
+		close(c)
+		return c
+	}
+	return w.Exec(ticker{}.tick)
+}
+
 // LoadedChunkCount returns the number of chunks currently kept in memory by the
 // world.
 func (w *World) LoadedChunkCount() int {
 	return len(w.chunks)
 }
 
+// PlayerCount returns the number of players currently in the World. Unlike
+// counting the players yielded by Tx.Players, PlayerCount does not require a
+// transaction.
+func (w *World) PlayerCount() int {
+	return int(w.playerCount.Load())
+}
+
 // EntityCount returns the number of entities tracked by the world.
 func (w *World) EntityCount() int {
 	return len(w.entities)
@@ -331,11 +506,46 @@ func (w *World) highestBlock(x, z int) int {
 // and z that has at least a solid top or bottom face.
 func (w *World) highestObstructingBlock(x, z int) int {
 	yHigh := w.highestBlock(x, z)
-	src := worldSource{w: w}
 	for y := yHigh; y >= w.Range()[0]; y-- {
 		pos := cube.Pos{x, y, z}
-		m := w.block(pos).Model()
-		if m.FaceSolid(pos, cube.FaceUp, src) || m.FaceSolid(pos, cube.FaceDown, src) {
+		if w.faceSolid(pos, cube.FaceUp) || w.faceSolid(pos, cube.FaceDown) {
+			return y
+		}
+	}
+	return w.Range()[0]
+}
+
+// faceSolid checks if the face passed of the block at a position in the World
+// is solid, according to the Model of the block.
+func (w *World) faceSolid(pos cube.Pos, face cube.Face) bool {
+	return w.block(pos).Model().FaceSolid(pos, face, worldSource{w: w})
+}
+
+// solidGroundBelow returns the position of the first block below the position
+// passed that has a solid top face. False is returned if no such block exists
+// above the minimum Y of the World.
+func (w *World) solidGroundBelow(pos cube.Pos) (cube.Pos, bool) {
+	for y := min(pos[1]-1, w.Range()[1]); y >= w.Range()[0]; y-- {
+		below := cube.Pos{pos[0], y, pos[2]}
+		if w.faceSolid(below, cube.FaceUp) {
+			return below, true
+		}
+	}
+	return cube.Pos{}, false
+}
+
+// highestBlockExcluding returns the highest non-air block in the World at a
+// given x and z for which exclude returns false. The minimum Y of the World is
+// returned if all blocks in the column are excluded.
+func (w *World) highestBlockExcluding(x, z int, exclude func(Block) bool) int {
+	c := w.chunk(ChunkPos{int32(x >> 4), int32(z >> 4)})
+	yHigh := int(c.HighestBlock(uint8(x), uint8(z)))
+	for y := yHigh; y >= w.Range()[0]; y-- {
+		pos := cube.Pos{x, y, z}
+		if c.Block(uint8(x), int16(y), uint8(z), 0) == airRID {
+			continue
+		}
+		if !exclude(w.blockInChunk(c, pos)) {
 			return y
 		}
 	}
@@ -382,6 +592,12 @@ func (w *World) setBlock(pos cube.Pos, b Block, opts *SetOpts) {
 	x, y, z := uint8(pos[0]), int16(pos[1]), uint8(pos[2])
 	c := w.chunk(chunkPosFromBlockPos(pos))
 
+	var old Block
+	watchers := w.blockWatchersAt(pos)
+	if len(watchers) != 0 {
+		old = w.blockInChunk(c, pos)
+	}
+
 	rid := BlockRuntimeID(b)
 
 	var before uint32
@@ -431,11 +647,77 @@ func (w *World) setBlock(pos cube.Pos, b Block, opts *SetOpts) {
 		viewer.ViewBlockUpdate(pos, b, 0)
 	})
 
+	if len(watchers) != 0 {
+		if b == nil {
+			b = air()
+		}
+		for _, bw := range watchers {
+			bw.fn(pos, old, b)
+		}
+	}
+
 	if !opts.DisableBlockUpdates {
 		w.doBlockUpdatesAround(pos)
 	}
 }
 
+// blockNBT returns the NBT data of the block at the position passed. False is
+// returned if the block there does not implement NBTer.
+func (w *World) blockNBT(pos cube.Pos) (map[string]any, bool) {
+	nb, ok := w.block(pos).(NBTer)
+	if !ok {
+		return nil, false
+	}
+	return nb.EncodeNBT(), true
+}
+
+// setBlockNBT decodes the NBT data passed into the block at the position
+// passed and writes the result back to the world. False is returned if the
+// block there does not implement NBTer.
+func (w *World) setBlockNBT(pos cube.Pos, data map[string]any) bool {
+	nb, ok := w.block(pos).(NBTer)
+	if !ok {
+		return false
+	}
+	b, ok := nb.DecodeNBT(data).(Block)
+	if !ok {
+		return false
+	}
+	w.setBlock(pos, b, &SetOpts{DisableBlockUpdates: true, DisableLiquidDisplacement: true})
+	return true
+}
+
+// moveBlock moves the block at from, including its block entity, to the
+// position to and sets the block at from to air. The block is written to its
+// new position before it is removed from the old one, so that it is present
+// in the world at all times, and block updates are only done once both
+// positions were changed. False is returned if either position is out of
+// bounds, if the positions are equal or if the block at from is air.
+func (w *World) moveBlock(from, to cube.Pos, opts *SetOpts) bool {
+	if from == to || from.OutOfBounds(w.Range()) || to.OutOfBounds(w.Range()) {
+		return false
+	}
+	b := w.block(from)
+	if BlockRuntimeID(b) == airRID {
+		return false
+	}
+	if opts == nil {
+		opts = &SetOpts{}
+	}
+	deferred := *opts
+	deferred.DisableBlockUpdates = true
+
+	// The same Block value is moved, so that block entities such as chests
+	// keep their state, including any containers currently opened.
+	w.setBlock(to, b, &deferred)
+	w.setBlock(from, nil, &deferred)
+	if !opts.DisableBlockUpdates {
+		w.doBlockUpdatesAround(to)
+		w.doBlockUpdatesAround(from)
+	}
+	return true
+}
+
 // setBiome sets the Biome at the position passed. If a chunk is not yet loaded
 // at that position, the chunk is first loaded or generated if it could not be
 // found in the world save.
@@ -449,6 +731,43 @@ func (w *World) setBiome(pos cube.Pos, b Biome) {
 	c.SetBiome(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), uint32(b.EncodeBiome()))
 }
 
+// setBiomes sets the biomes within the cuboid starting at origin with the
+// dimensions passed to the Biome returned by at for each position, relative
+// to origin. If at returns nil, the biome at that position is left unchanged.
+// Positions outside the Range of the World are skipped. Like buildStructure,
+// setBiomes operates per chunk and sends every changed chunk to its viewers
+// once.
+func (w *World) setBiomes(origin cube.Pos, dims [3]int, at func(x, y, z int) Biome) {
+	if dims[0] <= 0 || dims[1] <= 0 || dims[2] <= 0 {
+		return
+	}
+	maxX, maxZ := origin[0]+dims[0]-1, origin[2]+dims[2]-1
+	minY, maxY := max(origin[1], w.Range()[0]), min(origin[1]+dims[1]-1, w.Range()[1])
+	if minY > maxY {
+		return
+	}
+	for chunkX := origin[0] >> 4; chunkX <= maxX>>4; chunkX++ {
+		for chunkZ := origin[2] >> 4; chunkZ <= maxZ>>4; chunkZ++ {
+			chunkPos := ChunkPos{int32(chunkX), int32(chunkZ)}
+			c := w.chunk(chunkPos)
+
+			for x := max(origin[0], chunkX<<4); x <= min(maxX, chunkX<<4+15); x++ {
+				for z := max(origin[2], chunkZ<<4); z <= min(maxZ, chunkZ<<4+15); z++ {
+					for y := minY; y <= maxY; y++ {
+						if b := at(x-origin[0], y-origin[1], z-origin[2]); b != nil {
+							c.SetBiome(uint8(x), int16(y), uint8(z), uint32(b.EncodeBiome()))
+						}
+					}
+				}
+			}
+			c.modified = true
+			for viewer := range c.viewers {
+				viewer.ViewChunk(chunkPos, w.Dimension(), c.BlockEntities, c.Chunk)
+			}
+		}
+	}
+}
+
 // buildStructure builds a Structure passed at a specific position in the
 // world. Unlike setBlock, it takes a Structure implementation, which provides
 // blocks to be placed at a specific location. buildStructure is specifically
@@ -463,6 +782,7 @@ func (w *World) buildStructure(pos cube.Pos, s Structure) {
 	f := func(x, y, z int) Block {
 		return w.block(cube.Pos{pos[0] + x, pos[1] + y, pos[2] + z})
 	}
+	watchers := w.blockWatchersIntersecting(cube.Box(float64(pos[0]), float64(pos[1]), float64(pos[2]), float64(maxX), float64(maxY), float64(maxZ)))
 
 	// We approach this on a per-chunk basis, so that we can keep only one chunk
 	// in memory at a time while not needing to acquire a new chunk lock for
@@ -504,15 +824,25 @@ func (w *World) buildStructure(pos cube.Pos, s Structure) {
 							}
 							b, liq := s.At(xOffset-pos[0], yOffset-pos[1], zOffset-pos[2], f)
 							if b != nil {
+								nbtPos := cube.Pos{xOffset, yOffset, zOffset}
+								var old Block
+								if len(watchers) != 0 {
+									old = w.blockInChunk(c, nbtPos)
+								}
+
 								rid := BlockRuntimeID(b)
 								sub.SetBlock(uint8(xOffset), uint8(yOffset), uint8(zOffset), 0, rid)
 
-								nbtPos := cube.Pos{xOffset, yOffset, zOffset}
 								if nbtBlocks[rid] {
 									c.BlockEntities[nbtPos] = b
 								} else {
 									delete(c.BlockEntities, nbtPos)
 								}
+								for _, bw := range watchers {
+									if bw.watches(nbtPos) {
+										bw.fn(nbtPos, old, b)
+									}
+								}
 							}
 							if liq != nil {
 								sub.SetBlock(uint8(xOffset), uint8(yOffset), uint8(zOffset), 1, BlockRuntimeID(liq))
@@ -535,6 +865,184 @@ func (w *World) buildStructure(pos cube.Pos, s Structure) {
 	}
 }
 
+// replaceBlocks replaces every block within box for which match returns true
+// with the Block passed and returns the number of blocks replaced. A block is
+// within box if the block position lies in the integer bounds of the box.
+// Positions outside the Range of the World are ignored. Like buildStructure,
+// replaceBlocks operates per chunk and sends every changed chunk to its
+// viewers once instead of sending individual block updates. The changes are
+// passed to Handler.HandleBulkBlockChange before any block is changed.
+func (w *World) replaceBlocks(tx *Tx, box cube.BBox, match func(Block) bool, with Block) int {
+	minPos, maxPos := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+	if float64(maxPos[0]) == box.Max()[0] {
+		maxPos[0]--
+	}
+	if float64(maxPos[1]) == box.Max()[1] {
+		maxPos[1]--
+	}
+	if float64(maxPos[2]) == box.Max()[2] {
+		maxPos[2]--
+	}
+	minPos[1], maxPos[1] = max(minPos[1], w.Range()[0]), min(maxPos[1], w.Range()[1])
+	if minPos[0] > maxPos[0] || minPos[1] > maxPos[1] || minPos[2] > maxPos[2] {
+		return 0
+	}
+
+	if with == nil {
+		with = air()
+	}
+	rid := BlockRuntimeID(with)
+	newNBT := nbtBlocks[rid]
+
+	var (
+		n       int
+		changed []ChunkPos
+	)
+	replace := func(pos cube.Pos, c *Column, old Block) {
+		x, y, z := uint8(pos[0]), int16(pos[1]), uint8(pos[2])
+		c.SetBlock(x, y, z, 0, rid)
+		// Liquids in the second layer, such as the water of waterlogged
+		// blocks, are handled like setBlock does: They take the place of air
+		// and are removed unless the new block is able to hold them.
+		if li := c.Block(x, y, z, 1); li != airRID {
+			if rid == airRID {
+				c.SetBlock(x, y, z, 0, li)
+				c.SetBlock(x, y, z, 1, airRID)
+			} else if d, ok := with.(LiquidDisplacer); !ok || !canDisplace(d, blockByRuntimeIDOrAir(li)) {
+				c.SetBlock(x, y, z, 1, airRID)
+			}
+		}
+		if newNBT {
+			c.BlockEntities[pos] = with
+		} else {
+			delete(c.BlockEntities, pos)
+		}
+		for _, bw := range w.blockWatchersAt(pos) {
+			bw.fn(pos, old, with)
+		}
+		// Blocks are replaced chunk by chunk, so a chunk only needs to be
+		// recorded if it differs from the last one.
+		if chunkPos := chunkPosFromBlockPos(pos); len(changed) == 0 || changed[len(changed)-1] != chunkPos {
+			changed = append(changed, chunkPos)
+		}
+		n++
+	}
+
+	if _, ok := w.Handler().(NopHandler); ok {
+		// Nothing can cancel the operation, so blocks are replaced right away
+		// instead of collecting the changes for HandleBulkBlockChange first.
+		w.eachMatchingBlock(minPos, maxPos, match, replace)
+	} else {
+		var changes []BlockChange
+		w.eachMatchingBlock(minPos, maxPos, match, func(pos cube.Pos, _ *Column, old Block) {
+			changes = append(changes, BlockChange{Pos: pos, Old: old, New: with})
+		})
+		if len(changes) == 0 {
+			return 0
+		}
+		ctx := event.C(tx)
+		if w.Handler().HandleBulkBlockChange(ctx, changes); ctx.Cancelled() {
+			return 0
+		}
+		for _, change := range changes {
+			replace(change.Pos, w.chunk(chunkPosFromBlockPos(change.Pos)), change.Old)
+		}
+	}
+
+	// Every changed chunk is sent to its viewers once, after all of its
+	// blocks were replaced.
+	for _, chunkPos := range changed {
+		c := w.chunk(chunkPos)
+		c.modified = true
+		for viewer := range c.viewers {
+			viewer.ViewChunk(chunkPos, w.Dimension(), c.BlockEntities, c.Chunk)
+		}
+	}
+	return n
+}
+
+// canDisplace checks if the LiquidDisplacer passed can hold the Block passed,
+// which is expected to be a Liquid, in its second layer.
+func canDisplace(d LiquidDisplacer, b Block) bool {
+	l, ok := b.(Liquid)
+	return ok && d.CanDisplace(l)
+}
+
+// eachMatchingBlock calls fn for every block between minPos and maxPos
+// (inclusive) for which match returns true, passing the Column the block is in
+// and the block itself. The blocks are visited chunk by chunk. Only the first
+// layer is matched. match is called once per block state for blocks without a
+// block entity and once per block for blocks with one.
+func (w *World) eachMatchingBlock(minPos, maxPos cube.Pos, match func(Block) bool, fn func(pos cube.Pos, c *Column, b Block)) {
+	// matches caches the result of match for blocks without block entity data,
+	// which are fully identified by their runtime ID.
+	matches := make(map[uint32]bool)
+	for chunkX := minPos[0] >> 4; chunkX <= maxPos[0]>>4; chunkX++ {
+		for chunkZ := minPos[2] >> 4; chunkZ <= maxPos[2]>>4; chunkZ++ {
+			c := w.chunk(ChunkPos{int32(chunkX), int32(chunkZ)})
+			for x := max(minPos[0], chunkX<<4); x <= min(maxPos[0], chunkX<<4+15); x++ {
+				for z := max(minPos[2], chunkZ<<4); z <= min(maxPos[2], chunkZ<<4+15); z++ {
+					for y := minPos[1]; y <= maxPos[1]; y++ {
+						pos := cube.Pos{x, y, z}
+						current := c.Block(uint8(x), int16(y), uint8(z), 0)
+						if nbtBlocks[current] {
+							if b := w.blockInChunk(c, pos); match(b) {
+								fn(pos, c, b)
+							}
+							continue
+						}
+						m, ok := matches[current]
+						if !ok {
+							m = match(blockByRuntimeIDOrAir(current))
+							matches[current] = m
+						}
+						if m {
+							fn(pos, c, blockByRuntimeIDOrAir(current))
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// eachBlockInChunk calls fn for every non-air runtime ID stored in the loaded
+// chunk at the position passed, walking the sub chunks and their layers
+// directly. Empty sub chunks and layers filled with only air are skipped.
+// Iteration stops once fn returns false. Nothing happens if the chunk is not
+// loaded or not yet generated.
+func (w *World) eachBlockInChunk(pos ChunkPos, fn func(pos cube.Pos, rid uint32) bool) {
+	c, ok := w.chunks[pos]
+	if !ok || !c.Ready() {
+		return
+	}
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+	for i, sub := range c.Sub() {
+		if sub.Empty() {
+			continue
+		}
+		baseY := int(c.SubY(int16(i)))
+		for _, layer := range sub.Layers() {
+			if p := layer.Palette(); p.Len() == 1 && p.Value(0) == airRID {
+				continue
+			}
+			for x := uint8(0); x < 16; x++ {
+				for z := uint8(0); z < 16; z++ {
+					for y := uint8(0); y < 16; y++ {
+						rid := layer.At(x, y, z)
+						if rid == airRID {
+							continue
+						}
+						if !fn(cube.Pos{baseX + int(x), baseY + int(y), baseZ + int(z)}, rid) {
+							return
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
 // liquid attempts to return a Liquid block at the position passed. This
 // Liquid may be in the foreground or in any other layer. If found, the Liquid
 // is returned. If not, the bool returned is false.
@@ -763,6 +1271,12 @@ func (w *World) PlayersSleepingPercentage() int32 {
 	return w.set.PlayersSleepingPercentage
 }
 
+// ItemPickupDelay returns the default delay before item entities dropped in the
+// World may be picked up, as configured in Config.ItemPickupDelay.
+func (w *World) ItemPickupDelay() time.Duration {
+	return w.conf.ItemPickupDelay
+}
+
 // temperature returns the temperature in the World at a specific position.
 // Higher altitudes and different biomes influence the temperature returned.
 func (w *World) temperature(pos cube.Pos) float64 {
@@ -800,33 +1314,107 @@ func (w *World) playSound(tx *Tx, pos mgl64.Vec3, s Sound) {
 	w.releaseViewers(viewers)
 }
 
+// playSoundRadius plays a sound at a position in the World to all viewers of
+// the chunks within a radius around that position.
+func (w *World) playSoundRadius(tx *Tx, pos mgl64.Vec3, s Sound, radius float64) {
+	ctx := event.C(tx)
+	if w.Handler().HandleSound(ctx, s, pos); ctx.Cancelled() {
+		return
+	}
+	s.Play(w, pos)
+	viewers := w.viewersWithin(pos, radius)
+	for _, viewer := range viewers {
+		viewer.ViewSound(pos, s)
+	}
+	w.releaseViewers(viewers)
+}
+
 // addEntity adds an EntityHandle to a World. The Entity will be visible to all
 // viewers of the World that have the chunk at the EntityHandle's position. If
 // the chunk that the EntityHandle is in is not yet loaded, it will first be
 // loaded. addEntity panics if the EntityHandle is already in a world.
 // addEntity returns the Entity created by the EntityHandle.
 func (w *World) addEntity(tx *Tx, handle *EntityHandle) Entity {
-	handle.setAndUnlockWorld(w)
-	pos := chunkPosFromVec3(handle.data.Pos)
 	w.set.Lock()
 	currentTick := w.set.CurrentTick
 	w.set.Unlock()
-	state := &entityState{pos: pos, lastTick: currentTick, isItem: handle.t.EncodeEntity() == "minecraft:item"}
-	w.entities[handle] = state
 
+	pos := chunkPosFromVec3(handle.data.Pos)
 	c := w.chunk(pos)
-	c.Entities, c.modified = append(c.Entities, handle), true
+	e := w.placeEntity(tx, handle, pos, c, currentTick)
 	w.addEntityColumn(pos, c)
 
-	e := state.entity(tx, handle)
 	for v := range c.viewers {
 		// Show the entity to all viewers in the chunk of the entity.
-		showEntity(e, v)
+		w.showEntity(e, v)
 	}
 	w.Handler().HandleEntitySpawn(tx, e)
 	return e
 }
 
+// addEntities adds multiple EntityHandles to the World at once. The handles
+// are grouped by the chunk they are in, so that every chunk is looked up and
+// its viewers are iterated only once, regardless of the number of entities
+// added to it. Viewers implementing EntityBatchViewer are shown all entities
+// added to a chunk in a single call.
+// HandleEntitySpawn is called for every entity, in the order the handles were
+// passed.
+func (w *World) addEntities(tx *Tx, handles []*EntityHandle) []Entity {
+	w.set.Lock()
+	currentTick := w.set.CurrentTick
+	w.set.Unlock()
+
+	entities := make([]Entity, len(handles))
+	groups := make(map[ChunkPos][]int)
+	var order []ChunkPos
+	for i, handle := range handles {
+		pos := chunkPosFromVec3(handle.data.Pos)
+		if _, ok := groups[pos]; !ok {
+			order = append(order, pos)
+		}
+		groups[pos] = append(groups[pos], i)
+	}
+	for _, pos := range order {
+		c := w.chunk(pos)
+		for _, i := range groups[pos] {
+			entities[i] = w.placeEntity(tx, handles[i], pos, c, currentTick)
+		}
+		w.addEntityColumn(pos, c)
+
+		added := make([]Entity, len(groups[pos]))
+		for j, i := range groups[pos] {
+			added[j] = entities[i]
+		}
+		for v := range c.viewers {
+			// Show all entities added to the chunk to its viewers.
+			w.showEntities(added, v)
+		}
+	}
+	for _, e := range entities {
+		w.Handler().HandleEntitySpawn(tx, e)
+	}
+	return entities
+}
+
+// placeEntity registers an EntityHandle in the World and adds it to the
+// Column passed, which must be the column at pos. The Entity created by the
+// handle is returned, but is not yet shown to any viewers.
+func (w *World) placeEntity(tx *Tx, handle *EntityHandle, pos ChunkPos, c *Column, currentTick int64) Entity {
+	handle.setAndUnlockWorld(w)
+	state := &entityState{pos: pos, lastTick: currentTick, isItem: handle.t.EncodeEntity() == "minecraft:item"}
+	w.entities[handle] = state
+	c.Entities, c.modified = append(c.Entities, handle), true
+	if handle.t.EncodeEntity() == "minecraft:player" {
+		w.playerCount.Add(1)
+	}
+
+	e := state.entity(tx, handle)
+	if se, ok := e.(ScalableEntity); ok && w.conf.DifficultyScaler != nil {
+		se.ApplyAttributes(w.conf.DifficultyScaler(w.Difficulty(), se.BaseAttributes()))
+	}
+	return e
+}
+
 // removeEntity removes an Entity from the World that is currently present in
 // it. Any viewers of the Entity will no longer be able to see it.
 // removeEntity returns the EntityHandle of the Entity. After removing an Entity
@@ -851,6 +1439,10 @@ func (w *World) removeEntity(e Entity, tx *Tx) *EntityHandle {
 		v.HideEntity(e)
 	}
 	delete(w.entities, handle)
+	w.forgetHiddenEntity(handle)
+	if handle.t.EncodeEntity() == "minecraft:player" {
+		w.playerCount.Add(-1)
+	}
 	handle.unsetAndLockWorld()
 	return handle
 }
@@ -885,6 +1477,128 @@ func (w *World) entitiesWithin(tx *Tx, box cube.BBox) iter.Seq[Entity] {
 	}
 }
 
+// BlockEntityInfo holds a block entity and its position, as returned by
+// Tx.NearbyBlockEntities.
+type BlockEntityInfo struct {
+	// Pos is the position of the block entity.
+	Pos cube.Pos
+	// Block is the block entity at Pos.
+	Block Block
+}
+
+// maxBlockEntitySearchRadius is the largest radius used by
+// nearbyBlockEntities. It exceeds the horizontal size of any world, while
+// keeping squared distances well within the range of an int64.
+const maxBlockEntitySearchRadius = 1 << 26
+
+// nearbyBlockEntities returns the block entities in loaded chunks at most
+// radius blocks away from centre for which match returns true, sorted by
+// their distance to centre. A nil match matches all block entities. If the
+// radius covers more chunks than are loaded, the loaded chunks are searched
+// instead of all chunks within the radius.
+func (w *World) nearbyBlockEntities(centre cube.Pos, radius int, match func(Block) bool) []BlockEntityInfo {
+	if radius < 0 {
+		return nil
+	}
+	radius = min(radius, maxBlockEntitySearchRadius)
+	dist := func(pos cube.Pos) int64 {
+		x, y, z := int64(pos[0]-centre[0]), int64(pos[1]-centre[1]), int64(pos[2]-centre[2])
+		return x*x + y*y + z*z
+	}
+	maxDist := int64(radius) * int64(radius)
+	minPos := chunkPosFromBlockPos(centre.Add(cube.Pos{-radius, 0, -radius}))
+	maxPos := chunkPosFromBlockPos(centre.Add(cube.Pos{radius, 0, radius}))
+
+	var found []BlockEntityInfo
+	search := func(c *Column) {
+		for pos, b := range c.BlockEntities {
+			if dist(pos) > maxDist || (match != nil && !match(b)) {
+				continue
+			}
+			found = append(found, BlockEntityInfo{Pos: pos, Block: b})
+		}
+	}
+	if area := int64(maxPos[0]-minPos[0]+1) * int64(maxPos[1]-minPos[1]+1); area > int64(len(w.chunks)) {
+		for pos, c := range w.chunks {
+			if pos[0] >= minPos[0] && pos[0] <= maxPos[0] && pos[1] >= minPos[1] && pos[1] <= maxPos[1] {
+				search(c)
+			}
+		}
+	} else {
+		for x := minPos[0]; x <= maxPos[0]; x++ {
+			for z := minPos[1]; z <= maxPos[1]; z++ {
+				if c, ok := w.chunks[ChunkPos{x, z}]; ok {
+					search(c)
+				}
+			}
+		}
+	}
+	slices.SortFunc(found, func(a, b BlockEntityInfo) int {
+		if c := cmp.Compare(dist(a.Pos), dist(b.Pos)); c != 0 {
+			return c
+		}
+		return slices.Compare(a.Pos[:], b.Pos[:])
+	})
+	return found
+}
+
+// nearestEntity returns the Entity closest to pos within maxDist for which
+// filter returns true, together with its distance to pos. Chunks are searched
+// in rings of increasing distance around the chunk of pos, using the columns
+// indexed in entityColumns, and the search stops as soon as no chunk further
+// out could hold a closer Entity. A nil filter matches all entities.
+func (w *World) nearestEntity(tx *Tx, pos mgl64.Vec3, maxDist float64, filter func(Entity) bool) (Entity, float64, bool) {
+	if maxDist < 0 || len(w.entityColumns) == 0 {
+		return nil, 0, false
+	}
+	centre := chunkPosFromVec3(pos)
+	rings := int32(math.Ceil(maxDist/16)) + 1
+
+	var (
+		nearest     Entity
+		nearestDist = maxDist
+		found       bool
+	)
+	search := func(chunkPos ChunkPos) {
+		idx, ok := w.entityColumnIndex[chunkPos]
+		if !ok {
+			return
+		}
+		for _, handle := range w.entityColumns[idx].col.Entities {
+			dist := handle.data.Pos.Sub(pos).Len()
+			if dist > nearestDist || (found && dist == nearestDist) {
+				continue
+			}
+			state := w.entities[handle]
+			if state == nil {
+				continue
+			}
+			e := state.entity(tx, handle)
+			if filter != nil && !filter(e) {
+				continue
+			}
+			nearest, nearestDist, found = e, dist, true
+		}
+	}
+	for r := int32(0); r <= rings; r++ {
+		if found && nearestDist <= float64(r-1)*16 {
+			// Every chunk in this ring is at least (r-1)*16 blocks away from
+			// pos, so no closer entity can be found from here on.
+			break
+		}
+		for x := centre[0] - r; x <= centre[0]+r; x++ {
+			for z := centre[1] - r; z <= centre[1]+r; z++ {
+				if x != centre[0]-r && x != centre[0]+r && z != centre[1]-r && z != centre[1]+r {
+					// Only the outer edge of the square belongs to this ring.
+					continue
+				}
+				search(ChunkPos{x, z})
+			}
+		}
+	}
+	return nearest, nearestDist, found
+}
+
 // allEntities returns an iterator that yields all entities in the World.
 func (w *World) allEntities(tx *Tx) iter.Seq[Entity] {
 	return func(yield func(Entity) bool) {
@@ -898,6 +1612,20 @@ func (w *World) allEntities(tx *Tx) iter.Seq[Entity] {
 	}
 }
 
+// entityByID looks up a loaded entity by the ID it is stored under in chunk
+// data.
+func (w *World) entityByID(tx *Tx, id int64) (Entity, bool) {
+	for handle, state := range w.entities {
+		if handle.persistentID() != id {
+			continue
+		}
+		if ent := state.entity(tx, handle); ent != nil {
+			return ent, true
+		}
+	}
+	return nil, false
+}
+
 // allPlayers returns an iterator that yields all player entities in the World.
 func (w *World) allPlayers(tx *Tx) iter.Seq[Entity] {
 	return func(yield func(Entity) bool) {
@@ -913,6 +1641,25 @@ func (w *World) allPlayers(tx *Tx) iter.Seq[Entity] {
 	}
 }
 
+// playersWithin returns an iterator that yields all player entities in the
+// World of which the position is at most radius blocks away from pos.
+func (w *World) playersWithin(tx *Tx, pos mgl64.Vec3, radius float64) iter.Seq[Entity] {
+	return func(yield func(Entity) bool) {
+		if radius < 0 {
+			return
+		}
+		box := cube.Box(pos[0], pos[1], pos[2], pos[0], pos[1], pos[2]).Grow(radius)
+		for e := range w.entitiesWithin(tx, box) {
+			if e.H().t.EncodeEntity() != "minecraft:player" || e.Position().Sub(pos).Len() > radius {
+				continue
+			}
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
 // Spawn returns the spawn of the world. Every new player will by default spawn
 // on this position in the world when joining.
 func (w *World) Spawn() cube.Pos {
@@ -1042,6 +1789,51 @@ func (w *World) SetDifficulty(d Difficulty) {
 	w.set.Difficulty = d
 }
 
+// MaxChunkRadius returns the maximum chunk radius of Loaders in the World, as
+// set in Config.MaxChunkRadius. A value of 0 or lower means the radius of
+// Loaders is not limited.
+func (w *World) MaxChunkRadius() int {
+	return w.conf.MaxChunkRadius
+}
+
+// Seed returns the seed used to generate the World. If the Generator of the
+// World implements SeededGenerator, its seed is returned. Otherwise, the seed
+// set in Config.Seed or loaded from the Provider is returned.
+func (w *World) Seed() int64 {
+	if g, ok := w.Generator().(SeededGenerator); ok {
+		return g.Seed()
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.Seed
+}
+
+// FireSpread reports if fire spreads to other blocks in the World. Fire
+// spread is enabled by default.
+func (w *World) FireSpread() bool {
+	return !w.noFireSpread.Load()
+}
+
+// SetFireSpread changes if fire spreads to other blocks in the World. If
+// disabled, fire stays where it is, without Handler.HandleFireSpread being
+// called.
+func (w *World) SetFireSpread(spread bool) {
+	w.noFireSpread.Store(!spread)
+}
+
+// BlockBurn reports if flammable blocks in the World are burnt by
+// neighbouring fire. Block burning is enabled by default.
+func (w *World) BlockBurn() bool {
+	return !w.noBlockBurn.Load()
+}
+
+// SetBlockBurn changes if flammable blocks in the World are burnt by
+// neighbouring fire. If disabled, flammable blocks are left intact, without
+// Handler.HandleBlockBurn being called.
+func (w *World) SetBlockBurn(burn bool) {
+	w.noBlockBurn.Store(!burn)
+}
+
 // scheduleBlockUpdate schedules a block update at the position passed for the
 // block type passed after a specific delay. If the block at that position does
 // not handle block updates, nothing will happen.
@@ -1052,6 +1844,9 @@ func (w *World) scheduleBlockUpdate(pos cube.Pos, b Block, delay time.Duration)
 	if pos.OutOfBounds(w.Range()) {
 		return
 	}
+	if _, ok := b.(Liquid); ok && w.conf.LiquidTickMultiplier != 1 {
+		delay = time.Duration(float64(delay) * w.conf.LiquidTickMultiplier)
+	}
 	w.scheduledUpdates.schedule(pos, b, delay)
 }
 
@@ -1075,6 +1870,25 @@ type neighbourUpdate struct {
 	pos, neighbour cube.Pos
 }
 
+// NeighbourUpdateInfo holds information on a neighbour update that is waiting
+// to be performed, as returned by Tx.PendingNeighbourUpdates.
+type NeighbourUpdateInfo struct {
+	// Pos is the position of the block that is updated.
+	Pos cube.Pos
+	// Neighbour is the position of the neighbouring block that changed.
+	Neighbour cube.Pos
+}
+
+// pendingNeighbourUpdates returns a copy of the neighbour updates currently
+// queued in the World.
+func (w *World) pendingNeighbourUpdates() []NeighbourUpdateInfo {
+	updates := make([]NeighbourUpdateInfo, len(w.neighbourUpdates))
+	for i, update := range w.neighbourUpdates {
+		updates[i] = NeighbourUpdateInfo{Pos: update.pos, Neighbour: update.neighbour}
+	}
+	return updates
+}
+
 // updateNeighbour ticks the position passed as a result of the neighbour
 // passed being updated.
 func (w *World) updateNeighbour(pos, changedNeighbour cube.Pos) {
@@ -1094,6 +1908,34 @@ func (w *World) Handle(h Handler) {
 	w.handler.Store(&h)
 }
 
+// SetGenerator changes the Generator used to generate new chunks in the
+// World. Chunks already queued for generation are still generated using the
+// previous Generator. Passing nil, or NopGenerator{}, stops the generation of
+// any new terrain, so that only chunks already saved are served.
+func (w *World) SetGenerator(g Generator) {
+	if w == nil {
+		return
+	}
+	if g == nil {
+		g = NopGenerator{}
+	}
+	if sg, ok := g.(SeededGenerator); ok {
+		w.set.Lock()
+		w.set.Seed = sg.Seed()
+		w.set.Unlock()
+	}
+	w.generator.Store(&g)
+}
+
+// Generator returns the Generator currently used to generate new chunks in
+// the World.
+func (w *World) Generator() Generator {
+	if w == nil {
+		return NopGenerator{}
+	}
+	return *w.generator.Load()
+}
+
 // viewersOf returns all viewers viewing the position passed.
 //
 // The method deliberately borrows a slice from viewerSlicePool so the caller can iterate without allocating. The
@@ -1118,6 +1960,42 @@ func (w *World) viewersOf(pos mgl64.Vec3) []Viewer {
 	return viewers
 }
 
+// viewersWithin returns all viewers viewing any of the chunks that have at
+// least one block within the radius passed around a position. Like viewersOf,
+// the slice returned must be handed back through releaseViewers.
+func (w *World) viewersWithin(pos mgl64.Vec3, radius float64) []Viewer {
+	if radius <= 0 {
+		return w.viewersOf(pos)
+	}
+	minPos, maxPos := chunkPosFromVec3(pos.Sub(mgl64.Vec3{radius, 0, radius})), chunkPosFromVec3(pos.Add(mgl64.Vec3{radius, 0, radius}))
+	radiusSq := radius * radius
+
+	var viewers []Viewer
+	for x := minPos[0]; x <= maxPos[0]; x++ {
+		for z := minPos[1]; z <= maxPos[1]; z++ {
+			c, ok := w.chunks[ChunkPos{x, z}]
+			if !ok || len(c.viewers) == 0 {
+				continue
+			}
+			// Find the distance to the closest point of the chunk.
+			dx := pos[0] - max(float64(x<<4), min(pos[0], float64(x<<4+16)))
+			dz := pos[2] - max(float64(z<<4), min(pos[2], float64(z<<4+16)))
+			if dx*dx+dz*dz > radiusSq {
+				continue
+			}
+			if viewers == nil {
+				viewers = viewerSlicePool.Get().([]Viewer)[:0]
+			}
+			for v := range c.viewers {
+				if !slices.Contains(viewers, v) {
+					viewers = append(viewers, v)
+				}
+			}
+		}
+	}
+	return viewers
+}
+
 // releaseViewers returns pooled viewer slices to viewerSlicePool. Forgetting to release will degrade the pool and
 // reintroduce the very allocations this optimisation was meant to avoid.
 func (w *World) releaseViewers(viewers []Viewer) {
@@ -1146,6 +2024,18 @@ func (w *World) PortalDestination(dim Dimension) *World {
 	return dest
 }
 
+// PortalLinks returns the destination World of portals of every Dimension
+// (Overworld, Nether and End) when entered from this World, as returned by
+// PortalDestination. Dimensions that portals cannot travel to, for example
+// because the destination dimension is disabled, map to nil.
+func (w *World) PortalLinks() map[Dimension]*World {
+	links := make(map[Dimension]*World, 3)
+	for _, dim := range []Dimension{Overworld, Nether, End} {
+		links[dim] = w.PortalDestination(dim)
+	}
+	return links
+}
+
 // PortalDisabledMessage resolves the message to display when a portal targeting the
 // provided Dimension is disabled. An empty string suppresses any feedback.
 func (w *World) PortalDisabledMessage(dim Dimension) string {
@@ -1167,41 +2057,127 @@ func (w *World) DefaultWorld() *World {
 	return w
 }
 
+// SaveResult holds the result of saving a World, as passed to Config.OnSave.
+type SaveResult struct {
+	// Chunks is the number of modified chunks written to the Provider.
+	Chunks int
+	// Duration is the time the save took. For incremental saves, as done if
+	// Config.MaxChunkSavesPerTick is set, this is the time between queueing
+	// the chunks and writing the last of them.
+	Duration time.Duration
+	// Auto specifies if the save was an automatic save, as done every
+	// Config.SaveInterval, rather than a call to Save or SaveWithResult.
+	Auto bool
+	// ReadOnly specifies if nothing was saved because the World is
+	// read-only.
+	ReadOnly bool
+}
+
 // Save saves the World to the provider.
 func (w *World) Save() {
-	<-w.Exec(w.save(w.saveChunk))
+	w.SaveWithResult()
+}
+
+// SaveWithResult saves the World to its Provider like Save, returning the
+// number of modified chunks that were written. Nothing is saved and 0 is
+// returned if the World is read-only.
+func (w *World) SaveWithResult() (chunks int) {
+	return w.saveNow(false).Chunks
+}
+
+// saveNow saves all modified chunks of the World to its Provider and calls
+// Config.OnSave with the SaveResult once done.
+func (w *World) saveNow(auto bool) SaveResult {
+	start := time.Now()
+	res := SaveResult{Auto: auto, ReadOnly: w.readOnly.Load()}
+	<-w.Exec(w.save(func(tx *Tx, pos ChunkPos, c *Column) {
+		if w.saveChunk(tx, pos, c) {
+			res.Chunks++
+		}
+	}))
+	res.Duration = time.Since(start)
+	if w.conf.OnSave != nil {
+		w.conf.OnSave(res)
+	}
+	return res
+}
+
+// ReadOnly reports if the World is currently read-only, meaning no data is
+// saved to its Provider.
+func (w *World) ReadOnly() bool {
+	return w.readOnly.Load()
+}
+
+// SetReadOnly changes if the World is read-only. While read-only, the World
+// does not save any data to its Provider, which allows the files of the
+// Provider to be copied safely. Modified chunks are kept loaded until they
+// can be saved after calling SetReadOnly(false). If the World is closed while
+// read-only, modified chunks are saved regardless, unless Config.ReadOnly is
+// set. The initial value is that of Config.ReadOnly.
+func (w *World) SetReadOnly(readOnly bool) {
+	w.readOnly.Store(readOnly)
+}
+
+// holdUnsaved checks if the Column passed must be kept loaded because it has
+// changes that cannot be saved while the World is read-only. Worlds with
+// Config.ReadOnly set never save their changes, so their columns are not
+// held.
+func (w *World) holdUnsaved(c *Column) bool {
+	return c.modified && w.readOnly.Load() && !w.conf.ReadOnly
 }
 
 // save saves all loaded chunks to the World's provider.
 func (w *World) save(f func(*Tx, ChunkPos, *Column)) ExecFunc {
 	return func(tx *Tx) {
-		if w.conf.ReadOnly {
-			return
-		}
 		w.conf.Log.Debug("Saving chunks in memory to disk...")
 		for pos, c := range w.chunks {
+			// saveChunk does not write anything while the World is
+			// read-only, but f may still need to close the chunk.
 			f(tx, pos, c)
 		}
+		if w.readOnly.Load() {
+			return
+		}
 		w.conf.Log.Debug("Updating level.dat values...")
 		w.conf.Provider.SaveSettings(w.set)
+		w.storeStructures()
 	}
 }
 
 // saveChunk saves a chunk and its entities to disk after compacting the chunk.
-func (w *World) saveChunk(_ *Tx, pos ChunkPos, c *Column) {
-	if !w.conf.ReadOnly && c.modified {
-		c.Compact()
-		if err := w.conf.Provider.StoreColumn(pos, w.conf.Dim, w.columnTo(c, pos)); err != nil {
+// True is returned if the chunk was written. Of chunks that were not
+// modified, only the inhabited time is saved if it changed.
+func (w *World) saveChunk(_ *Tx, pos ChunkPos, c *Column) bool {
+	if w.readOnly.Load() {
+		return false
+	}
+	if !c.modified {
+		return c.inhabitedTimeChanged && w.saveInhabitedTime(pos, c)
+	}
+	c.Compact()
+	if w.conf.ValidateBlockEntities {
+		for _, err := range validateColumnBlockEntities(c) {
 			w.conf.Log.Error("save chunk: "+err.Error(), "X", pos[0], "Z", pos[1])
 		}
 	}
+	if err := w.conf.Provider.StoreColumn(pos, w.conf.Dim, w.columnTo(c, pos)); err != nil {
+		w.conf.Log.Error("save chunk: "+err.Error(), "X", pos[0], "Z", pos[1])
+		return false
+	}
+	c.inhabitedTimeChanged = false
+	return true
 }
 
 // closeChunk saves a chunk and its entities to disk after compacting the chunk.
 // Afterwards, scheduled updates from that chunk are removed and all entities
 // in it are closed.
 func (w *World) closeChunk(tx *Tx, pos ChunkPos, c *Column) {
-	w.saveChunk(tx, pos, c)
+	saved := w.saveChunk(tx, pos, c)
+	if w.chunkCache != nil && (saved || !c.modified) && c.Ready() {
+		// The column is encoded before its entities are closed below, so
+		// that they are re-created when the chunk is loaded from the cache.
+		w.chunkCache.put(pos, w.columnTo(c, pos))
+	}
 	w.scheduledUpdates.removeChunk(pos)
 	w.removeActiveColumn(pos)
 	w.removeEntityColumn(pos)
@@ -1212,6 +2188,9 @@ func (w *World) closeChunk(tx *Tx, pos ChunkPos, c *Column) {
 		_ = e.mustEntity(tx).Close()
 	}
 	clear(c.Entities)
+	if w.conf.OnChunkUnload != nil {
+		w.conf.OnChunkUnload(pos)
+	}
 	delete(w.chunks, pos)
 }
 
@@ -1229,11 +2208,19 @@ func (w *World) close() {
 		w.Handler().HandleClose(tx)
 		w.Handle(NopHandler{})
 
+		if w.readOnly.Load() && !w.conf.ReadOnly {
+			// The World was made read-only using SetReadOnly, for example
+			// during a backup. Its changes would be lost if not saved now.
+			w.conf.Log.Warn("Closing read-only world: saving modified chunks.")
+			w.readOnly.Store(false)
+		}
 		w.save(w.closeChunk)(tx)
 	})
 
 	close(w.closing)
 	w.running.Wait()
+	w.storePendingGeneration()
+	w.storeStructures()
 
 	close(w.queueClosing)
 	w.queueing.Wait()
@@ -1295,7 +2282,7 @@ func (w *World) addViewer(tx *Tx, pos ChunkPos, c *Column, loader *Loader) {
 	w.addActiveColumn(pos, c)
 
 	for _, entity := range c.Entities {
-		showEntity(entity.mustEntity(tx), loader.viewer)
+		w.showEntity(entity.mustEntity(tx), loader.viewer)
 	}
 }
 
@@ -1326,7 +2313,7 @@ func (w *World) removeViewer(tx *Tx, pos ChunkPos, loader *Loader) {
 		}
 	}
 
-	if len(c.viewers) == 0 && len(c.loaders) == 0 {
+	if len(c.viewers) == 0 && len(c.loaders) == 0 && !w.holdUnsaved(c) && !w.forcedChunks()(pos) {
 		w.closeChunk(tx, pos, c)
 	}
 }
@@ -1340,13 +2327,154 @@ func (w *World) Handler() Handler {
 }
 
 // showEntity shows an Entity to a viewer of the world. It makes sure
-// everything of the Entity, including the items held, is shown.
-func showEntity(e Entity, viewer Viewer) {
+// everything of the Entity, including the items held, is shown. Nothing
+// happens if the Entity was hidden from the viewer using hideEntityFrom.
+func (w *World) showEntity(e Entity, viewer Viewer) {
+	if len(w.hiddenEntities) != 0 {
+		if _, hidden := w.hiddenEntities[e.H()][viewer]; hidden {
+			return
+		}
+	}
 	viewer.ViewEntity(e)
 	viewer.ViewEntityItems(e)
 	viewer.ViewEntityArmour(e)
 }
 
+// showEntities shows multiple entities to a viewer, leaving out entities
+// hidden from it. If the viewer implements EntityBatchViewer, the entities are
+// passed to it in one call. Otherwise, each of them is shown using showEntity.
+func (w *World) showEntities(entities []Entity, viewer Viewer) {
+	bv, ok := viewer.(EntityBatchViewer)
+	if !ok {
+		for _, e := range entities {
+			w.showEntity(e, viewer)
+		}
+		return
+	}
+	if len(w.hiddenEntities) != 0 {
+		visible := make([]Entity, 0, len(entities))
+		for _, e := range entities {
+			if _, hidden := w.hiddenEntities[e.H()][viewer]; !hidden {
+				visible = append(visible, e)
+			}
+		}
+		entities = visible
+	}
+	if len(entities) != 0 {
+		bv.ViewEntities(entities)
+	}
+}
+
+// hideEntityFrom hides an Entity from a specific viewer, regardless of the
+// chunks the viewer has loaded. The Entity stays hidden until showEntityTo is
+// called or until either the Entity or the viewer leaves the World.
+func (w *World) hideEntityFrom(e Entity, viewer Viewer) {
+	handle := e.H()
+	state, ok := w.entities[handle]
+	if !ok || viewer == nil {
+		return
+	}
+	if w.hiddenEntities == nil {
+		w.hiddenEntities = make(map[*EntityHandle]map[Viewer]struct{})
+	}
+	hiddenFrom, ok := w.hiddenEntities[handle]
+	if !ok {
+		hiddenFrom = make(map[Viewer]struct{})
+		w.hiddenEntities[handle] = hiddenFrom
+	}
+	if _, hidden := hiddenFrom[viewer]; hidden {
+		return
+	}
+	hiddenFrom[viewer] = struct{}{}
+	if c, ok := w.chunks[state.pos]; ok {
+		if _, viewing := c.viewers[viewer]; viewing {
+			viewer.HideEntity(e)
+		}
+	}
+	// Viewers able to do so also ignore the movement, state and other
+	// updates of the Entity, which are sent to all viewers of its chunk.
+	if hv, ok := viewer.(EntityHidingViewer); ok {
+		hv.SetEntityHidden(handle, true)
+	}
+}
+
+// showEntityTo reverts a previous call to hideEntityFrom, showing the Entity
+// to the viewer again if it is viewing the chunk that the Entity is in.
+func (w *World) showEntityTo(e Entity, viewer Viewer) {
+	handle := e.H()
+	hiddenFrom, ok := w.hiddenEntities[handle]
+	if !ok {
+		return
+	}
+	if _, hidden := hiddenFrom[viewer]; !hidden {
+		return
+	}
+	delete(hiddenFrom, viewer)
+	if len(hiddenFrom) == 0 {
+		delete(w.hiddenEntities, handle)
+	}
+	if hv, ok := viewer.(EntityHidingViewer); ok {
+		hv.SetEntityHidden(handle, false)
+	}
+	if state, ok := w.entities[handle]; ok {
+		if c, ok := w.chunks[state.pos]; ok {
+			if _, viewing := c.viewers[viewer]; viewing {
+				w.showEntity(e, viewer)
+			}
+		}
+	}
+}
+
+// resendChunk sends the current contents of the chunk at a position to a
+// viewer again, if the chunk is loaded and the viewer is viewing it.
+func (w *World) resendChunk(pos ChunkPos, viewer Viewer) bool {
+	c, ok := w.chunks[pos]
+	if !ok || !c.Ready() {
+		return false
+	}
+	if _, viewing := c.viewers[viewer]; !viewing {
+		return false
+	}
+	viewer.ViewChunk(pos, w.Dimension(), c.BlockEntities, c.Chunk)
+	return true
+}
+
+// entityHiddenFrom checks if an Entity was hidden from a viewer using
+// hideEntityFrom.
+func (w *World) entityHiddenFrom(e Entity, viewer Viewer) bool {
+	_, hidden := w.hiddenEntities[e.H()][viewer]
+	return hidden
+}
+
+// forgetHiddenViewer removes a viewer from all entities hidden from it. It is
+// called when the viewer leaves the World.
+func (w *World) forgetHiddenViewer(viewer Viewer) {
+	hv, hiding := viewer.(EntityHidingViewer)
+	for handle, hiddenFrom := range w.hiddenEntities {
+		if _, hidden := hiddenFrom[viewer]; !hidden {
+			continue
+		}
+		delete(hiddenFrom, viewer)
+		if len(hiddenFrom) == 0 {
+			delete(w.hiddenEntities, handle)
+		}
+		if hiding {
+			hv.SetEntityHidden(handle, false)
+		}
+	}
+}
+
+// forgetHiddenEntity removes an entity hidden from viewers using
+// hideEntityFrom. It is called when the entity leaves the World.
+func (w *World) forgetHiddenEntity(handle *EntityHandle) {
+	for viewer := range w.hiddenEntities[handle] {
+		if hv, ok := viewer.(EntityHidingViewer); ok {
+			hv.SetEntityHidden(handle, false)
+		}
+	}
+	delete(w.hiddenEntities, handle)
+}
+
 // chunk reads a chunk from the position passed. If a chunk at that position is
 // not yet loaded, the chunk is loaded from the provider, or generated if it
 // did not yet exist. Additionally, chunks newly loaded have the light in them
@@ -1402,13 +2530,19 @@ func (w *World) chunkLoaded(pos ChunkPos) bool {
 // Behavior summary:
 //  1. If the chunk exists in persistent storage, load it and mark as ready.
 //  2. If not found, create a new column and generate it asynchronously.
-//  3. If an unexpected error occurs, return an empty ready column to prevent blocking.
+//  3. If an unexpected error occurs, apply the ChunkLoadErrorPolicy, and if the error
+//     remains, return an empty ready column to prevent blocking.
 //
 // This function guarantees that the returned *Column will eventually become ready,
 // even if generation is canceled due to shutdown.
 func (w *World) loadChunk(pos ChunkPos) (*Column, error) {
-	// Attempt to load the column from the persistent provider (e.g. LevelDB).
-	column, err := w.conf.Provider.LoadColumn(pos, w.conf.Dim)
+	// Recently closed chunks are taken from the cache first, and otherwise
+	// loaded from the persistent provider (e.g. LevelDB).
+	column, ok := w.chunkCache.take(pos)
+	var err error
+	if !ok {
+		column, err = w.loadColumn(pos)
+	}
 
 	switch {
 	case err == nil:
@@ -1452,7 +2586,9 @@ func (w *World) loadChunk(pos ChunkPos) (*Column, error) {
 
 	default:
 		// Case 3: Unexpected error occurred (I/O failure, corruption, etc.)
-		// To avoid deadlocks, return a ready empty column and the error.
+		// and the ChunkLoadErrorPolicy did not resolve it. To avoid
+		// deadlocks, return a ready empty column and the error. The column
+		// is not stored in the world, so it never replaces the data stored.
 		col := newColumn(chunk.New(airRID, w.Range()))
 		col.markReady()
 		return col, err
@@ -1466,7 +2602,8 @@ func (w *World) loadChunk(pos ChunkPos) (*Column, error) {
 // This prevents chunks from being stuck in a "not ready" state during shutdown,
 // which could otherwise cause Close() or c.waitReady() to block forever.
 func (w *World) generateChunkAsync(pos ChunkPos, col *Column) {
-	task := generationTask{pos: pos, col: col}
+	task := generationTask{pos: pos, col: col, gen: w.Generator()}
+	w.addPendingGeneration(pos)
 
 	select {
 	case <-w.closing:
@@ -1511,6 +2648,14 @@ func (w *World) generatorWorker() {
 	defer w.running.Done()
 
 	for {
+		// Check for shutdown first, so that no new tasks are started once the
+		// world is closing, even if more tasks are queued.
+		select {
+		case <-w.closing:
+			w.drainGenerationQueue()
+			return
+		default:
+		}
 		select {
 		case task := <-w.generatorQueue:
 			// A new generation task is available — process it immediately.
@@ -1534,13 +2679,13 @@ func (w *World) runGenerationTask(task generationTask) {
 	defer func() {
 		// Always recover from panics during generation to prevent worker termination.
 		if r := recover(); r != nil {
-			w.conf.Log.Error(
-				"generate chunk: panic",
-				"error", fmt.Sprint(r),
-				"X", task.pos[0],
-				"Z", task.pos[1],
-			)
+			w.handleGenerationFailure(task.pos, r)
+		} else {
+			w.clearGenerationFailures(task.pos)
 		}
+		// Generation was attempted, so it is not resumed, even if it
+		// failed, which would otherwise happen on every start.
+		w.removePendingGeneration(task.pos)
 
 		// Mark the column as ready regardless of success or failure.
 		task.col.markReady()
@@ -1548,7 +2693,8 @@ func (w *World) runGenerationTask(task generationTask) {
 
 	// Perform the actual chunk generation.
 	// The generator implementation is responsible for populating the chunk’s data.
-	w.conf.Generator.GenerateChunk(task.pos, task.col.Chunk)
+	task.gen.GenerateChunk(task.pos, task.col.Chunk)
+	w.runFeatures(task.pos, task.col.Chunk)
 }
 
 // drainGenerationQueue flushes any remaining tasks in the generator queue.
@@ -1594,10 +2740,110 @@ func (w *World) handleGeneratorBackpressure() {
 	)
 }
 
+// NeighbourUpdateCapHits returns the number of ticks in which neighbour
+// updates were deferred to the next tick because
+// Config.MaxNeighbourUpdatesPerTick was reached.
+func (w *World) NeighbourUpdateCapHits() uint64 {
+	return w.neighbourUpdateCapHits.Load()
+}
+
+// handleNeighbourUpdateCap increments the neighbour update cap counter and
+// emits a throttled warning that the number of updates passed was deferred to
+// the next tick. Frequent warnings point at redstone or liquid contraptions
+// producing more updates than the configured cap allows.
+func (w *World) handleNeighbourUpdateCap(deferred int) {
+	count := w.neighbourUpdateCapHits.Add(1)
+	now := time.Now().UnixNano()
+	last := w.lastNeighbourUpdateCapLog.Load()
+
+	if last != 0 && time.Duration(now-last) < time.Minute {
+		return
+	}
+	if !w.lastNeighbourUpdateCapLog.CompareAndSwap(last, now) {
+		return
+	}
+	w.conf.Log.Warn(
+		"world neighbour update cap reached: deferring updates to next tick.",
+		"deferred", deferred,
+		"cap", w.conf.MaxNeighbourUpdatesPerTick,
+		"capped_ticks", count,
+	)
+}
+
+// handleGenerationFailure records a panic recovered while generating the chunk
+// at the position passed, logs it and calls Config.OnGenerationFailure. On top
+// of the error logged for every failure, a throttled warning is emitted that
+// points operators at the generator. Chunks failing generation are left empty,
+// so this helps operators find generator bugs that would otherwise only leave
+// holes in the world.
+func (w *World) handleGenerationFailure(pos ChunkPos, r any) {
+	err := fmt.Errorf("generate chunk: panic: %v", r)
+
+	w.genFailMu.Lock()
+	if w.genFailures == nil {
+		w.genFailures = make(map[ChunkPos]int)
+	}
+	w.genFailures[pos]++
+	failures := w.genFailures[pos]
+	w.genFailMu.Unlock()
+
+	w.conf.Log.Error("generate chunk: panic", "error", fmt.Sprint(r), "X", pos[0], "Z", pos[1], "failures", failures)
+	now := time.Now().UnixNano()
+	last := w.lastGenFailureLog.Load()
+	if (last == 0 || time.Duration(now-last) >= time.Minute) && w.lastGenFailureLog.CompareAndSwap(last, now) {
+		w.conf.Log.Warn("Chunk generation failed, leaving the chunk empty. The generator may be faulty.")
+	}
+	if w.conf.OnGenerationFailure != nil {
+		w.conf.OnGenerationFailure(pos, failures, err)
+	}
+}
+
+// clearGenerationFailures forgets the generation failures of the chunk at the
+// position passed after it was generated successfully.
+func (w *World) clearGenerationFailures(pos ChunkPos) {
+	w.genFailMu.Lock()
+	defer w.genFailMu.Unlock()
+	delete(w.genFailures, pos)
+}
+
+// GenerationFailures returns the number of times generating the chunk at the
+// position passed failed because the Generator panicked. The failures of a
+// chunk are forgotten once it is generated successfully.
+func (w *World) GenerationFailures(pos ChunkPos) int {
+	w.genFailMu.Lock()
+	defer w.genFailMu.Unlock()
+	return w.genFailures[pos]
+}
+
+// handleCrowdedChunk handles a chunk holding more entities than
+// Config.ChunkEntityLimit by logging a throttled warning and calling
+// Config.OnChunkEntityLimit.
+func (w *World) handleCrowdedChunk(tx *Tx, pos ChunkPos) {
+	c, ok := w.chunks[pos]
+	if !ok || len(c.Entities) <= w.conf.ChunkEntityLimit {
+		return
+	}
+	if now, last := time.Now().UnixNano(), w.lastCrowdedChunkLog.Load(); last == 0 || time.Duration(now-last) >= time.Minute {
+		w.lastCrowdedChunkLog.Store(now)
+		w.conf.Log.Warn("chunk holds too many entities: consider removing entities or raising the chunk entity limit.",
+			"X", pos[0],
+			"Z", pos[1],
+			"entities", len(c.Entities),
+			"limit", w.conf.ChunkEntityLimit,
+		)
+	}
+	if w.conf.OnChunkEntityLimit != nil {
+		w.conf.OnChunkEntityLimit(tx, pos, slices.Clone(c.Entities))
+	}
+}
+
 // calculateLight calculates the light in the chunk passed and spreads the
 // light of any surrounding neighbours if they have all chunks loaded around it
 // as a result of the one passed.
 func (w *World) calculateLight(centre ChunkPos) {
+	if w.conf.SkipLighting {
+		return
+	}
 	for x := int32(-1); x <= 1; x++ {
 		for z := int32(-1); z <= 1; z++ {
 			// For all the neighbours of this chunk, if they exist, check if all
@@ -1615,6 +2861,9 @@ func (w *World) calculateLight(centre ChunkPos) {
 // spreadLight spreads the light from the chunk passed at the position passed
 // to all neighbours if each of them is loaded.
 func (w *World) spreadLight(pos ChunkPos) {
+	if w.conf.SkipLighting {
+		return
+	}
 	c := make([]*chunk.Chunk, 0, 9)
 	for z := int32(-1); z <= 1; z++ {
 		for x := int32(-1); x <= 1; x++ {
@@ -1652,7 +2901,11 @@ func (w *World) autoSave() {
 		case <-closeUnused.C:
 			<-w.Exec(w.closeUnusedChunks)
 		case <-save.C:
-			w.Save()
+			if w.conf.MaxChunkSavesPerTick > 0 && !w.readOnly.Load() {
+				<-w.Exec(w.queueSave)
+				continue
+			}
+			w.saveNow(true)
 		case <-w.closing:
 			w.running.Done()
 			return
@@ -1661,10 +2914,13 @@ func (w *World) autoSave() {
 }
 
 // CollectGarbage closes chunks that have no viewers and returns the number of
-// chunks, entities and block entities that were removed as a result.
+// chunks, entities and block entities that were removed as a result. Modified
+// chunks are not closed while the World is read-only, as their changes could
+// not be saved.
 func (w *World) CollectGarbage(tx *Tx) (chunksCollected, entitiesCollected, blockEntitiesCollected int) {
+	forced := w.forcedChunks()
 	for pos, c := range w.chunks {
-		if len(c.viewers) != 0 || len(c.loaders) != 0 {
+		if len(c.viewers) != 0 || len(c.loaders) != 0 || forced(pos) || w.holdUnsaved(c) {
 			continue
 		}
 		chunksCollected++
@@ -1692,6 +2948,12 @@ type Column struct {
 	viewers map[Viewer]struct{}
 	loaders []*Loader
 
+	// inhabitedTime is the number of ticks that the column was simulated.
+	inhabitedTime int64
+	// inhabitedTimeChanged is set every inhabitedTimeSaveInterval ticks
+	// that the column is simulated, until its inhabited time was saved.
+	inhabitedTimeChanged bool
+
 	ready      atomic.Bool
 	readyCh    chan struct{}
 	lightOnce  sync.Once
@@ -1810,9 +3072,15 @@ func (c *Column) markReady() {
 	close(c.readyCh)
 }
 
-// ensureLight fills and spreads light for the Column once.
+// ensureLight fills and spreads light for the Column once. If
+// Config.SkipLighting is set, the Column is given full skylight instead.
 func (c *Column) ensureLight(w *World, pos ChunkPos) {
 	c.lightOnce.Do(func() {
+		if w.conf.SkipLighting {
+			c.FillFullLight()
+			c.lightReady.Store(true)
+			return
+		}
 		chunk.LightArea([]*chunk.Chunk{c.Chunk}, int(pos[0]), int(pos[1])).Fill()
 		c.lightReady.Store(true)
 		w.calculateLight(pos)
@@ -1829,6 +3097,7 @@ func (w *World) columnTo(col *Column, pos ChunkPos) *chunk.Column {
 		BlockEntities:   make([]chunk.BlockEntity, 0, len(col.BlockEntities)),
 		ScheduledBlocks: make([]chunk.ScheduledBlockUpdate, 0, len(scheduled)),
 		Tick:            w.scheduledUpdates.currentTick,
+		InhabitedTime:   col.inhabitedTime,
 	}
 	for _, e := range col.Entities {
 		if e.t.EncodeEntity() == "minecraft:player" {
@@ -1841,7 +3110,7 @@ func (w *World) columnTo(col *Column, pos ChunkPos) *chunk.Column {
 		data := e.encodeNBT()
 		maps.Copy(data, e.t.EncodeNBT(&e.data))
 		data["identifier"] = e.t.EncodeEntity()
-		c.Entities = append(c.Entities, chunk.Entity{ID: int64(binary.LittleEndian.Uint64(e.id[8:])), Data: data})
+		c.Entities = append(c.Entities, chunk.Entity{ID: e.persistentID(), Data: data})
 	}
 	for pos, be := range col.BlockEntities {
 		c.BlockEntities = append(c.BlockEntities, chunk.BlockEntity{Pos: pos, Data: be.(NBTer).EncodeNBT()})
@@ -1852,10 +3121,60 @@ func (w *World) columnTo(col *Column, pos ChunkPos) *chunk.Column {
 	return c
 }
 
+// repairChunk removes the block entities of the loaded chunk at the position
+// passed for which the block at their position does not have a block entity,
+// such as blocks that were replaced with air without removing their block
+// entity. The number of block entities removed is returned.
+func (w *World) repairChunk(pos ChunkPos) int {
+	c, ok := w.chunks[pos]
+	if !ok {
+		return 0
+	}
+	removed := 0
+	for bePos := range c.BlockEntities {
+		if bePos.OutOfBounds(w.Range()) || chunkPosFromBlockPos(bePos) != pos || !nbtBlocks[c.Block(uint8(bePos[0]), int16(bePos[1]), uint8(bePos[2]), 0)] {
+			delete(c.BlockEntities, bePos)
+			removed++
+		}
+	}
+	if removed > 0 {
+		c.modified = true
+		w.conf.Log.Warn("repair chunk: removed orphaned block entities", "X", pos[0], "Z", pos[1], "count", removed)
+	}
+	return removed
+}
+
+// markChunkModified marks the loaded chunk at the position passed as modified,
+// so that it is written to the Provider when it is next saved.
+func (w *World) markChunkModified(pos ChunkPos) {
+	if c, ok := w.chunks[pos]; ok {
+		c.modified = true
+	}
+}
+
+// exportColumn converts the loaded chunk at the position passed to a
+// chunk.Column, like columnTo, but copies the chunk data so that the
+// chunk.Column returned may be used after the transaction ends.
+func (w *World) exportColumn(pos ChunkPos) (*chunk.Column, bool) {
+	c, ok := w.chunks[pos]
+	if !ok || !c.Ready() {
+		return nil, false
+	}
+	col := w.columnTo(c, pos)
+	cp, err := chunk.DiskDecode(chunk.Encode(c.Chunk, chunk.DiskEncoding), w.Range())
+	if err != nil {
+		w.conf.Log.Error("export column: "+err.Error(), "X", pos[0], "Z", pos[1])
+		return nil, false
+	}
+	col.Chunk = cp
+	return col, true
+}
+
 // columnFrom converts a chunk.Column to a Column after reading it from a
 // provider.
-func (w *World) columnFrom(c *chunk.Column, _ ChunkPos) *Column {
+func (w *World) columnFrom(c *chunk.Column, pos ChunkPos) *Column {
 	col := newColumn(c.Chunk)
+	col.inhabitedTime = c.InhabitedTime
 	col.Entities = make([]*EntityHandle, 0, len(c.Entities))
 	col.BlockEntities = make(map[cube.Pos]Block, len(c.BlockEntities))
 	for _, e := range c.Entities {
@@ -1870,6 +3189,11 @@ func (w *World) columnFrom(c *chunk.Column, _ ChunkPos) *Column {
 			continue
 		}
 		t, ok := w.conf.Entities.Lookup(eid)
+		if !ok && w.conf.EntityRemap != nil {
+			if remapped, rok := w.conf.EntityRemap(eid); rok {
+				t, ok = w.conf.Entities.Lookup(remapped)
+			}
+		}
 		if !ok {
 			w.conf.Log.Error("read column: unknown entity type", "ID", e.ID, "type", eid)
 			continue
@@ -1877,6 +3201,10 @@ func (w *World) columnFrom(c *chunk.Column, _ ChunkPos) *Column {
 		col.Entities = append(col.Entities, entityFromData(t, e.ID, e.Data))
 	}
 	for _, be := range c.BlockEntities {
+		if be.Pos.OutOfBounds(w.Range()) || chunkPosFromBlockPos(be.Pos) != pos {
+			w.conf.Log.Error("read column: block entity outside of chunk", "pos", be.Pos)
+			continue
+		}
 		rid := c.Chunk.Block(uint8(be.Pos[0]), int16(be.Pos[1]), uint8(be.Pos[2]), 0)
 		b, ok := BlockByRuntimeID(rid)
 		if !ok {